@@ -0,0 +1,36 @@
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/image"
+)
+
+type Diff struct {
+	OldRef   string
+	NewRef   string
+	Registry image.Registry
+}
+
+func (d Diff) Run(ctx context.Context) (*declcfg.ChangeSet, error) {
+	oldCfg, err := d.render(ctx, d.OldRef)
+	if err != nil {
+		return nil, fmt.Errorf("render old reference: %v", err)
+	}
+	newCfg, err := d.render(ctx, d.NewRef)
+	if err != nil {
+		return nil, fmt.Errorf("render new reference: %v", err)
+	}
+	cs := declcfg.Diff(*oldCfg, *newCfg)
+	return &cs, nil
+}
+
+func (d Diff) render(ctx context.Context, ref string) (*declcfg.DeclarativeConfig, error) {
+	r := Render{
+		Refs:     []string{ref},
+		Registry: d.Registry,
+	}
+	return r.Run(ctx)
+}
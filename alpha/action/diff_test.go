@@ -0,0 +1,72 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	type spec struct {
+		name        string
+		diff        Diff
+		expectedOut string
+		expectedErr string
+	}
+
+	specs := []spec{
+		{
+			name: "Success/NoChanges",
+			diff: Diff{
+				OldRef: "testdata/index-declcfgs/latest",
+				NewRef: "testdata/index-declcfgs/latest",
+			},
+			expectedOut: "",
+		},
+		{
+			name: "Success/Changes",
+			diff: Diff{
+				OldRef: "testdata/index-declcfgs/old",
+				NewRef: "testdata/index-declcfgs/latest",
+			},
+			expectedOut: `package "bar" modified: defaultChannel
+channel "bar/alpha" modified: entries
+  entry "bar.v1.0.0" added: replaces "bar.v0.2.0"
+channel "bar/stable" added
+  entry "bar.v1.0.0" added
+channel "baz/stable" modified: entries
+  entry "baz.v1.1.0" added: replaces "baz.v1.0.0", skips ["baz.v1.0.1"]
+channel "foo/beta" modified: entries
+  entry "foo.v0.3.1" added: replaces "foo.v0.2.0", skips ["foo.v0.3.0"]
+bundle "bar/bar.v1.0.0" added
+bundle "baz/baz.v1.1.0" added
+bundle "foo/foo.v0.3.1" added
+`,
+		},
+		{
+			name: "Error/UnknownOldRef",
+			diff: Diff{
+				OldRef: "unknown-index",
+				NewRef: "testdata/index-declcfgs/latest",
+			},
+			expectedErr: `render old reference: render reference "unknown-index": failed to pull image "unknown-index": repository name must be canonical`,
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			cs, err := s.diff.Run(context.Background())
+			if s.expectedErr != "" {
+				require.Nil(t, cs)
+				require.EqualError(t, err, s.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, cs.WriteText(buf))
+			require.Equal(t, s.expectedOut, buf.String())
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// ErrNotFormatted is returned by Fmt.Run in check mode when one or more files
+// of the catalog are not in canonical form.
+var ErrNotFormatted = errors.New("catalog is not formatted")
+
+// Fmt rewrites the file-based catalog in CatalogDir in a canonical,
+// deterministic form, distributing its objects into files according to
+// Layout.
+type Fmt struct {
+	CatalogDir string
+	Layout     declcfg.Layout
+	WriteFunc  declcfg.WriteFunc
+	FileExt    string
+
+	// Check disables writing. Instead, Run returns ErrNotFormatted if any
+	// files would be created, changed or removed.
+	Check bool
+}
+
+// Run formats the catalog and returns the sorted, slash-separated paths
+// (relative to CatalogDir) of the files that were (or, in check mode, would
+// be) created, changed or removed.
+func (f Fmt) Run(ctx context.Context) ([]string, error) {
+	root := os.DirFS(f.CatalogDir)
+
+	cfg := &declcfg.DeclarativeConfig{}
+	existing := map[string][]byte{}
+	if err := declcfg.WalkFS(root, func(path string, fcfg *declcfg.DeclarativeConfig, err error) error {
+		if err != nil {
+			return fmt.Errorf("load %q: %v", path, err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data, err := fs.ReadFile(root, path)
+		if err != nil {
+			return err
+		}
+		existing[path] = data
+		cfg.Merge(fcfg)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	canonicalize(cfg)
+
+	files, err := declcfg.SplitByLayout(*cfg, f.Layout, f.FileExt)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := map[string][]byte{}
+	for name, fcfg := range files {
+		buf := &bytes.Buffer{}
+		if err := f.WriteFunc(*fcfg, buf); err != nil {
+			return nil, fmt.Errorf("write %q: %v", name, err)
+		}
+		desired[name] = buf.Bytes()
+	}
+
+	changed := sets.New[string]()
+	for name, data := range desired {
+		if cur, ok := existing[name]; !ok || !bytes.Equal(cur, data) {
+			changed.Insert(name)
+		}
+	}
+	for name := range existing {
+		if _, ok := desired[name]; !ok {
+			changed.Insert(name)
+		}
+	}
+	paths := sets.List(changed)
+
+	if f.Check {
+		if len(paths) > 0 {
+			return paths, ErrNotFormatted
+		}
+		return paths, nil
+	}
+
+	for _, name := range paths {
+		filename := filepath.Join(f.CatalogDir, filepath.FromSlash(name))
+		data, ok := desired[name]
+		if !ok {
+			if err := os.Remove(filename); err != nil {
+				return nil, err
+			}
+			if err := removeEmptyParents(f.CatalogDir, filepath.Dir(filename)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filename, data, 0666); err != nil {
+			return nil, fmt.Errorf("write file %q: %v", filename, err)
+		}
+	}
+	return paths, nil
+}
+
+// canonicalize sorts the parts of cfg whose order is not already fixed by
+// the declcfg writers, so that loading the same catalog always produces the
+// same output.
+func canonicalize(cfg *declcfg.DeclarativeConfig) {
+	for _, c := range cfg.Channels {
+		sort.SliceStable(c.Entries, func(i, j int) bool {
+			return c.Entries[i].Name < c.Entries[j].Name
+		})
+	}
+	sort.SliceStable(cfg.Others, func(i, j int) bool {
+		a, b := cfg.Others[i], cfg.Others[j]
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return string(a.Blob) < string(b.Blob)
+	})
+}
+
+// removeEmptyParents removes dir and its parents up to, but not including,
+// root, stopping at the first directory that is not empty.
+func removeEmptyParents(root, dir string) error {
+	root = filepath.Clean(root)
+	for dir = filepath.Clean(dir); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return nil
+		}
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package action
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+func TestFmt(t *testing.T) {
+	catalogDir := t.TempDir()
+	writeTestFile := func(name, content string) {
+		filename := filepath.Join(catalogDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0777))
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0666))
+	}
+	writeTestFile("index.yaml", `---
+schema: olm.package
+name: foo
+defaultChannel: stable
+---
+schema: olm.channel
+package: foo
+name: stable
+entries:
+  - name: foo.v0.2.0
+    replaces: foo.v0.1.0
+  - name: foo.v0.1.0
+---
+schema: olm.bundle
+package: foo
+name: foo.v0.2.0
+image: foo-bundle:v0.2.0
+properties:
+  - type: olm.package
+    value: {packageName: foo, version: 0.2.0}
+`)
+	writeTestFile("bundles/foo.v0.1.0.json", `{"schema": "olm.bundle", "package": "foo", "name": "foo.v0.1.0", "image": "foo-bundle:v0.1.0", "properties": [{"type": "olm.package", "value": {"packageName": "foo", "version": "0.1.0"}}]}`)
+	writeTestFile(".indexignore", "README.md\n")
+	writeTestFile("README.md", "not a catalog file\n")
+
+	expectedCatalog := `---
+defaultChannel: stable
+name: foo
+schema: olm.package
+---
+entries:
+- name: foo.v0.1.0
+- name: foo.v0.2.0
+  replaces: foo.v0.1.0
+name: stable
+package: foo
+schema: olm.channel
+---
+image: foo-bundle:v0.1.0
+name: foo.v0.1.0
+package: foo
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.1.0
+schema: olm.bundle
+---
+image: foo-bundle:v0.2.0
+name: foo.v0.2.0
+package: foo
+properties:
+- type: olm.package
+  value:
+    packageName: foo
+    version: 0.2.0
+schema: olm.bundle
+`
+
+	newFmt := func(check bool) Fmt {
+		return Fmt{
+			CatalogDir: catalogDir,
+			Layout:     declcfg.LayoutPackage,
+			WriteFunc:  declcfg.WriteYAML,
+			FileExt:    ".yaml",
+			Check:      check,
+		}
+	}
+	expectedPaths := []string{"bundles/foo.v0.1.0.json", "foo/catalog.yaml", "index.yaml"}
+
+	// Check mode reports the non-canonical files without touching them.
+	paths, err := newFmt(true).Run(context.Background())
+	require.ErrorIs(t, err, ErrNotFormatted)
+	require.Equal(t, expectedPaths, paths)
+	require.FileExists(t, filepath.Join(catalogDir, "index.yaml"))
+	require.NoFileExists(t, filepath.Join(catalogDir, "foo", "catalog.yaml"))
+
+	// Formatting rewrites the catalog and removes files and directories
+	// that no longer contain any objects.
+	paths, err = newFmt(false).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expectedPaths, paths)
+	require.NoFileExists(t, filepath.Join(catalogDir, "index.yaml"))
+	require.NoDirExists(t, filepath.Join(catalogDir, "bundles"))
+	require.FileExists(t, filepath.Join(catalogDir, "README.md"))
+	actual, err := os.ReadFile(filepath.Join(catalogDir, "foo", "catalog.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, expectedCatalog, string(actual))
+
+	// A formatted catalog passes the check.
+	paths, err = newFmt(true).Run(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
@@ -15,6 +15,10 @@ import (
 
 	"github.com/h2non/filetype"
 	"github.com/h2non/filetype/matchers"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/operator-framework/operator-registry/alpha/action/migrations"
@@ -31,6 +35,8 @@ import (
 
 var logDeprecationMessage sync.Once
 
+var tracer = otel.Tracer("github.com/operator-framework/operator-registry/alpha/action")
+
 type RefType uint
 
 const (
@@ -61,6 +67,9 @@ type Render struct {
 }
 
 func (r Render) Run(ctx context.Context) (*declcfg.DeclarativeConfig, error) {
+	ctx, span := tracer.Start(ctx, "action.Render")
+	defer span.End()
+
 	if r.skipSqliteDeprecationLog {
 		// exhaust once with a no-op function.
 		logDeprecationMessage.Do(func() {})
@@ -159,9 +168,20 @@ func (r Render) renderReference(ctx context.Context, ref string) (*declcfg.Decla
 	return sqliteToDeclcfg(ctx, db)
 }
 
+func (r Render) pull(ctx context.Context, ref image.Reference) error {
+	ctx, span := tracer.Start(ctx, "image.Pull", trace.WithAttributes(attribute.String("image.ref", ref.String())))
+	defer span.End()
+	if err := r.Registry.Pull(ctx, ref); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
 func (r Render) imageToDeclcfg(ctx context.Context, imageRef string) (*declcfg.DeclarativeConfig, error) {
 	ref := image.SimpleReference(imageRef)
-	if err := r.Registry.Pull(ctx, ref); err != nil {
+	if err := r.pull(ctx, ref); err != nil {
 		return nil, fmt.Errorf("failed to pull image %q: %v", ref, err)
 	}
 	labels, err := r.Registry.Labels(ctx, ref)
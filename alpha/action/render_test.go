@@ -479,6 +479,7 @@ func TestRender(t *testing.T) {
 				Packages: []declcfg.Package{
 					{
 						Schema:         "olm.package",
+						Location:       declcfg.Location{Path: "index.yaml", Line: 2},
 						Name:           "foo",
 						DefaultChannel: "beta",
 						Properties: []property.Property{
@@ -487,7 +488,7 @@ func TestRender(t *testing.T) {
 					},
 				},
 				Channels: []declcfg.Channel{
-					{Schema: "olm.channel", Package: "foo", Name: "beta", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "beta", Location: declcfg.Location{Path: "index.yaml", Line: 11}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.1.0", SkipRange: "<0.1.0"},
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					},
@@ -495,16 +496,17 @@ func TestRender(t *testing.T) {
 							{Type: "user", Value: json.RawMessage("{\"group\":\"xyz.com\",\"name\":\"account\"}")},
 						},
 					},
-					{Schema: "olm.channel", Package: "foo", Name: "stable", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "stable", Location: declcfg.Location{Path: "index.yaml", Line: 29}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					}},
 				},
 				Bundles: []declcfg.Bundle{
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.1.0",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.1.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.1.0",
+						Location: declcfg.Location{Path: "index.yaml", Line: 40},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.1.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -526,10 +528,11 @@ func TestRender(t *testing.T) {
 						Objects: []string{string(foov1csv), string(foov1crd)},
 					},
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.2.0",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.2.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.2.0",
+						Location: declcfg.Location{Path: "index.yaml", Line: 74},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.2.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -577,6 +580,7 @@ func TestRender(t *testing.T) {
 				Packages: []declcfg.Package{
 					{
 						Schema:         "olm.package",
+						Location:       declcfg.Location{Path: "foo/index.yaml", Line: 2},
 						Name:           "foo",
 						DefaultChannel: "beta",
 						Properties: []property.Property{
@@ -585,7 +589,7 @@ func TestRender(t *testing.T) {
 					},
 				},
 				Channels: []declcfg.Channel{
-					{Schema: "olm.channel", Package: "foo", Name: "beta", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "beta", Location: declcfg.Location{Path: "foo/index.yaml", Line: 11}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.1.0", SkipRange: "<0.1.0"},
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					},
@@ -593,16 +597,17 @@ func TestRender(t *testing.T) {
 							{Type: "user", Value: json.RawMessage("{\"group\":\"xyz.com\",\"name\":\"account\"}")},
 						},
 					},
-					{Schema: "olm.channel", Package: "foo", Name: "stable", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "stable", Location: declcfg.Location{Path: "foo/index.yaml", Line: 29}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					}},
 				},
 				Bundles: []declcfg.Bundle{
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.1.0",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.1.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.1.0",
+						Location: declcfg.Location{Path: "foo/index.yaml", Line: 40},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.1.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -624,10 +629,11 @@ func TestRender(t *testing.T) {
 						Objects: []string{string(foov1csv), string(foov1crd)},
 					},
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.2.0",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.2.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.2.0",
+						Location: declcfg.Location{Path: "foo/index.yaml", Line: 74},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.2.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -676,6 +682,7 @@ func TestRender(t *testing.T) {
 				Packages: []declcfg.Package{
 					{
 						Schema:         "olm.package",
+						Location:       declcfg.Location{Path: "index.yaml", Line: 2},
 						Name:           "foo",
 						DefaultChannel: "beta",
 						Properties: []property.Property{
@@ -684,7 +691,7 @@ func TestRender(t *testing.T) {
 					},
 				},
 				Channels: []declcfg.Channel{
-					{Schema: "olm.channel", Package: "foo", Name: "beta", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "beta", Location: declcfg.Location{Path: "index.yaml", Line: 11}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.1.0", SkipRange: "<0.1.0"},
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					},
@@ -692,16 +699,17 @@ func TestRender(t *testing.T) {
 							{Type: "user", Value: json.RawMessage("{\"group\":\"xyz.com\",\"name\":\"account\"}")},
 						},
 					},
-					{Schema: "olm.channel", Package: "foo", Name: "stable", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "stable", Location: declcfg.Location{Path: "index.yaml", Line: 29}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					}},
 				},
 				Bundles: []declcfg.Bundle{
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.1.0-MIGRATED",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.1.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.1.0-MIGRATED",
+						Location: declcfg.Location{Path: "index.yaml", Line: 40},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.1.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -723,10 +731,11 @@ func TestRender(t *testing.T) {
 						Objects: []string{string(foov1csv), string(foov1crd)},
 					},
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.2.0-MIGRATED",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.2.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.2.0-MIGRATED",
+						Location: declcfg.Location{Path: "index.yaml", Line: 74},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.2.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -775,6 +784,7 @@ func TestRender(t *testing.T) {
 				Packages: []declcfg.Package{
 					{
 						Schema:         "olm.package",
+						Location:       declcfg.Location{Path: "foo/index.yaml", Line: 2},
 						Name:           "foo",
 						DefaultChannel: "beta",
 						Properties: []property.Property{
@@ -783,7 +793,7 @@ func TestRender(t *testing.T) {
 					},
 				},
 				Channels: []declcfg.Channel{
-					{Schema: "olm.channel", Package: "foo", Name: "beta", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "beta", Location: declcfg.Location{Path: "foo/index.yaml", Line: 11}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.1.0", SkipRange: "<0.1.0"},
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					},
@@ -791,16 +801,17 @@ func TestRender(t *testing.T) {
 							{Type: "user", Value: json.RawMessage("{\"group\":\"xyz.com\",\"name\":\"account\"}")},
 						},
 					},
-					{Schema: "olm.channel", Package: "foo", Name: "stable", Entries: []declcfg.ChannelEntry{
+					{Schema: "olm.channel", Package: "foo", Name: "stable", Location: declcfg.Location{Path: "foo/index.yaml", Line: 29}, Entries: []declcfg.ChannelEntry{
 						{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", SkipRange: "<0.2.0", Skips: []string{"foo.v0.1.1", "foo.v0.1.2"}},
 					}},
 				},
 				Bundles: []declcfg.Bundle{
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.1.0-MIGRATED",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.1.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.1.0-MIGRATED",
+						Location: declcfg.Location{Path: "foo/index.yaml", Line: 40},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.1.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
@@ -822,10 +833,11 @@ func TestRender(t *testing.T) {
 						Objects: []string{string(foov1csv), string(foov1crd)},
 					},
 					{
-						Schema:  "olm.bundle",
-						Name:    "foo.v0.2.0-MIGRATED",
-						Package: "foo",
-						Image:   "test.registry/foo-operator/foo-bundle:v0.2.0",
+						Schema:   "olm.bundle",
+						Name:     "foo.v0.2.0-MIGRATED",
+						Location: declcfg.Location{Path: "foo/index.yaml", Line: 74},
+						Package:  "foo",
+						Image:    "test.registry/foo-operator/foo-bundle:v0.2.0",
 						Properties: []property.Property{
 							property.MustBuildGVK("test.foo", "v1", "Foo"),
 							property.MustBuildGVKRequired("test.bar", "v1alpha1", "Bar"),
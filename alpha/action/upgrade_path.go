@@ -0,0 +1,306 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/alpha/model"
+	"github.com/operator-framework/operator-registry/alpha/model/graph"
+	"github.com/operator-framework/operator-registry/pkg/image"
+)
+
+// ErrNoUpgradePath is returned by UpgradePath.Run when the installed version
+// cannot be upgraded to the head of the channel. The result is returned along
+// with the error.
+var ErrNoUpgradePath = errors.New("no upgrade path to channel head")
+
+// UpgradePath computes the sequence of bundles that OLM would install to
+// upgrade a package from an installed version to the head of a channel.
+//
+// At each step, the candidate upgrades of the current bundle are the bundles
+// in the channel that replace or skip it, which are the upgrades returned by
+// the registry's GetBundleThatReplaces and GetChannelEntriesThatReplace
+// queries. Like OLM, the candidate that is closest to the channel head is
+// chosen. Ties are broken by the higher version and then by bundle name.
+type UpgradePath struct {
+	CatalogRef       string
+	Package          string
+	Channel          string
+	InstalledVersion string
+	// AllPaths also computes every upgrade path from the installed version
+	// to the channel head.
+	AllPaths bool
+	// IncludeSkipRange also treats the bundles whose skipRange includes the
+	// version of the current bundle as candidate upgrades, as OLM's resolver
+	// does. The registry queries do not report these upgrades. An installed
+	// version that is not in the channel can only be upgraded through a
+	// skipRange.
+	IncludeSkipRange bool
+	Registry         image.Registry
+}
+
+// UpgradeStep is a bundle installed during an upgrade, and the reason it is
+// an upgrade of the previously installed bundle.
+type UpgradeStep struct {
+	Bundle  string         `json:"bundle"`
+	Version string         `json:"version"`
+	Via     graph.EdgeKind `json:"via"`
+}
+
+func (s UpgradeStep) String() string {
+	return fmt.Sprintf("%s (%s) via %s", s.Bundle, s.Version, s.Via)
+}
+
+type UpgradePathResult struct {
+	Package          string `json:"package"`
+	Channel          string `json:"channel"`
+	InstalledVersion string `json:"installedVersion"`
+	// Installed is the name of the installed bundle. It is empty if the
+	// installed version is not in the channel.
+	Installed string `json:"installed,omitempty"`
+	Head      string `json:"head"`
+	// Path is the sequence of bundles OLM installs to reach the channel
+	// head. It is empty if the installed bundle is the channel head, or if
+	// there is no upgrade path.
+	Path []UpgradeStep `json:"path"`
+	// AllPaths is every upgrade path to the channel head, sorted by length.
+	// It is only set if UpgradePath.AllPaths is set.
+	AllPaths [][]UpgradeStep `json:"allPaths,omitempty"`
+}
+
+func (u UpgradePath) Run(ctx context.Context) (*UpgradePathResult, error) {
+	installedVersion, err := semver.ParseTolerant(u.InstalledVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parse installed version %q: %v", u.InstalledVersion, err)
+	}
+
+	m, err := u.render(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := m[u.Package]
+	if !ok {
+		return nil, fmt.Errorf("package %q not found", u.Package)
+	}
+	ch, ok := pkg.Channels[u.Channel]
+	if !ok {
+		return nil, fmt.Errorf("package %q, channel %q not found", u.Package, u.Channel)
+	}
+	head, err := ch.Head()
+	if err != nil {
+		return nil, fmt.Errorf("package %q, channel %q: %v", u.Package, u.Channel, err)
+	}
+	var opts []graph.Option
+	if !u.IncludeSkipRange {
+		opts = append(opts, graph.WithoutSkipRange())
+	}
+	g, err := graph.New(ch, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("package %q, channel %q: %v", u.Package, u.Channel, err)
+	}
+
+	installed, err := g.BundleForVersion(installedVersion)
+	if err != nil && !errors.Is(err, graph.ErrNoBundle) {
+		return nil, fmt.Errorf("package %q: %v", u.Package, err)
+	}
+
+	res := &UpgradePathResult{
+		Package:          u.Package,
+		Channel:          u.Channel,
+		InstalledVersion: installedVersion.String(),
+		Installed:        installed,
+		Head:             head.Name,
+		Path:             []UpgradeStep{},
+	}
+
+	// The first upgrades of a version that is not in the channel can only
+	// come from skipRanges, since replaces and skips refer to bundle names.
+	var first []graph.Edge
+	if installed != "" {
+		first = g.Upgrades(installed)
+	} else if u.IncludeSkipRange {
+		first, err = skipRangeUpgrades(ch, installedVersion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if u.AllPaths {
+		if res.AllPaths, err = allUpgradePaths(g, ch, installed, first, head.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if installed == head.Name {
+		return res, nil
+	}
+	for candidates := first; ; {
+		next, ok := closestUpgrade(g, candidates, head.Name)
+		if !ok {
+			res.Path = []UpgradeStep{}
+			return res, ErrNoUpgradePath
+		}
+		res.Path = append(res.Path, upgradeStep(ch, next))
+		if next.To == head.Name {
+			return res, nil
+		}
+		candidates = g.Upgrades(next.To)
+	}
+}
+
+func (u UpgradePath) render(ctx context.Context) (model.Model, error) {
+	r := Render{
+		Refs:           []string{u.CatalogRef},
+		AllowedRefMask: RefDCImage | RefDCDir | RefSqliteImage | RefSqliteFile,
+		Registry:       u.Registry,
+	}
+	cfg, err := r.Run(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNotAllowed) {
+			return nil, fmt.Errorf("cannot compute upgrade path of non-index %q", u.CatalogRef)
+		}
+		return nil, err
+	}
+	return declcfg.ConvertToModel(*cfg)
+}
+
+// skipRangeUpgrades returns edges from a bundle that is not in ch, with
+// version v, to the bundles of ch whose skipRange includes v.
+func skipRangeUpgrades(ch *model.Channel, v semver.Version) ([]graph.Edge, error) {
+	var edges []graph.Edge
+	for _, b := range ch.Bundles {
+		if b.SkipRange == "" {
+			continue
+		}
+		skipRange, err := semver.ParseRange(b.SkipRange)
+		if err != nil {
+			return nil, fmt.Errorf("bundle %q: parse skipRange %q: %v", b.Name, b.SkipRange, err)
+		}
+		if skipRange(v) {
+			edges = append(edges, graph.Edge{To: b.Name, Kind: graph.EdgeSkipRange})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].To < edges[j].To
+	})
+	return edges, nil
+}
+
+// closestUpgrade returns the candidate upgrade whose target has the shortest
+// upgrade path to head, breaking ties by the higher version and then by
+// bundle name. Following it always reduces the distance to head, so repeated
+// calls terminate even if the channel has cycles.
+func closestUpgrade(g *graph.Graph, candidates []graph.Edge, head string) (graph.Edge, bool) {
+	var (
+		best         graph.Edge
+		bestDistance int
+		found        bool
+	)
+	bundles := g.Channel().Bundles
+	for _, e := range candidates {
+		path, err := g.ShortestPath(e.To, head)
+		if err != nil {
+			continue
+		}
+		d := len(path)
+		switch {
+		case !found, d < bestDistance:
+			best, bestDistance, found = e, d, true
+		case d == bestDistance:
+			if c := bundles[e.To].Version.Compare(bundles[best.To].Version); c > 0 || (c == 0 && e.To < best.To) {
+				best = e
+			}
+		}
+	}
+	return best, found
+}
+
+func allUpgradePaths(g *graph.Graph, ch *model.Channel, installed string, first []graph.Edge, head string) ([][]UpgradeStep, error) {
+	paths := [][]UpgradeStep{}
+	if installed != "" {
+		edgePaths, err := g.Paths(installed, head)
+		if err != nil {
+			return nil, err
+		}
+		for _, edges := range edgePaths {
+			paths = append(paths, upgradeSteps(ch, edges))
+		}
+		return paths, nil
+	}
+
+	for _, e := range first {
+		edgePaths, err := g.Paths(e.To, head)
+		if err != nil {
+			return nil, err
+		}
+		for _, edges := range edgePaths {
+			paths = append(paths, upgradeSteps(ch, append([]graph.Edge{e}, edges...)))
+		}
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i]) < len(paths[j])
+	})
+	return paths, nil
+}
+
+func upgradeSteps(ch *model.Channel, edges []graph.Edge) []UpgradeStep {
+	steps := make([]UpgradeStep, 0, len(edges))
+	for _, e := range edges {
+		steps = append(steps, upgradeStep(ch, e))
+	}
+	return steps
+}
+
+func upgradeStep(ch *model.Channel, e graph.Edge) UpgradeStep {
+	return UpgradeStep{
+		Bundle:  e.To,
+		Version: ch.Bundles[e.To].Version.String(),
+		Via:     e.Kind,
+	}
+}
+
+// WriteText writes a human-readable description of the result to w.
+func (r UpgradePathResult) WriteText(w io.Writer) error {
+	installed := r.Installed
+	if installed == "" {
+		installed = "<not in channel>"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Package:   %s\n", r.Package)
+	fmt.Fprintf(&b, "Channel:   %s\n", r.Channel)
+	fmt.Fprintf(&b, "Installed: %s (%s)\n", installed, r.InstalledVersion)
+	fmt.Fprintf(&b, "Head:      %s\n", r.Head)
+	b.WriteString("\n")
+
+	switch {
+	case r.Installed == r.Head:
+		b.WriteString("The installed bundle is the channel head.\n")
+	case len(r.Path) == 0:
+		b.WriteString("There is no upgrade path to the channel head.\n")
+	default:
+		b.WriteString("Upgrade path:\n")
+		for i, s := range r.Path {
+			fmt.Fprintf(&b, "  %d. %s\n", i+1, s)
+		}
+	}
+
+	if r.AllPaths != nil {
+		fmt.Fprintf(&b, "\nAll upgrade paths (%d):\n", len(r.AllPaths))
+		for i, path := range r.AllPaths {
+			fmt.Fprintf(&b, "  %d. %s", i+1, installed)
+			for _, s := range path {
+				fmt.Fprintf(&b, " -(%s)-> %s", s.Via, s.Bundle)
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,172 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/model/graph"
+)
+
+func TestUpgradePath(t *testing.T) {
+	type spec struct {
+		name        string
+		upgradePath UpgradePath
+		expected    *UpgradePathResult
+		expectedErr string
+	}
+
+	fooPath := func(installed, version string, path []UpgradeStep, allPaths [][]UpgradeStep) *UpgradePathResult {
+		return &UpgradePathResult{
+			Package:          "foo",
+			Channel:          "beta",
+			InstalledVersion: version,
+			Installed:        installed,
+			Head:             "foo.v0.3.1",
+			Path:             path,
+			AllPaths:         allPaths,
+		}
+	}
+	step := func(bundle, version string, via graph.EdgeKind) UpgradeStep {
+		return UpgradeStep{Bundle: bundle, Version: version, Via: via}
+	}
+
+	specs := []spec{
+		{
+			name:        "Success/InstalledInChannel",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "0.1.0"},
+			expected: fooPath("foo.v0.1.0", "0.1.0", []UpgradeStep{
+				step("foo.v0.2.0", "0.2.0", graph.EdgeReplaces),
+				step("foo.v0.3.1", "0.3.1", graph.EdgeReplaces),
+			}, nil),
+		},
+		{
+			name:        "Success/AllPaths",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "v0.1.0", AllPaths: true},
+			expected: fooPath("foo.v0.1.0", "0.1.0", []UpgradeStep{
+				step("foo.v0.2.0", "0.2.0", graph.EdgeReplaces),
+				step("foo.v0.3.1", "0.3.1", graph.EdgeReplaces),
+			}, [][]UpgradeStep{
+				{
+					step("foo.v0.2.0", "0.2.0", graph.EdgeReplaces),
+					step("foo.v0.3.1", "0.3.1", graph.EdgeReplaces),
+				},
+				{
+					step("foo.v0.2.0", "0.2.0", graph.EdgeReplaces),
+					step("foo.v0.3.0", "0.3.0", graph.EdgeReplaces),
+					step("foo.v0.3.1", "0.3.1", graph.EdgeSkips),
+				},
+			}),
+		},
+		{
+			name:        "Success/InstalledNotInChannel",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "0.0.5", AllPaths: true, IncludeSkipRange: true},
+			expected: fooPath("", "0.0.5", []UpgradeStep{
+				step("foo.v0.2.0", "0.2.0", graph.EdgeSkipRange),
+				step("foo.v0.3.1", "0.3.1", graph.EdgeReplaces),
+			}, [][]UpgradeStep{
+				{
+					step("foo.v0.2.0", "0.2.0", graph.EdgeSkipRange),
+					step("foo.v0.3.1", "0.3.1", graph.EdgeReplaces),
+				},
+				{
+					step("foo.v0.1.0", "0.1.0", graph.EdgeSkipRange),
+					step("foo.v0.2.0", "0.2.0", graph.EdgeReplaces),
+					step("foo.v0.3.1", "0.3.1", graph.EdgeReplaces),
+				},
+				{
+					step("foo.v0.2.0", "0.2.0", graph.EdgeSkipRange),
+					step("foo.v0.3.0", "0.3.0", graph.EdgeReplaces),
+					step("foo.v0.3.1", "0.3.1", graph.EdgeSkips),
+				},
+				{
+					step("foo.v0.1.0", "0.1.0", graph.EdgeSkipRange),
+					step("foo.v0.2.0", "0.2.0", graph.EdgeReplaces),
+					step("foo.v0.3.0", "0.3.0", graph.EdgeReplaces),
+					step("foo.v0.3.1", "0.3.1", graph.EdgeSkips),
+				},
+			}),
+		},
+		{
+			name:        "Success/InstalledIsHead",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "0.3.1"},
+			expected:    fooPath("foo.v0.3.1", "0.3.1", []UpgradeStep{}, nil),
+		},
+		{
+			// Like GetBundleThatReplaces, skipRanges are not followed unless
+			// requested.
+			name:        "Error/InstalledNotInChannelWithoutSkipRange",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "0.0.5", AllPaths: true},
+			expected:    fooPath("", "0.0.5", []UpgradeStep{}, [][]UpgradeStep{}),
+			expectedErr: ErrNoUpgradePath.Error(),
+		},
+		{
+			name:        "Error/NoUpgradePath",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "1.0.0"},
+			expected:    fooPath("", "1.0.0", []UpgradeStep{}, nil),
+			expectedErr: ErrNoUpgradePath.Error(),
+		},
+		{
+			name:        "Error/InvalidVersion",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "beta", InstalledVersion: "latest"},
+			expectedErr: `parse installed version "latest": Invalid character(s) found in major number "0latest"`,
+		},
+		{
+			name:        "Error/UnknownPackage",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "qux", Channel: "beta", InstalledVersion: "0.1.0"},
+			expectedErr: `package "qux" not found`,
+		},
+		{
+			name:        "Error/UnknownChannel",
+			upgradePath: UpgradePath{CatalogRef: "testdata/index-declcfgs/latest", Package: "foo", Channel: "stable", InstalledVersion: "0.1.0"},
+			expectedErr: `package "foo", channel "stable" not found`,
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := s.upgradePath.Run(context.Background())
+			if s.expectedErr != "" {
+				require.EqualError(t, err, s.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, s.expected, actual)
+		})
+	}
+}
+
+func TestUpgradePathResultWriteText(t *testing.T) {
+	res := UpgradePathResult{
+		Package:          "foo",
+		Channel:          "beta",
+		InstalledVersion: "0.1.0",
+		Installed:        "foo.v0.1.0",
+		Head:             "foo.v0.3.1",
+		Path: []UpgradeStep{
+			{Bundle: "foo.v0.2.0", Version: "0.2.0", Via: graph.EdgeReplaces},
+			{Bundle: "foo.v0.3.1", Version: "0.3.1", Via: graph.EdgeSkipRange},
+		},
+		AllPaths: [][]UpgradeStep{
+			{
+				{Bundle: "foo.v0.2.0", Version: "0.2.0", Via: graph.EdgeReplaces},
+				{Bundle: "foo.v0.3.1", Version: "0.3.1", Via: graph.EdgeSkipRange},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, res.WriteText(buf))
+	require.Equal(t, `Package:   foo
+Channel:   beta
+Installed: foo.v0.1.0 (0.1.0)
+Head:      foo.v0.3.1
+
+Upgrade path:
+  1. foo.v0.2.0 (0.2.0) via replaces
+  2. foo.v0.3.1 (0.3.1) via skipRange
+
+All upgrade paths (1):
+  1. foo.v0.1.0 -(replaces)-> foo.v0.2.0 -(skipRange)-> foo.v0.3.1
+`, buf.String())
+}
@@ -0,0 +1,105 @@
+package declcfg
+
+import (
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compression describes a compression format of declarative config files,
+// which is detected by the last extension of the file name, e.g.
+// catalog.json.gz or catalog.yaml.zst.
+type compression struct {
+	ext       string
+	newReader func(io.Reader) (io.ReadCloser, error)
+	newWriter func(io.Writer) (io.WriteCloser, error)
+}
+
+var compressions = []compression{
+	{
+		ext: ".gz",
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+	},
+	{
+		ext: ".zst",
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			return dec.IOReadCloser(), nil
+		},
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		},
+	},
+}
+
+// compressionFor returns the compression of the file at path, or nil if the
+// file is not compressed.
+func compressionFor(path string) *compression {
+	for i := range compressions {
+		if strings.HasSuffix(path, compressions[i].ext) {
+			return &compressions[i]
+		}
+	}
+	return nil
+}
+
+// openFile opens the file at path in root. Compressed files are transparently
+// decompressed.
+func openFile(root fs.FS, path string) (io.ReadCloser, error) {
+	file, err := root.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	c := compressionFor(path)
+	if c == nil {
+		return file, nil
+	}
+	r, err := c.newReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &decompressingReadCloser{ReadCloser: r, file: file}, nil
+}
+
+type decompressingReadCloser struct {
+	io.ReadCloser
+	file io.Closer
+}
+
+func (r *decompressingReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	if ferr := r.file.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// compressWriter returns a writer that compresses its input to w if filename
+// has the extension of a supported compression. Otherwise, the returned
+// writer writes to w directly. The returned writer must be closed to flush
+// the compressed data; closing it does not close w.
+func compressWriter(filename string, w io.Writer) (io.WriteCloser, error) {
+	c := compressionFor(filename)
+	if c == nil {
+		return nopWriteCloser{w}, nil
+	}
+	return c.newWriter(w)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,127 @@
+package declcfg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFSCompressed(t *testing.T) {
+	type spec struct {
+		name      string
+		writeFunc WriteFunc
+		fileExt   string
+		magic     []byte
+	}
+	specs := []spec{
+		{
+			name:      "JSONGzip",
+			writeFunc: WriteJSON,
+			fileExt:   ".json.gz",
+			magic:     []byte{0x1f, 0x8b},
+		},
+		{
+			name:      "YAMLZstd",
+			writeFunc: WriteYAML,
+			fileExt:   ".yaml.zst",
+			magic:     []byte{0x28, 0xb5, 0x2f, 0xfd},
+		},
+		{
+			name:      "YAMLGzip",
+			writeFunc: WriteYAML,
+			fileExt:   ".yaml.gz",
+			magic:     []byte{0x1f, 0x8b},
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			cfg := buildValidDeclarativeConfig(validDeclarativeConfigSpec{})
+			dir := t.TempDir()
+			require.NoError(t, WriteFS(cfg, dir, s.writeFunc, s.fileExt))
+
+			path := filepath.Join("anakin", "catalog"+s.fileExt)
+			data, err := os.ReadFile(filepath.Join(dir, path))
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(data, s.magic), "expected compressed file")
+
+			f, err := openFile(os.DirFS(dir), filepath.ToSlash(path))
+			require.NoError(t, err)
+			defer f.Close()
+			actual, err := io.ReadAll(f)
+			require.NoError(t, err)
+
+			expected := &bytes.Buffer{}
+			require.NoError(t, s.writeFunc(DeclarativeConfig{
+				Packages: cfg.Packages[:1],
+				Channels: cfg.Channels[:2],
+				Bundles:  cfg.Bundles[:3],
+			}, expected))
+			require.Equal(t, expected.String(), string(actual))
+
+			loaded, err := LoadFS(context.Background(), os.DirFS(dir))
+			require.NoError(t, err)
+			require.Len(t, loaded.Packages, len(cfg.Packages))
+			require.Len(t, loaded.Bundles, len(cfg.Bundles))
+		})
+	}
+}
+
+func TestWalkMetasFSCompressed(t *testing.T) {
+	plain := []byte(`---
+schema: olm.package
+name: foo
+---
+schema: olm.channel
+package: foo
+name: stable
+entries: []
+`)
+
+	var gz bytes.Buffer
+	gzw := gzip.NewWriter(&gz)
+	_, err := gzw.Write(plain)
+	require.NoError(t, err)
+	require.NoError(t, gzw.Close())
+
+	zw, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	zst := zw.EncodeAll(plain, nil)
+	require.NoError(t, zw.Close())
+
+	fsys := fstest.MapFS{
+		"foo/catalog.yaml.gz":  &fstest.MapFile{Data: gz.Bytes()},
+		"bar/catalog.yaml.zst": &fstest.MapFile{Data: zst},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		locations := map[string]Location{}
+		err := WalkMetasFS(context.Background(), fsys, func(path string, meta *Meta, err error) error {
+			if err != nil {
+				return err
+			}
+			locations[path+" "+meta.Schema] = meta.Location
+			return nil
+		}, WithConcurrency(1))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Location{
+			"foo/catalog.yaml.gz olm.package":  {Path: "foo/catalog.yaml.gz", Line: 2},
+			"foo/catalog.yaml.gz olm.channel":  {Path: "foo/catalog.yaml.gz", Line: 5},
+			"bar/catalog.yaml.zst olm.package": {Path: "bar/catalog.yaml.zst", Line: 2},
+			"bar/catalog.yaml.zst olm.channel": {Path: "bar/catalog.yaml.zst", Line: 5},
+		}, locations)
+	})
+	t.Run("Error/InvalidCompressedData", func(t *testing.T) {
+		invalidFS := fstest.MapFS{"foo/catalog.json.gz": &fstest.MapFile{Data: []byte("not gzip")}}
+		_, err := LoadFS(context.Background(), invalidFS)
+		require.Error(t, err)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"github.com/operator-framework/operator-registry/alpha/model"
 	"github.com/operator-framework/operator-registry/alpha/property"
 )
 
@@ -37,8 +38,15 @@ type Package struct {
 	Icon           *Icon               `json:"icon,omitempty"`
 	Description    string              `json:"description,omitempty"`
 	Properties     []property.Property `json:"properties,omitempty" hash:"set"`
+
+	// Location is the position of the package in the file-based catalog
+	// it was loaded from, if any. It is never persisted in the blob.
+	Location Location `json:"-"`
 }
 
+// Location identifies the file and line from which an object was loaded.
+type Location = model.Location
+
 type Icon struct {
 	Data      []byte `json:"base64data"`
 	MediaType string `json:"mediatype"`
@@ -50,6 +58,10 @@ type Channel struct {
 	Package    string              `json:"package"`
 	Entries    []ChannelEntry      `json:"entries"`
 	Properties []property.Property `json:"properties,omitempty" hash:"set"`
+
+	// Location is the position of the channel in the file-based catalog
+	// it was loaded from, if any. It is never persisted in the blob.
+	Location Location `json:"-"`
 }
 
 type ChannelEntry struct {
@@ -85,6 +97,10 @@ type Bundle struct {
 	// first class fields.
 	CsvJSON string   `json:"-"`
 	Objects []string `json:"-"`
+
+	// Location is the position of the bundle in the file-based catalog
+	// it was loaded from, if any. It is never persisted in the blob.
+	Location Location `json:"-"`
 }
 
 type RelatedImage struct {
@@ -96,6 +112,10 @@ type Deprecation struct {
 	Schema  string             `json:"schema"`
 	Package string             `json:"package"`
 	Entries []DeprecationEntry `json:"entries"`
+
+	// Location is the position of the deprecation in the file-based catalog
+	// it was loaded from, if any. It is never persisted in the blob.
+	Location Location `json:"-"`
 }
 
 type DeprecationEntry struct {
@@ -114,6 +134,15 @@ type Meta struct {
 	Name    string
 
 	Blob json.RawMessage
+
+	// Location is the position of the object in the file or stream it was
+	// decoded from, if any. It is not part of the blob.
+	Location Location
+
+	// Object is the blob decoded into the Go type registered for Schema in
+	// the SchemaRegistry used to load it, or nil if Schema is not registered.
+	// It is a pointer to that type, and is not used when writing the meta.
+	Object interface{}
 }
 
 func (m Meta) MarshalJSON() ([]byte, error) {
@@ -1,9 +1,11 @@
 package declcfg
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/blang/semver/v4"
+	"go.opentelemetry.io/otel/codes"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 
@@ -11,26 +13,41 @@ import (
 	"github.com/operator-framework/operator-registry/alpha/property"
 )
 
+// ConvertToModelContext is like ConvertToModel, but records the conversion
+// as a span of the trace in ctx.
+func ConvertToModelContext(ctx context.Context, cfg DeclarativeConfig) (_ model.Model, err error) {
+	_, span := tracer.Start(ctx, "declcfg.ConvertToModel")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	return ConvertToModel(cfg)
+}
+
 func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 	mpkgs := model.Model{}
 	defaultChannels := map[string]string{}
 	for _, p := range cfg.Packages {
 		if p.Name == "" {
-			return nil, fmt.Errorf("config contains package with no name")
+			return nil, validationErrorf(model.ErrorCodeNameRequired, model.ValidationPath{}, p.Location, "config contains package with no name")
 		}
 
-		if _, ok := mpkgs[p.Name]; ok {
-			return nil, fmt.Errorf("duplicate package %q", p.Name)
+		if existing, ok := mpkgs[p.Name]; ok {
+			return nil, validationErrorf(model.ErrorCodeDuplicatePackage, model.ValidationPath{Package: p.Name}, p.Location, "duplicate package %q%s", p.Name, previouslyDefinedAt(existing.Location))
 		}
 
 		if errs := validation.IsDNS1123Label(p.Name); len(errs) > 0 {
-			return nil, fmt.Errorf("invalid package name %q: %v", p.Name, errs)
+			return nil, validationErrorf(model.ErrorCodeInvalidName, model.ValidationPath{Package: p.Name}, p.Location, "invalid package name %q: %v", p.Name, errs)
 		}
 
 		mpkg := &model.Package{
 			Name:        p.Name,
 			Description: p.Description,
 			Channels:    map[string]*model.Channel{},
+			Location:    p.Location,
 		}
 		if p.Icon != nil {
 			mpkg.Icon = &model.Icon{
@@ -46,15 +63,15 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 	for _, c := range cfg.Channels {
 		mpkg, ok := mpkgs[c.Package]
 		if !ok {
-			return nil, fmt.Errorf("unknown package %q for channel %q", c.Package, c.Name)
+			return nil, validationErrorf(model.ErrorCodeUnknownPackage, model.ValidationPath{Package: c.Package, Channel: c.Name}, c.Location, "unknown package %q for channel %q", c.Package, c.Name)
 		}
 
 		if c.Name == "" {
-			return nil, fmt.Errorf("package %q contains channel with no name", c.Package)
+			return nil, validationErrorf(model.ErrorCodeNameRequired, model.ValidationPath{Package: c.Package}, c.Location, "package %q contains channel with no name", c.Package)
 		}
 
-		if _, ok := mpkg.Channels[c.Name]; ok {
-			return nil, fmt.Errorf("package %q has duplicate channel %q", c.Package, c.Name)
+		if existing, ok := mpkg.Channels[c.Name]; ok {
+			return nil, validationErrorf(model.ErrorCodeDuplicateChannel, model.ValidationPath{Package: c.Package, Channel: c.Name}, c.Location, "package %q has duplicate channel %q%s", c.Package, c.Name, previouslyDefinedAt(existing.Location))
 		}
 
 		mch := &model.Channel{
@@ -65,12 +82,13 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 			//   DO NOT use it for any public-facing functionalities.
 			//   This API is in alpha stage and it is subject to change.
 			Properties: c.Properties,
+			Location:   c.Location,
 		}
 
 		cde := sets.Set[string]{}
 		for _, entry := range c.Entries {
 			if _, ok := mch.Bundles[entry.Name]; ok {
-				return nil, fmt.Errorf("invalid package %q, channel %q: duplicate entry %q", c.Package, c.Name, entry.Name)
+				return nil, validationErrorf(model.ErrorCodeDuplicateEntry, model.ValidationPath{Package: c.Package, Channel: c.Name, Bundle: entry.Name}, c.Location, "invalid package %q, channel %q: duplicate entry %q", c.Package, c.Name, entry.Name)
 			}
 			cde = cde.Insert(entry.Name)
 			mch.Bundles[entry.Name] = &model.Bundle{
@@ -94,45 +112,49 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 
 	// packageBundles tracks the set of bundle names for each package
 	// and is used to detect duplicate bundles.
+	// bundleLocations tracks where each bundle of a package was defined.
 	packageBundles := map[string]sets.Set[string]{}
+	bundleLocations := map[string]map[string]Location{}
 
 	for _, b := range cfg.Bundles {
 		if b.Package == "" {
-			return nil, fmt.Errorf("package name must be set for bundle %q", b.Name)
+			return nil, validationErrorf(model.ErrorCodeUnknownPackage, model.ValidationPath{Bundle: b.Name}, b.Location, "package name must be set for bundle %q", b.Name)
 		}
 		mpkg, ok := mpkgs[b.Package]
 		if !ok {
-			return nil, fmt.Errorf("unknown package %q for bundle %q", b.Package, b.Name)
+			return nil, validationErrorf(model.ErrorCodeUnknownPackage, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "unknown package %q for bundle %q", b.Package, b.Name)
 		}
 
 		bundles, ok := packageBundles[b.Package]
 		if !ok {
 			bundles = sets.Set[string]{}
+			bundleLocations[b.Package] = map[string]Location{}
 		}
 		if bundles.Has(b.Name) {
-			return nil, fmt.Errorf("package %q has duplicate bundle %q", b.Package, b.Name)
+			return nil, validationErrorf(model.ErrorCodeDuplicateBundle, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "package %q has duplicate bundle %q%s", b.Package, b.Name, previouslyDefinedAt(bundleLocations[b.Package][b.Name]))
 		}
 		bundles.Insert(b.Name)
 		packageBundles[b.Package] = bundles
+		bundleLocations[b.Package][b.Name] = b.Location
 
 		props, err := property.Parse(b.Properties)
 		if err != nil {
-			return nil, fmt.Errorf("parse properties for bundle %q: %v", b.Name, err)
+			return nil, validationErrorf(model.ErrorCodeInvalidProperty, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "parse properties for bundle %q: %v", b.Name, err)
 		}
 
 		if len(props.Packages) != 1 {
-			return nil, fmt.Errorf("package %q bundle %q must have exactly 1 %q property, found %d", b.Package, b.Name, property.TypePackage, len(props.Packages))
+			return nil, validationErrorf(model.ErrorCodeInvalidPackageProperty, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "package %q bundle %q must have exactly 1 %q property, found %d", b.Package, b.Name, property.TypePackage, len(props.Packages))
 		}
 
 		if b.Package != props.Packages[0].PackageName {
-			return nil, fmt.Errorf("package %q does not match %q property %q", b.Package, property.TypePackage, props.Packages[0].PackageName)
+			return nil, validationErrorf(model.ErrorCodeInvalidPackageProperty, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "package %q does not match %q property %q", b.Package, property.TypePackage, props.Packages[0].PackageName)
 		}
 
 		// Parse version from the package property.
 		rawVersion := props.Packages[0].Version
 		ver, err := semver.Parse(rawVersion)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing bundle %q version %q: %v", b.Name, rawVersion, err)
+			return nil, validationErrorf(model.ErrorCodeInvalidVersion, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "error parsing bundle %q version %q: %v", b.Name, rawVersion, err)
 		}
 
 		channelDefinedEntries[b.Package] = channelDefinedEntries[b.Package].Delete(b.Name)
@@ -147,16 +169,17 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 				mb.Objects = b.Objects
 				mb.PropertiesP = props
 				mb.Version = ver
+				mb.Location = b.Location
 			}
 		}
 		if !found {
-			return nil, fmt.Errorf("package %q, bundle %q not found in any channel entries", b.Package, b.Name)
+			return nil, validationErrorf(model.ErrorCodeBundleNotInChannel, model.ValidationPath{Package: b.Package, Bundle: b.Name}, b.Location, "package %q, bundle %q not found in any channel entries", b.Package, b.Name)
 		}
 	}
 
 	for pkg, entries := range channelDefinedEntries {
 		if entries.Len() > 0 {
-			return nil, fmt.Errorf("no olm.bundle blobs found in package %q for olm.channel entries %s", pkg, sets.List[string](entries))
+			return nil, validationErrorf(model.ErrorCodeBundleNotFound, model.ValidationPath{Package: pkg}, Location{}, "no olm.bundle blobs found in package %q for olm.channel entries %s", pkg, sets.List[string](entries))
 		}
 	}
 
@@ -182,18 +205,18 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 		// no need to validate schema, since it could not be unmarshaled if missing/invalid
 
 		if deprecation.Package == "" {
-			return nil, fmt.Errorf("package name must be set for deprecation item %v", i)
+			return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{}, deprecation.Location, "package name must be set for deprecation item %v", i)
 		}
 
 		// must refer to package in this catalog
 		mpkg, ok := mpkgs[deprecation.Package]
 		if !ok {
-			return nil, fmt.Errorf("cannot apply deprecations to an unknown package %q", deprecation.Package)
+			return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "cannot apply deprecations to an unknown package %q", deprecation.Package)
 		}
 
 		// must be unique per package
 		if deprecationsByPackage.Has(deprecation.Package) {
-			return nil, fmt.Errorf("expected a maximum of one deprecation per package: %q", deprecation.Package)
+			return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "expected a maximum of one deprecation per package: %q", deprecation.Package)
 		}
 		deprecationsByPackage.Insert(deprecation.Package)
 
@@ -201,18 +224,18 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 
 		for j, entry := range deprecation.Entries {
 			if entry.Reference.Schema == "" {
-				return nil, fmt.Errorf("schema must be set for deprecation entry [%v] for package %q", deprecation.Package, j)
+				return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "schema must be set for deprecation entry [%v] for package %q", deprecation.Package, j)
 			}
 
 			if references.Has(entry.Reference) {
-				return nil, fmt.Errorf("duplicate deprecation entry %#v for package %q", entry.Reference, deprecation.Package)
+				return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "duplicate deprecation entry %#v for package %q", entry.Reference, deprecation.Package)
 			}
 			references.Insert(entry.Reference)
 
 			switch entry.Reference.Schema {
 			case SchemaBundle:
 				if !packageBundles[deprecation.Package].Has(entry.Reference.Name) {
-					return nil, fmt.Errorf("cannot deprecate bundle %q for package %q: bundle not found", entry.Reference.Name, deprecation.Package)
+					return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "cannot deprecate bundle %q for package %q: bundle not found", entry.Reference.Name, deprecation.Package)
 				}
 				for _, mch := range mpkg.Channels {
 					if mb, ok := mch.Bundles[entry.Reference.Name]; ok {
@@ -222,18 +245,18 @@ func ConvertToModel(cfg DeclarativeConfig) (model.Model, error) {
 			case SchemaChannel:
 				ch, ok := mpkg.Channels[entry.Reference.Name]
 				if !ok {
-					return nil, fmt.Errorf("cannot deprecate channel %q for package %q: channel not found", entry.Reference.Name, deprecation.Package)
+					return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "cannot deprecate channel %q for package %q: channel not found", entry.Reference.Name, deprecation.Package)
 				}
 				ch.Deprecation = &model.Deprecation{Message: entry.Message}
 
 			case SchemaPackage:
 				if entry.Reference.Name != "" {
-					return nil, fmt.Errorf("package name must be empty for deprecated package %q (specified %q)", deprecation.Package, entry.Reference.Name)
+					return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "package name must be empty for deprecated package %q (specified %q)", deprecation.Package, entry.Reference.Name)
 				}
 				mpkg.Deprecation = &model.Deprecation{Message: entry.Message}
 
 			default:
-				return nil, fmt.Errorf("cannot deprecate object %#v referenced by entry %v for package %q: object schema unknown", entry.Reference, j, deprecation.Package)
+				return nil, validationErrorf(model.ErrorCodeInvalidDeprecation, model.ValidationPath{Package: deprecation.Package}, deprecation.Location, "cannot deprecate object %#v referenced by entry %v for package %q: object schema unknown", entry.Reference, j, deprecation.Package)
 			}
 		}
 	}
@@ -255,3 +278,32 @@ func relatedImagesToModelRelatedImages(in []RelatedImage) []model.RelatedImage {
 	}
 	return out
 }
+
+// locationErrorf returns an error formatted according to format, prefixed
+// with loc if the location is known.
+func locationErrorf(loc Location, format string, a ...any) error {
+	err := fmt.Errorf(format, a...)
+	if l := loc.String(); l != "" {
+		return fmt.Errorf("%s: %w", l, err)
+	}
+	return err
+}
+
+// validationErrorf returns a ValidationError with the given code and path,
+// whose message is formatted according to format and prefixed with loc if
+// the location is known.
+func validationErrorf(code model.ErrorCode, path model.ValidationPath, loc Location, format string, a ...any) *model.ValidationError {
+	verr := &model.ValidationError{Path: path, Code: code, Message: fmt.Sprintf(format, a...)}
+	if l := loc.String(); l != "" {
+		verr.Message = fmt.Sprintf("%s: %s", l, verr.Message)
+		verr.Location = &loc
+	}
+	return verr
+}
+
+func previouslyDefinedAt(loc Location) string {
+	if l := loc.String(); l != "" {
+		return fmt.Sprintf(" (previously defined at %s)", l)
+	}
+	return ""
+}
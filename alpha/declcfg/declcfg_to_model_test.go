@@ -252,6 +252,39 @@ func TestConvertToModel(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "Error/DuplicatePackageWithLocation",
+			assertion: hasError(`b/catalog.yaml:1: duplicate package "foo" (previously defined at a/catalog.yaml:7)`),
+			cfg: DeclarativeConfig{
+				Packages: []Package{
+					withPackageLocation(newTestPackage("foo", "alpha", svgSmallCircle), "a/catalog.yaml", 7),
+					withPackageLocation(newTestPackage("foo", "alpha", svgSmallCircle), "b/catalog.yaml", 1),
+				},
+				Channels: []Channel{newTestChannel("foo", "alpha", ChannelEntry{Name: "foo.v0.1.0"})},
+				Bundles:  []Bundle{newTestBundle("foo", "0.1.0")},
+			},
+		},
+		{
+			name:      "Error/DuplicateBundleWithLocation",
+			assertion: hasError(`foo/catalog.json:12: package "foo" has duplicate bundle "foo.v0.1.0" (previously defined at foo/catalog.json:3)`),
+			cfg: DeclarativeConfig{
+				Packages: []Package{newTestPackage("foo", "alpha", svgSmallCircle)},
+				Channels: []Channel{newTestChannel("foo", "alpha", ChannelEntry{Name: "foo.v0.1.0"})},
+				Bundles: []Bundle{
+					newTestBundle("foo", "0.1.0", func(b *Bundle) { b.Location = Location{Path: "foo/catalog.json", Line: 3} }),
+					newTestBundle("foo", "0.1.0", func(b *Bundle) { b.Location = Location{Path: "foo/catalog.json", Line: 12} }),
+				},
+			},
+		},
+		{
+			name:      "Error/ChannelUnknownPackageWithLocation",
+			assertion: hasError(`bar/catalog.yaml:4: unknown package "bar" for channel "alpha"`),
+			cfg: DeclarativeConfig{
+				Packages: []Package{newTestPackage("foo", "alpha", svgSmallCircle)},
+				Channels: []Channel{withChannelLocation(newTestChannel("bar", "alpha", ChannelEntry{Name: "bar.v0.1.0"}), "bar/catalog.yaml", 4)},
+				Bundles:  []Bundle{newTestBundle("foo", "0.1.0")},
+			},
+		},
 		{
 			name:      "Success/ValidModel",
 			assertion: require.NoError,
@@ -452,6 +485,16 @@ func TestConvertToModel(t *testing.T) {
 	}
 }
 
+func withPackageLocation(p Package, path string, line int) Package {
+	p.Location = Location{Path: path, Line: line}
+	return p
+}
+
+func withChannelLocation(c Channel, path string, line int) Channel {
+	c.Location = Location{Path: path, Line: line}
+	return c
+}
+
 func TestConvertToModelBundle(t *testing.T) {
 	cfg := DeclarativeConfig{
 		Packages: []Package{newTestPackage("foo", "alpha", svgSmallCircle)},
@@ -502,6 +545,71 @@ func TestConvertToModelRoundtrip(t *testing.T) {
 	assert.Len(t, actual.Others, 0, "expected unrecognized schemas not to make the roundtrip")
 }
 
+func TestConvertToModelValidationErrors(t *testing.T) {
+	type spec struct {
+		name   string
+		cfg    DeclarativeConfig
+		expect model.ValidationError
+	}
+
+	specs := []spec{
+		{
+			name: "DuplicateChannel",
+			cfg: DeclarativeConfig{
+				Packages: []Package{newTestPackage("foo", "alpha", svgSmallCircle)},
+				Channels: []Channel{
+					newTestChannel("foo", "alpha", ChannelEntry{Name: "foo.v0.1.0"}),
+					withChannelLocation(newTestChannel("foo", "alpha", ChannelEntry{Name: "foo.v0.1.0"}), "foo.yaml", 12),
+				},
+				Bundles: []Bundle{newTestBundle("foo", "0.1.0")},
+			},
+			expect: model.ValidationError{
+				Path:     model.ValidationPath{Package: "foo", Channel: "alpha"},
+				Code:     model.ErrorCodeDuplicateChannel,
+				Message:  `foo.yaml:12: package "foo" has duplicate channel "alpha"`,
+				Location: &Location{Path: "foo.yaml", Line: 12},
+			},
+		},
+		{
+			name: "BundleNotInChannel",
+			cfg: DeclarativeConfig{
+				Packages: []Package{newTestPackage("foo", "alpha", svgSmallCircle)},
+				Channels: []Channel{newTestChannel("foo", "alpha", ChannelEntry{Name: "foo.v0.1.0"})},
+				Bundles:  []Bundle{newTestBundle("foo", "0.1.0"), newTestBundle("foo", "0.2.0")},
+			},
+			expect: model.ValidationError{
+				Path:    model.ValidationPath{Package: "foo", Bundle: "foo.v0.2.0"},
+				Code:    model.ErrorCodeBundleNotInChannel,
+				Message: `package "foo", bundle "foo.v0.2.0" not found in any channel entries`,
+			},
+		},
+		{
+			name: "InvalidVersion",
+			cfg: DeclarativeConfig{
+				Packages: []Package{newTestPackage("foo", "alpha", svgSmallCircle)},
+				Channels: []Channel{newTestChannel("foo", "alpha", ChannelEntry{Name: "foo.v0.1.0"})},
+				Bundles: []Bundle{func() Bundle {
+					b := newTestBundle("foo", "0.1.0")
+					b.Properties = []property.Property{property.MustBuildPackage("foo", "bad")}
+					return b
+				}()},
+			},
+			expect: model.ValidationError{
+				Path:    model.ValidationPath{Package: "foo", Bundle: "foo.v0.1.0"},
+				Code:    model.ErrorCodeInvalidVersion,
+				Message: `error parsing bundle "foo.v0.1.0" version "bad": No Major.Minor.Patch elements found`,
+			},
+		},
+	}
+
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			_, err := ConvertToModel(s.cfg)
+			require.Equal(t, []*model.ValidationError{&s.expect}, model.ValidationErrors(err))
+		})
+	}
+}
+
 func hasError(expectedError string) require.ErrorAssertionFunc {
 	return func(t require.TestingT, actualError error, args ...interface{}) {
 		if stdt, ok := t.(*testing.T); ok {
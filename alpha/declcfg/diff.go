@@ -0,0 +1,491 @@
+package declcfg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/operator-framework/operator-registry/alpha/property"
+)
+
+type ChangeType string
+
+const (
+	ChangeTypeAdded    ChangeType = "added"
+	ChangeTypeRemoved  ChangeType = "removed"
+	ChangeTypeModified ChangeType = "modified"
+)
+
+// ChangeSet describes the differences between two declarative configs.
+// Every slice is sorted by package name and then by object name, so that
+// the same pair of inputs always produces the same ChangeSet.
+type ChangeSet struct {
+	Packages     []PackageChange     `json:"packages,omitempty"`
+	Channels     []ChannelChange     `json:"channels,omitempty"`
+	Bundles      []BundleChange      `json:"bundles,omitempty"`
+	Deprecations []DeprecationChange `json:"deprecations,omitempty"`
+}
+
+// PackageChange describes an added, removed or modified olm.package. For
+// modified packages, Fields lists the names of the fields that changed.
+type PackageChange struct {
+	Name   string     `json:"name"`
+	Type   ChangeType `json:"type"`
+	Fields []string   `json:"fields,omitempty"`
+}
+
+// ChannelChange describes an added, removed or modified olm.channel. Edges
+// contains one entry per channel entry whose upgrade edges were added,
+// removed or modified.
+type ChannelChange struct {
+	Package string       `json:"package"`
+	Name    string       `json:"name"`
+	Type    ChangeType   `json:"type"`
+	Fields  []string     `json:"fields,omitempty"`
+	Edges   []EdgeChange `json:"edges,omitempty"`
+}
+
+// EdgeChange describes a change to a single channel entry. Old is nil for
+// added entries and New is nil for removed entries.
+type EdgeChange struct {
+	Name string        `json:"name"`
+	Type ChangeType    `json:"type"`
+	Old  *ChannelEntry `json:"old,omitempty"`
+	New  *ChannelEntry `json:"new,omitempty"`
+}
+
+// BundleChange describes an added, removed or modified olm.bundle. For
+// modified bundles, Fields lists the names of the fields that changed.
+type BundleChange struct {
+	Package string     `json:"package"`
+	Name    string     `json:"name"`
+	Type    ChangeType `json:"type"`
+	Fields  []string   `json:"fields,omitempty"`
+}
+
+// DeprecationChange describes an added, removed or modified entry of a
+// package's olm.deprecations blob.
+type DeprecationChange struct {
+	Package    string                 `json:"package"`
+	Reference  PackageScopedReference `json:"reference"`
+	Type       ChangeType             `json:"type"`
+	OldMessage string                 `json:"oldMessage,omitempty"`
+	NewMessage string                 `json:"newMessage,omitempty"`
+}
+
+// IsEmpty returns true if the ChangeSet contains no changes.
+func (c ChangeSet) IsEmpty() bool {
+	return len(c.Packages) == 0 && len(c.Channels) == 0 && len(c.Bundles) == 0 && len(c.Deprecations) == 0
+}
+
+// Diff compares two declarative configs and returns the set of packages,
+// channels, bundles and deprecation entries that were added, removed or
+// modified between oldCfg and newCfg. Objects are matched by package and
+// name, so the order of objects in either config is irrelevant. Objects in
+// DeclarativeConfig.Others are not compared.
+func Diff(oldCfg, newCfg DeclarativeConfig) ChangeSet {
+	return ChangeSet{
+		Packages:     diffPackages(oldCfg.Packages, newCfg.Packages),
+		Channels:     diffChannels(oldCfg.Channels, newCfg.Channels),
+		Bundles:      diffBundles(oldCfg.Bundles, newCfg.Bundles),
+		Deprecations: diffDeprecations(oldCfg.Deprecations, newCfg.Deprecations),
+	}
+}
+
+func diffPackages(oldPkgs, newPkgs []Package) []PackageChange {
+	oldByName := map[string]Package{}
+	for _, p := range oldPkgs {
+		oldByName[p.Name] = p
+	}
+	newByName := map[string]Package{}
+	for _, p := range newPkgs {
+		newByName[p.Name] = p
+	}
+
+	var changes []PackageChange
+	for _, name := range unionKeys(oldByName, newByName) {
+		o, inOld := oldByName[name]
+		n, inNew := newByName[name]
+		switch {
+		case !inOld:
+			changes = append(changes, PackageChange{Name: name, Type: ChangeTypeAdded})
+		case !inNew:
+			changes = append(changes, PackageChange{Name: name, Type: ChangeTypeRemoved})
+		default:
+			if fields := packageChangedFields(o, n); len(fields) > 0 {
+				changes = append(changes, PackageChange{Name: name, Type: ChangeTypeModified, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// packageChangedFields returns the names of the fields that differ between
+// two definitions of the same package.
+func packageChangedFields(o, n Package) []string {
+	var fields []string
+	if o.DefaultChannel != n.DefaultChannel {
+		fields = append(fields, "defaultChannel")
+	}
+	if !reflect.DeepEqual(o.Icon, n.Icon) {
+		fields = append(fields, "icon")
+	}
+	if o.Description != n.Description {
+		fields = append(fields, "description")
+	}
+	if !propertiesEqual(o.Properties, n.Properties) {
+		fields = append(fields, "properties")
+	}
+	return fields
+}
+
+type packageScopedKey struct {
+	Package string
+	Name    string
+}
+
+func (k packageScopedKey) less(other packageScopedKey) bool {
+	if k.Package != other.Package {
+		return k.Package < other.Package
+	}
+	return k.Name < other.Name
+}
+
+func diffChannels(oldChannels, newChannels []Channel) []ChannelChange {
+	oldByKey := map[packageScopedKey]Channel{}
+	for _, c := range oldChannels {
+		oldByKey[packageScopedKey{c.Package, c.Name}] = c
+	}
+	newByKey := map[packageScopedKey]Channel{}
+	for _, c := range newChannels {
+		newByKey[packageScopedKey{c.Package, c.Name}] = c
+	}
+
+	var changes []ChannelChange
+	for _, key := range unionScopedKeys(oldByKey, newByKey) {
+		o, inOld := oldByKey[key]
+		n, inNew := newByKey[key]
+		change := ChannelChange{Package: key.Package, Name: key.Name}
+		switch {
+		case !inOld:
+			change.Type = ChangeTypeAdded
+			change.Edges = diffChannelEntries(nil, n.Entries)
+		case !inNew:
+			change.Type = ChangeTypeRemoved
+			change.Edges = diffChannelEntries(o.Entries, nil)
+		default:
+			change.Type = ChangeTypeModified
+			change.Edges = diffChannelEntries(o.Entries, n.Entries)
+			if len(change.Edges) > 0 {
+				change.Fields = append(change.Fields, "entries")
+			}
+			if !propertiesEqual(o.Properties, n.Properties) {
+				change.Fields = append(change.Fields, "properties")
+			}
+			if len(change.Fields) == 0 {
+				continue
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func diffChannelEntries(oldEntries, newEntries []ChannelEntry) []EdgeChange {
+	oldByName := map[string]ChannelEntry{}
+	for _, e := range oldEntries {
+		oldByName[e.Name] = e
+	}
+	newByName := map[string]ChannelEntry{}
+	for _, e := range newEntries {
+		newByName[e.Name] = e
+	}
+
+	var changes []EdgeChange
+	for _, name := range unionKeys(oldByName, newByName) {
+		o, inOld := oldByName[name]
+		n, inNew := newByName[name]
+		switch {
+		case !inOld:
+			changes = append(changes, EdgeChange{Name: name, Type: ChangeTypeAdded, New: &n})
+		case !inNew:
+			changes = append(changes, EdgeChange{Name: name, Type: ChangeTypeRemoved, Old: &o})
+		case !channelEntriesEqual(o, n):
+			changes = append(changes, EdgeChange{Name: name, Type: ChangeTypeModified, Old: &o, New: &n})
+		}
+	}
+	return changes
+}
+
+func channelEntriesEqual(a, b ChannelEntry) bool {
+	return len(channelEntryChangedFields(a, b)) == 0
+}
+
+// channelEntryChangedFields returns the names of the fields that differ
+// between two definitions of the same channel entry.
+func channelEntryChangedFields(o, n ChannelEntry) []string {
+	var fields []string
+	if o.Replaces != n.Replaces {
+		fields = append(fields, "replaces")
+	}
+	if !sets.New(o.Skips...).Equal(sets.New(n.Skips...)) {
+		fields = append(fields, "skips")
+	}
+	if o.SkipRange != n.SkipRange {
+		fields = append(fields, "skipRange")
+	}
+	return fields
+}
+
+func diffBundles(oldBundles, newBundles []Bundle) []BundleChange {
+	oldByKey := map[packageScopedKey]Bundle{}
+	for _, b := range oldBundles {
+		oldByKey[packageScopedKey{b.Package, b.Name}] = b
+	}
+	newByKey := map[packageScopedKey]Bundle{}
+	for _, b := range newBundles {
+		newByKey[packageScopedKey{b.Package, b.Name}] = b
+	}
+
+	var changes []BundleChange
+	for _, key := range unionScopedKeys(oldByKey, newByKey) {
+		o, inOld := oldByKey[key]
+		n, inNew := newByKey[key]
+		switch {
+		case !inOld:
+			changes = append(changes, BundleChange{Package: key.Package, Name: key.Name, Type: ChangeTypeAdded})
+		case !inNew:
+			changes = append(changes, BundleChange{Package: key.Package, Name: key.Name, Type: ChangeTypeRemoved})
+		default:
+			if fields := bundleChangedFields(o, n); len(fields) > 0 {
+				changes = append(changes, BundleChange{Package: key.Package, Name: key.Name, Type: ChangeTypeModified, Fields: fields})
+			}
+		}
+	}
+	return changes
+}
+
+// bundleChangedFields returns the names of the fields that differ between
+// two definitions of the same bundle.
+func bundleChangedFields(o, n Bundle) []string {
+	var fields []string
+	if o.Image != n.Image {
+		fields = append(fields, "image")
+	}
+	if !propertiesEqual(o.Properties, n.Properties) {
+		fields = append(fields, "properties")
+	}
+	if !sets.New(o.RelatedImages...).Equal(sets.New(n.RelatedImages...)) {
+		fields = append(fields, "relatedImages")
+	}
+	return fields
+}
+
+type deprecationKey struct {
+	Package   string
+	Reference PackageScopedReference
+}
+
+func diffDeprecations(oldDeprecations, newDeprecations []Deprecation) []DeprecationChange {
+	oldByKey := map[deprecationKey]string{}
+	for _, d := range oldDeprecations {
+		for _, e := range d.Entries {
+			oldByKey[deprecationKey{d.Package, e.Reference}] = e.Message
+		}
+	}
+	newByKey := map[deprecationKey]string{}
+	for _, d := range newDeprecations {
+		for _, e := range d.Entries {
+			newByKey[deprecationKey{d.Package, e.Reference}] = e.Message
+		}
+	}
+
+	keys := sets.New[deprecationKey]()
+	for k := range oldByKey {
+		keys.Insert(k)
+	}
+	for k := range newByKey {
+		keys.Insert(k)
+	}
+	sortedKeys := keys.UnsortedList()
+	sort.Slice(sortedKeys, func(i, j int) bool {
+		a, b := sortedKeys[i], sortedKeys[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Reference.Schema != b.Reference.Schema {
+			return a.Reference.Schema < b.Reference.Schema
+		}
+		return a.Reference.Name < b.Reference.Name
+	})
+
+	var changes []DeprecationChange
+	for _, key := range sortedKeys {
+		o, inOld := oldByKey[key]
+		n, inNew := newByKey[key]
+		change := DeprecationChange{Package: key.Package, Reference: key.Reference, OldMessage: o, NewMessage: n}
+		switch {
+		case !inOld:
+			change.Type = ChangeTypeAdded
+		case !inNew:
+			change.Type = ChangeTypeRemoved
+		case o != n:
+			change.Type = ChangeTypeModified
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// propertiesEqual returns true if a and b contain the same properties,
+// regardless of order and of insignificant whitespace in property values.
+func propertiesEqual(a, b []property.Property) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := map[string]int{}
+	for _, p := range a {
+		count[propertyKey(p)]++
+	}
+	for _, p := range b {
+		k := propertyKey(p)
+		if count[k] == 0 {
+			return false
+		}
+		count[k]--
+	}
+	return true
+}
+
+func propertyKey(p property.Property) string {
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, p.Value); err != nil {
+		return p.Type + "\x00" + string(p.Value)
+	}
+	return p.Type + "\x00" + buf.String()
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := sets.New[string]()
+	for k := range a {
+		keys.Insert(k)
+	}
+	for k := range b {
+		keys.Insert(k)
+	}
+	return sets.List(keys)
+}
+
+func unionScopedKeys[V any](a, b map[packageScopedKey]V) []packageScopedKey {
+	keys := sets.New[packageScopedKey]()
+	for k := range a {
+		keys.Insert(k)
+	}
+	for k := range b {
+		keys.Insert(k)
+	}
+	out := keys.UnsortedList()
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].less(out[j])
+	})
+	return out
+}
+
+// WriteText writes a human-readable summary of the ChangeSet to w.
+//
+// Example output:
+//
+//	package "foo" modified: defaultChannel
+//	channel "foo/stable" modified: entries
+//	  entry "foo.v0.2.0" added: replaces "foo.v0.1.0"
+//	bundle "foo/foo.v0.2.0" added
+//	deprecation "foo" olm.bundle "foo.v0.1.0" added: "foo.v0.1.0 is deprecated"
+func (c ChangeSet) WriteText(w io.Writer) error {
+	var lines []string
+	for _, p := range c.Packages {
+		lines = append(lines, changeLine(fmt.Sprintf("package %q", p.Name), p.Type, p.Fields))
+	}
+	for _, ch := range c.Channels {
+		lines = append(lines, changeLine(fmt.Sprintf("channel %q", ch.Package+"/"+ch.Name), ch.Type, ch.Fields))
+		for _, e := range ch.Edges {
+			lines = append(lines, "  "+edgeLine(e))
+		}
+	}
+	for _, b := range c.Bundles {
+		lines = append(lines, changeLine(fmt.Sprintf("bundle %q", b.Package+"/"+b.Name), b.Type, b.Fields))
+	}
+	for _, d := range c.Deprecations {
+		ref := d.Reference.Schema
+		if d.Reference.Name != "" {
+			ref = fmt.Sprintf("%s %q", ref, d.Reference.Name)
+		}
+		line := fmt.Sprintf("deprecation %q %s %s", d.Package, ref, d.Type)
+		switch d.Type {
+		case ChangeTypeAdded:
+			line += fmt.Sprintf(": %q", d.NewMessage)
+		case ChangeTypeRemoved:
+			line += fmt.Sprintf(": %q", d.OldMessage)
+		case ChangeTypeModified:
+			line += fmt.Sprintf(": %q -> %q", d.OldMessage, d.NewMessage)
+		}
+		lines = append(lines, line)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func changeLine(subject string, t ChangeType, fields []string) string {
+	if len(fields) == 0 {
+		return fmt.Sprintf("%s %s", subject, t)
+	}
+	return fmt.Sprintf("%s %s: %s", subject, t, strings.Join(fields, ", "))
+}
+
+func edgeLine(e EdgeChange) string {
+	switch e.Type {
+	case ChangeTypeAdded:
+		return fmt.Sprintf("entry %q added%s", e.Name, describeEdges(*e.New))
+	case ChangeTypeRemoved:
+		return fmt.Sprintf("entry %q removed%s", e.Name, describeEdges(*e.Old))
+	default:
+		return fmt.Sprintf("entry %q modified:%s ->%s", e.Name, orNone(describeEdges(*e.Old)), orNone(describeEdges(*e.New)))
+	}
+}
+
+func describeEdges(e ChannelEntry) string {
+	var parts []string
+	if e.Replaces != "" {
+		parts = append(parts, fmt.Sprintf("replaces %q", e.Replaces))
+	}
+	if len(e.Skips) > 0 {
+		skips := append([]string{}, e.Skips...)
+		sort.Strings(skips)
+		parts = append(parts, fmt.Sprintf("skips %q", skips))
+	}
+	if e.SkipRange != "" {
+		parts = append(parts, fmt.Sprintf("skipRange %q", e.SkipRange))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(parts, ", ")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return " <none>"
+	}
+	return strings.TrimPrefix(s, ":")
+}
@@ -0,0 +1,152 @@
+package declcfg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	type spec struct {
+		name     string
+		oldCfg   func() DeclarativeConfig
+		newCfg   func() DeclarativeConfig
+		expected ChangeSet
+	}
+
+	base := func() DeclarativeConfig {
+		return buildValidDeclarativeConfig(validDeclarativeConfigSpec{IncludeUnrecognized: true, IncludeDeprecations: true})
+	}
+
+	specs := []spec{
+		{
+			name:     "Identical",
+			oldCfg:   base,
+			newCfg:   base,
+			expected: ChangeSet{},
+		},
+		{
+			name:   "IgnoresOrder",
+			oldCfg: base,
+			newCfg: func() DeclarativeConfig {
+				cfg := base()
+				cfg.Bundles[0], cfg.Bundles[4] = cfg.Bundles[4], cfg.Bundles[0]
+				cfg.Channels[0], cfg.Channels[2] = cfg.Channels[2], cfg.Channels[0]
+				props := cfg.Bundles[1].Properties
+				props[0], props[1] = props[1], props[0]
+				return cfg
+			},
+			expected: ChangeSet{},
+		},
+		{
+			name: "AddedAndRemoved",
+			oldCfg: func() DeclarativeConfig {
+				cfg := base()
+				cfg.Packages = cfg.Packages[:1]
+				cfg.Channels = cfg.Channels[:2]
+				cfg.Bundles = cfg.Bundles[:3]
+				return cfg
+			},
+			newCfg: func() DeclarativeConfig {
+				cfg := base()
+				cfg.Deprecations = nil
+				return cfg
+			},
+			expected: ChangeSet{
+				Packages: []PackageChange{{Name: "boba-fett", Type: ChangeTypeAdded}},
+				Channels: []ChannelChange{{
+					Package: "boba-fett",
+					Name:    "mando",
+					Type:    ChangeTypeAdded,
+					Edges: []EdgeChange{
+						{Name: "boba-fett.v1.0.0", Type: ChangeTypeAdded, New: &ChannelEntry{Name: "boba-fett.v1.0.0"}},
+						{Name: "boba-fett.v2.0.0", Type: ChangeTypeAdded, New: &ChannelEntry{Name: "boba-fett.v2.0.0", Replaces: "boba-fett.v1.0.0"}},
+					},
+				}},
+				Bundles: []BundleChange{
+					{Package: "boba-fett", Name: "boba-fett.v1.0.0", Type: ChangeTypeAdded},
+					{Package: "boba-fett", Name: "boba-fett.v2.0.0", Type: ChangeTypeAdded},
+				},
+				Deprecations: []DeprecationChange{
+					{Package: "anakin", Reference: PackageScopedReference{Schema: SchemaBundle, Name: "anakin.v0.0.1"}, Type: ChangeTypeRemoved, OldMessage: "This bundle version is deprecated"},
+					{Package: "anakin", Reference: PackageScopedReference{Schema: SchemaChannel, Name: "light"}, Type: ChangeTypeRemoved, OldMessage: "This channel is deprecated"},
+					{Package: "anakin", Reference: PackageScopedReference{Schema: SchemaPackage}, Type: ChangeTypeRemoved, OldMessage: "This package is deprecated... there is another"},
+				},
+			},
+		},
+		{
+			name:   "Modified",
+			oldCfg: base,
+			newCfg: func() DeclarativeConfig {
+				cfg := base()
+				cfg.Packages[0].DefaultChannel = "light"
+				cfg.Packages[0].Description = "new description"
+				cfg.Channels[0].Entries[2].Skips = nil
+				cfg.Channels[0].Entries[2].SkipRange = "<0.1.1"
+				cfg.Bundles[1].Image = "anakin-bundle:v0.1.0-rebuilt"
+				cfg.Deprecations[0].Entries[1].Message = "Use the dark channel"
+				return cfg
+			},
+			expected: ChangeSet{
+				Packages: []PackageChange{{Name: "anakin", Type: ChangeTypeModified, Fields: []string{"defaultChannel", "description"}}},
+				Channels: []ChannelChange{{
+					Package: "anakin",
+					Name:    "dark",
+					Type:    ChangeTypeModified,
+					Fields:  []string{"entries"},
+					Edges: []EdgeChange{{
+						Name: "anakin.v0.1.1",
+						Type: ChangeTypeModified,
+						Old:  &ChannelEntry{Name: "anakin.v0.1.1", Replaces: "anakin.v0.0.1", Skips: []string{"anakin.v0.1.0"}},
+						New:  &ChannelEntry{Name: "anakin.v0.1.1", Replaces: "anakin.v0.0.1", SkipRange: "<0.1.1"},
+					}},
+				}},
+				Bundles: []BundleChange{{Package: "anakin", Name: "anakin.v0.1.0", Type: ChangeTypeModified, Fields: []string{"image"}}},
+				Deprecations: []DeprecationChange{{
+					Package:    "anakin",
+					Reference:  PackageScopedReference{Schema: SchemaChannel, Name: "light"},
+					Type:       ChangeTypeModified,
+					OldMessage: "This channel is deprecated",
+					NewMessage: "Use the dark channel",
+				}},
+			},
+		},
+	}
+
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			actual := Diff(s.oldCfg(), s.newCfg())
+			require.Equal(t, s.expected, actual)
+			require.Equal(t, s.expected.IsEmpty(), actual.IsEmpty())
+		})
+	}
+}
+
+func TestChangeSetWriteText(t *testing.T) {
+	cs := ChangeSet{
+		Packages: []PackageChange{{Name: "foo", Type: ChangeTypeModified, Fields: []string{"defaultChannel"}}},
+		Channels: []ChannelChange{{
+			Package: "foo",
+			Name:    "stable",
+			Type:    ChangeTypeModified,
+			Fields:  []string{"entries"},
+			Edges: []EdgeChange{
+				{Name: "foo.v0.2.0", Type: ChangeTypeAdded, New: &ChannelEntry{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0"}},
+				{Name: "foo.v0.1.0", Type: ChangeTypeModified, Old: &ChannelEntry{Name: "foo.v0.1.0", SkipRange: "<0.1.0"}, New: &ChannelEntry{Name: "foo.v0.1.0"}},
+			},
+		}},
+		Bundles:      []BundleChange{{Package: "foo", Name: "foo.v0.2.0", Type: ChangeTypeAdded}},
+		Deprecations: []DeprecationChange{{Package: "foo", Reference: PackageScopedReference{Schema: SchemaBundle, Name: "foo.v0.1.0"}, Type: ChangeTypeAdded, NewMessage: "foo.v0.1.0 is deprecated"}},
+	}
+	expected := `package "foo" modified: defaultChannel
+channel "foo/stable" modified: entries
+  entry "foo.v0.2.0" added: replaces "foo.v0.1.0"
+  entry "foo.v0.1.0" modified: skipRange "<0.1.0" -> <none>
+bundle "foo/foo.v0.2.0" added
+deprecation "foo" olm.bundle "foo.v0.1.0" added: "foo.v0.1.0 is deprecated"
+`
+	var buf bytes.Buffer
+	require.NoError(t, cs.WriteText(&buf))
+	require.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,170 @@
+package declcfg
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Layout describes how the objects of a declarative config are distributed
+// across files when written to a filesystem.
+type Layout string
+
+const (
+	// LayoutPackage writes all objects of a package to <package>/catalog<ext>.
+	LayoutPackage Layout = "package"
+
+	// LayoutSchema writes the objects of a package to one file per schema,
+	// named <package>/<schema><ext> (e.g. foo/olm.channel.yaml).
+	LayoutSchema Layout = "schema"
+
+	// LayoutBundle writes each bundle to <package>/bundles/<bundle><ext> and
+	// all other objects of a package to <package>/package<ext>.
+	LayoutBundle Layout = "bundle"
+)
+
+// Layouts is the list of supported layouts.
+var Layouts = []Layout{LayoutPackage, LayoutSchema, LayoutBundle}
+
+// SplitByLayout distributes the objects of cfg into files according to layout.
+// The returned map is keyed by slash-separated file paths relative to the
+// root of the catalog. Objects in cfg.Others that do not belong to a package
+// are placed in files at the root of the catalog.
+func SplitByLayout(cfg DeclarativeConfig, layout Layout, fileExt string) (map[string]*DeclarativeConfig, error) {
+	files := map[string]*DeclarativeConfig{}
+	fileFor := func(elem ...string) (*DeclarativeConfig, error) {
+		for _, e := range elem {
+			if err := validatePathElement(e); err != nil {
+				return nil, err
+			}
+		}
+		name := path.Join(elem...) + fileExt
+		f, ok := files[name]
+		if !ok {
+			f = &DeclarativeConfig{}
+			files[name] = f
+		}
+		return f, nil
+	}
+
+	var (
+		// packageFile returns the file for a non-bundle object of the given
+		// schema. Only objects in cfg.Others may have an empty package.
+		packageFile func(pkg, schema string) (*DeclarativeConfig, error)
+		bundleFile  func(pkg, name string) (*DeclarativeConfig, error)
+	)
+	switch layout {
+	case LayoutPackage:
+		packageFile = func(pkg, _ string) (*DeclarativeConfig, error) {
+			if pkg == "" {
+				return fileFor("catalog")
+			}
+			return fileFor(pkg, "catalog")
+		}
+		bundleFile = func(pkg, _ string) (*DeclarativeConfig, error) {
+			return fileFor(pkg, "catalog")
+		}
+	case LayoutSchema:
+		packageFile = func(pkg, schema string) (*DeclarativeConfig, error) {
+			if pkg == "" {
+				return fileFor(schema)
+			}
+			return fileFor(pkg, schema)
+		}
+		bundleFile = func(pkg, _ string) (*DeclarativeConfig, error) {
+			return fileFor(pkg, SchemaBundle)
+		}
+	case LayoutBundle:
+		packageFile = func(pkg, _ string) (*DeclarativeConfig, error) {
+			if pkg == "" {
+				return fileFor("catalog")
+			}
+			return fileFor(pkg, "package")
+		}
+		bundleFile = func(pkg, name string) (*DeclarativeConfig, error) {
+			return fileFor(pkg, "bundles", name)
+		}
+	default:
+		return nil, fmt.Errorf("unknown layout %q, expected one of %v", layout, Layouts)
+	}
+
+	for _, p := range cfg.Packages {
+		if p.Name == "" {
+			return nil, fmt.Errorf("config contains package with no name")
+		}
+		f, err := packageFile(p.Name, SchemaPackage)
+		if err != nil {
+			return nil, fmt.Errorf("package %q: %v", p.Name, err)
+		}
+		f.Packages = append(f.Packages, p)
+	}
+	for _, c := range cfg.Channels {
+		if c.Package == "" {
+			return nil, fmt.Errorf("package name must be set for channel %q", c.Name)
+		}
+		f, err := packageFile(c.Package, SchemaChannel)
+		if err != nil {
+			return nil, fmt.Errorf("package %q, channel %q: %v", c.Package, c.Name, err)
+		}
+		f.Channels = append(f.Channels, c)
+	}
+	for _, b := range cfg.Bundles {
+		if b.Package == "" {
+			return nil, fmt.Errorf("package name must be set for bundle %q", b.Name)
+		}
+		f, err := bundleFile(b.Package, b.Name)
+		if err != nil {
+			return nil, fmt.Errorf("package %q, bundle %q: %v", b.Package, b.Name, err)
+		}
+		f.Bundles = append(f.Bundles, b)
+	}
+	for i, d := range cfg.Deprecations {
+		if d.Package == "" {
+			return nil, fmt.Errorf("package name must be set for deprecation item %v", i)
+		}
+		f, err := packageFile(d.Package, SchemaDeprecation)
+		if err != nil {
+			return nil, fmt.Errorf("package %q, deprecation: %v", d.Package, err)
+		}
+		f.Deprecations = append(f.Deprecations, d)
+	}
+	for _, o := range cfg.Others {
+		f, err := packageFile(o.Package, o.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("package %q, object with schema %q: %v", o.Package, o.Schema, err)
+		}
+		f.Others = append(f.Others, o)
+	}
+	return files, nil
+}
+
+// validatePathElement ensures that a package, bundle or schema name can be
+// used as a single path element without escaping the catalog root.
+func validatePathElement(elem string) error {
+	if elem == "" || elem == "." || elem == ".." || strings.ContainsAny(elem, `/\`) {
+		return fmt.Errorf("%q cannot be used as a file or directory name", elem)
+	}
+	return nil
+}
+
+// WriteFSLayout writes cfg to rootDir, distributing its objects into files
+// according to layout. Unlike WriteFS, all objects of cfg are written,
+// including deprecations and objects with unrecognized schemas.
+func WriteFSLayout(cfg DeclarativeConfig, rootDir string, layout Layout, writeFunc WriteFunc, fileExt string) error {
+	files, err := SplitByLayout(cfg, layout, fileExt)
+	if err != nil {
+		return err
+	}
+	for name, fcfg := range files {
+		filename := filepath.Join(rootDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+			return err
+		}
+		if err := writeFile(*fcfg, filename, writeFunc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
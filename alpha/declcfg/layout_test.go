@@ -0,0 +1,107 @@
+package declcfg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestSplitByLayout(t *testing.T) {
+	type spec struct {
+		name          string
+		layout        Layout
+		cfg           DeclarativeConfig
+		expectedFiles []string
+		expectedErr   string
+	}
+
+	validCfg := buildValidDeclarativeConfig(validDeclarativeConfigSpec{IncludeUnrecognized: true, IncludeDeprecations: true})
+	specs := []spec{
+		{
+			name:   "Package",
+			layout: LayoutPackage,
+			cfg:    validCfg,
+			expectedFiles: []string{
+				"anakin/catalog.yaml",
+				"boba-fett/catalog.yaml",
+				"catalog.yaml",
+			},
+		},
+		{
+			name:   "Schema",
+			layout: LayoutSchema,
+			cfg:    validCfg,
+			expectedFiles: []string{
+				"anakin/custom.3.yaml",
+				"anakin/olm.bundle.yaml",
+				"anakin/olm.channel.yaml",
+				"anakin/olm.deprecations.yaml",
+				"anakin/olm.package.yaml",
+				"boba-fett/custom.3.yaml",
+				"boba-fett/olm.bundle.yaml",
+				"boba-fett/olm.channel.yaml",
+				"boba-fett/olm.package.yaml",
+				"custom.1.yaml",
+				"custom.2.yaml",
+			},
+		},
+		{
+			name:   "Bundle",
+			layout: LayoutBundle,
+			cfg:    validCfg,
+			expectedFiles: []string{
+				"anakin/bundles/anakin.v0.0.1.yaml",
+				"anakin/bundles/anakin.v0.1.0.yaml",
+				"anakin/bundles/anakin.v0.1.1.yaml",
+				"anakin/package.yaml",
+				"boba-fett/bundles/boba-fett.v1.0.0.yaml",
+				"boba-fett/bundles/boba-fett.v2.0.0.yaml",
+				"boba-fett/package.yaml",
+				"catalog.yaml",
+			},
+		},
+		{
+			name:        "Error/UnknownLayout",
+			layout:      Layout("unknown"),
+			cfg:         validCfg,
+			expectedErr: `unknown layout "unknown", expected one of [package schema bundle]`,
+		},
+		{
+			name:   "Error/BundleWithNoPackage",
+			layout: LayoutPackage,
+			cfg: DeclarativeConfig{
+				Bundles: []Bundle{{Schema: SchemaBundle, Name: "foo.v0.1.0"}},
+			},
+			expectedErr: `package name must be set for bundle "foo.v0.1.0"`,
+		},
+		{
+			name:   "Error/BundleNameEscapesRoot",
+			layout: LayoutBundle,
+			cfg: DeclarativeConfig{
+				Bundles: []Bundle{{Schema: SchemaBundle, Package: "foo", Name: "../foo.v0.1.0"}},
+			},
+			expectedErr: `package "foo", bundle "../foo.v0.1.0": "../foo.v0.1.0" cannot be used as a file or directory name`,
+		},
+	}
+
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			files, err := SplitByLayout(s.cfg, s.layout, ".yaml")
+			if s.expectedErr != "" {
+				require.EqualError(t, err, s.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			names := sets.List(sets.KeySet(files))
+			require.Equal(t, s.expectedFiles, names)
+
+			// Every object must be placed in exactly one file.
+			merged := DeclarativeConfig{}
+			for _, name := range names {
+				merged.Merge(files[name])
+			}
+			equalsDeclarativeConfig(t, s.cfg, merged)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package declcfg
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,12 +10,16 @@ import (
 	"io"
 	"io/fs"
 	"runtime"
+	"strings"
 	"sync"
 
 	"github.com/joelanford/ignore"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
 
 	"github.com/operator-framework/api/pkg/operators"
 
@@ -24,6 +30,8 @@ const (
 	indexIgnoreFilename = ".indexignore"
 )
 
+var tracer = otel.Tracer("github.com/operator-framework/operator-registry/alpha/declcfg")
+
 type WalkMetasFSFunc func(path string, meta *Meta, err error) error
 
 // WalkMetasFS walks the filesystem rooted at root and calls walkFn for each individual meta object found in the root.
@@ -66,8 +74,10 @@ func WalkMetasFS(ctx context.Context, root fs.FS, walkFn WalkMetasFSFunc, opts .
 
 type WalkMetasReaderFunc func(meta *Meta, err error) error
 
+// WalkMetasReader decodes the YAML or JSON stream from r and calls walkFn for each meta object found in it.
+// The Line of each meta's Location is set to the line on which the object starts.
 func WalkMetasReader(r io.Reader, walkFn WalkMetasReaderFunc) error {
-	dec := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	dec := newMetaDecoder(r, 4096)
 	for {
 		var in Meta
 		if err := dec.Decode(&in); err != nil {
@@ -131,6 +141,7 @@ func walkFiles(root fs.FS, fn func(root fs.FS, path string, err error) error) er
 
 type LoadOptions struct {
 	concurrency int
+	schemas     *SchemaRegistry
 }
 
 type LoadOption func(*LoadOptions)
@@ -141,13 +152,34 @@ func WithConcurrency(concurrency int) LoadOption {
 	}
 }
 
+// WithSchemaRegistry sets the registry used to decode objects that are not
+// one of the olm.* schemas. By default, DefaultSchemaRegistry is used.
+func WithSchemaRegistry(schemas *SchemaRegistry) LoadOption {
+	return func(opts *LoadOptions) {
+		opts.schemas = schemas
+	}
+}
+
 // LoadFS loads a declarative config from the provided root FS. LoadFS walks the
 // filesystem from root and uses a gitignore-style filename matcher to skip files
 // that match patterns found in .indexignore files found throughout the filesystem.
 // If LoadFS encounters an error loading or parsing any file, the error will be
 // immediately returned.
-func LoadFS(ctx context.Context, root fs.FS, opts ...LoadOption) (*DeclarativeConfig, error) {
-	builder := fbcBuilder{}
+func LoadFS(ctx context.Context, root fs.FS, opts ...LoadOption) (_ *DeclarativeConfig, err error) {
+	ctx, span := tracer.Start(ctx, "declcfg.LoadFS")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	options := LoadOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	builder := fbcBuilder{schemas: options.schemas}
 	if err := WalkMetasFS(ctx, root, func(path string, meta *Meta, err error) error {
 		if err != nil {
 			return err
@@ -183,13 +215,18 @@ func parseMetaPaths(ctx context.Context, root fs.FS, pathChan <-chan string, wal
 			if !ok {
 				return nil
 			}
-			file, err := root.Open(path)
+			file, err := openFile(root, path)
 			if err != nil {
 				return err
 			}
-			if err := WalkMetasReader(file, func(meta *Meta, err error) error {
+			err = WalkMetasReader(file, func(meta *Meta, err error) error {
+				if meta != nil {
+					meta.Location.Path = path
+				}
 				return walkFn(path, meta, err)
-			}); err != nil {
+			})
+			file.Close()
+			if err != nil {
 				return err
 			}
 		}
@@ -245,18 +282,23 @@ func LoadReader(r io.Reader) (*DeclarativeConfig, error) {
 // LoadFile will unmarshall declarative config components from a single filename provided in 'path'
 // located at a filesystem hierarchy 'root'
 func LoadFile(root fs.FS, path string) (*DeclarativeConfig, error) {
-	file, err := root.Open(path)
+	file, err := openFile(root, path)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	cfg, err := LoadReader(file)
-	if err != nil {
+	builder := fbcBuilder{}
+	if err := WalkMetasReader(file, func(meta *Meta, err error) error {
+		if err != nil {
+			return err
+		}
+		meta.Location.Path = path
+		return builder.addMeta(meta)
+	}); err != nil {
 		return nil, err
 	}
-
-	return cfg, nil
+	return &builder.cfg, nil
 }
 
 // LoadSlice will compose declarative config components from a slice of Meta objects
@@ -273,6 +315,10 @@ func LoadSlice(metas []*Meta) (*DeclarativeConfig, error) {
 type fbcBuilder struct {
 	cfg DeclarativeConfig
 
+	// schemas decodes objects with unrecognized schemas. If nil,
+	// DefaultSchemaRegistry is used.
+	schemas *SchemaRegistry
+
 	packagesMu     sync.Mutex
 	channelsMu     sync.Mutex
 	bundlesMu      sync.Mutex
@@ -287,6 +333,7 @@ func (c *fbcBuilder) addMeta(in *Meta) error {
 		if err := json.Unmarshal(in.Blob, &p); err != nil {
 			return fmt.Errorf("parse package: %v", err)
 		}
+		p.Location = in.Location
 		c.packagesMu.Lock()
 		c.cfg.Packages = append(c.cfg.Packages, p)
 		c.packagesMu.Unlock()
@@ -295,6 +342,7 @@ func (c *fbcBuilder) addMeta(in *Meta) error {
 		if err := json.Unmarshal(in.Blob, &ch); err != nil {
 			return fmt.Errorf("parse channel: %v", err)
 		}
+		ch.Location = in.Location
 		c.channelsMu.Lock()
 		c.cfg.Channels = append(c.cfg.Channels, ch)
 		c.channelsMu.Unlock()
@@ -303,6 +351,7 @@ func (c *fbcBuilder) addMeta(in *Meta) error {
 		if err := json.Unmarshal(in.Blob, &b); err != nil {
 			return fmt.Errorf("parse bundle: %v", err)
 		}
+		b.Location = in.Location
 		if err := readBundleObjects(&b); err != nil {
 			return fmt.Errorf("read bundle objects: %v", err)
 		}
@@ -314,15 +363,191 @@ func (c *fbcBuilder) addMeta(in *Meta) error {
 		if err := json.Unmarshal(in.Blob, &d); err != nil {
 			return fmt.Errorf("parse deprecation: %w", err)
 		}
+		d.Location = in.Location
 		c.deprecationsMu.Lock()
 		c.cfg.Deprecations = append(c.cfg.Deprecations, d)
 		c.deprecationsMu.Unlock()
 	case "":
 		return fmt.Errorf("object '%s' is missing root schema field", string(in.Blob))
 	default:
+		schemas := c.schemas
+		if schemas == nil {
+			schemas = DefaultSchemaRegistry
+		}
+		if err := schemas.Decode(in); err != nil {
+			return err
+		}
 		c.othersMu.Lock()
 		c.cfg.Others = append(c.cfg.Others, *in)
 		c.othersMu.Unlock()
 	}
 	return nil
 }
+
+// metaDecoder decodes a stream of YAML documents or concatenated JSON objects
+// into metas, keeping track of the line on which each object starts. It
+// follows the same rules as yaml.YAMLOrJSONDecoder to decide whether the
+// stream is YAML or JSON, and to split YAML streams into documents.
+type metaDecoder struct {
+	r          io.Reader
+	bufferSize int
+
+	json  *json.Decoder
+	lines *lineTracker
+	yaml  *yamlDocumentReader
+}
+
+func newMetaDecoder(r io.Reader, bufferSize int) *metaDecoder {
+	return &metaDecoder{r: r, bufferSize: bufferSize}
+}
+
+func (d *metaDecoder) Decode(into *Meta) error {
+	if d.json == nil && d.yaml == nil {
+		d.lines = &lineTracker{r: d.r}
+		buffer, _, isJSON := yaml.GuessJSONStream(d.lines, d.bufferSize)
+		if isJSON {
+			d.json = json.NewDecoder(buffer)
+		} else {
+			d.yaml = &yamlDocumentReader{r: bufio.NewReader(buffer)}
+		}
+	}
+	if d.json != nil {
+		return d.decodeJSON(into)
+	}
+	return d.decodeYAML(into)
+}
+
+func (d *metaDecoder) decodeJSON(into *Meta) error {
+	var raw json.RawMessage
+	if err := d.json.Decode(&raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return yaml.JSONSyntaxError{Offset: syntaxErr.Offset, Err: syntaxErr}
+		}
+		return err
+	}
+	line := d.lines.lineAt(d.json.InputOffset() - int64(len(raw)))
+	if err := json.Unmarshal(raw, into); err != nil {
+		return err
+	}
+	into.Location.Line = line
+	return nil
+}
+
+func (d *metaDecoder) decodeYAML(into *Meta) error {
+	doc, line, err := d.yaml.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if len(doc) != 0 {
+		if err := sigsyaml.Unmarshal(doc, into); err != nil {
+			return err
+		}
+		into.Location.Line = line
+	}
+	return err
+}
+
+// lineTracker records the offsets of the newlines read from r, so that the
+// line number of an offset that has already been read can be computed.
+// Offsets passed to lineAt must not decrease between calls.
+type lineTracker struct {
+	r io.Reader
+
+	offset   int64
+	newlines []int64
+	line     int
+}
+
+func (t *lineTracker) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	for i, c := range p[:n] {
+		if c == '\n' {
+			t.newlines = append(t.newlines, t.offset+int64(i))
+		}
+	}
+	t.offset += int64(n)
+	return n, err
+}
+
+// lineAt returns the 1-based line number of the byte at offset.
+func (t *lineTracker) lineAt(offset int64) int {
+	i := 0
+	for i < len(t.newlines) && t.newlines[i] < offset {
+		i++
+	}
+	t.line += i
+	t.newlines = t.newlines[i:]
+	return t.line + 1
+}
+
+const yamlSeparator = "---"
+
+// yamlDocumentReader splits a YAML stream into documents in the same way as
+// yaml.YAMLReader, and also reports the line on which each document starts.
+type yamlDocumentReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// Read returns the next document and the 1-based line number of its first
+// line that is neither blank nor a comment.
+func (r *yamlDocumentReader) Read() ([]byte, int, error) {
+	var (
+		buffer    bytes.Buffer
+		startLine int
+	)
+	for {
+		line, err := r.readLine()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, 0, err
+		}
+
+		isSeparator := bytes.HasPrefix(line, []byte(yamlSeparator))
+		if isSeparator {
+			// We have a potential document terminator
+			trimmed := strings.TrimSpace(string(line[len(yamlSeparator):]))
+			// We only allow comments and spaces following the yaml doc separator, otherwise we'll return an error
+			if len(trimmed) > 0 && trimmed[0] != '#' {
+				return nil, 0, fmt.Errorf("invalid Yaml document separator: %s", trimmed)
+			}
+			if buffer.Len() != 0 {
+				return buffer.Bytes(), startLine, nil
+			}
+			if errors.Is(err, io.EOF) {
+				return nil, 0, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			if buffer.Len() != 0 {
+				// If we're at EOF, we have a final, non-terminated line. Return it.
+				return buffer.Bytes(), startLine, nil
+			}
+			return nil, 0, err
+		}
+		if startLine == 0 && !isSeparator {
+			if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 && trimmed[0] != '#' {
+				startLine = r.line
+			}
+		}
+		buffer.Write(line)
+	}
+}
+
+// readLine returns a single line (with '\n' ended) from the underlying reader.
+// An error is returned iff there is an error with the underlying reader.
+func (r *yamlDocumentReader) readLine() ([]byte, error) {
+	var (
+		isPrefix = true
+		err      error
+		line     []byte
+		buffer   bytes.Buffer
+	)
+	for isPrefix && err == nil {
+		line, isPrefix, err = r.r.ReadLine()
+		buffer.Write(line)
+	}
+	buffer.WriteByte('\n')
+	r.line++
+	return buffer.Bytes(), err
+}
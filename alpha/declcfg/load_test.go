@@ -364,12 +364,94 @@ func TestLoadFS(t *testing.T) {
 			s.assertion(t, err)
 			if err == nil {
 				require.NotNil(t, cfg)
+				clearLocations(cfg)
 				equalsDeclarativeConfig(t, *s.expected, *cfg)
 			}
 		})
 	}
 }
 
+// clearLocations removes the source locations recorded by the loader, for
+// comparison against expected configs that do not set them.
+func clearLocations(cfg *DeclarativeConfig) {
+	for i := range cfg.Packages {
+		cfg.Packages[i].Location = Location{}
+	}
+	for i := range cfg.Channels {
+		cfg.Channels[i].Location = Location{}
+	}
+	for i := range cfg.Bundles {
+		cfg.Bundles[i].Location = Location{}
+	}
+	for i := range cfg.Deprecations {
+		cfg.Deprecations[i].Location = Location{}
+	}
+	for i := range cfg.Others {
+		cfg.Others[i].Location = Location{}
+	}
+}
+
+func TestLoadFSLocations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"foo/catalog.yaml": &fstest.MapFile{Data: []byte(`---
+# foo catalog
+schema: olm.package
+name: foo
+defaultChannel: stable
+---
+
+# the stable channel
+schema: olm.channel
+package: foo
+name: stable
+entries:
+- name: foo.v0.1.0
+---
+schema: olm.bundle
+package: foo
+name: foo.v0.1.0
+image: foo-bundle:v0.1.0
+properties: []
+`)},
+		"bar/catalog.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package", "name": "bar"}
+
+{
+  "schema": "olm.deprecations",
+  "package": "bar",
+  "entries": []
+}
+{"schema": "custom", "package": "bar"}
+`)},
+	}
+	cfg, err := LoadFS(context.Background(), fsys)
+	require.NoError(t, err)
+
+	locations := map[string]Location{}
+	for _, p := range cfg.Packages {
+		locations["package/"+p.Name] = p.Location
+	}
+	for _, c := range cfg.Channels {
+		locations["channel/"+c.Name] = c.Location
+	}
+	for _, b := range cfg.Bundles {
+		locations["bundle/"+b.Name] = b.Location
+	}
+	for _, d := range cfg.Deprecations {
+		locations["deprecations/"+d.Package] = d.Location
+	}
+	for _, o := range cfg.Others {
+		locations["other/"+o.Schema] = o.Location
+	}
+	assert.Equal(t, map[string]Location{
+		"package/foo":       {Path: "foo/catalog.yaml", Line: 3},
+		"channel/stable":    {Path: "foo/catalog.yaml", Line: 9},
+		"bundle/foo.v0.1.0": {Path: "foo/catalog.yaml", Line: 15},
+		"package/bar":       {Path: "bar/catalog.json", Line: 1},
+		"deprecations/bar":  {Path: "bar/catalog.json", Line: 3},
+		"other/custom":      {Path: "bar/catalog.json", Line: 8},
+	}, locations)
+}
+
 func toJSON(t *testing.T, in []byte) string {
 	t.Helper()
 	out, err := yaml.ToJSON(in)
@@ -0,0 +1,393 @@
+package declcfg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MergePolicy determines how MergeConfigs resolves objects that are defined
+// differently by more than one source.
+type MergePolicy string
+
+const (
+	// MergePolicyError fails the merge if any object is defined differently
+	// by more than one source.
+	MergePolicyError MergePolicy = "error"
+
+	// MergePolicyLastWins replaces conflicting objects as a whole with the
+	// definition from the source that comes last.
+	MergePolicyLastWins MergePolicy = "last-wins"
+
+	// MergePolicyUnion combines the entries of channels and deprecations
+	// defined by more than one source, so that sources can contribute
+	// bundles to the same channels. Conflicting definitions of the same
+	// channel entry, deprecation entry or any other object are resolved in
+	// favor of the source that comes last.
+	MergePolicyUnion MergePolicy = "union"
+)
+
+// MergePolicies is the list of supported merge policies.
+var MergePolicies = []MergePolicy{MergePolicyError, MergePolicyLastWins, MergePolicyUnion}
+
+// MergeSource is a named declarative config to be merged by MergeConfigs.
+// The name identifies the source in conflict reports.
+type MergeSource struct {
+	Name   string
+	Config DeclarativeConfig
+}
+
+// MergeOrigin identifies where a conflicting definition came from.
+type MergeOrigin struct {
+	Source   string
+	Location Location
+}
+
+func (o MergeOrigin) String() string {
+	if loc := o.Location.String(); loc != "" {
+		return fmt.Sprintf("%q (%s)", o.Source, loc)
+	}
+	return fmt.Sprintf("%q", o.Source)
+}
+
+// MergeConflict describes an object that is defined differently by two
+// sources.
+type MergeConflict struct {
+	// Schema is the schema of the conflicting object.
+	Schema string
+	// Package is the package of the conflicting object. For packages, it is
+	// the name of the package itself.
+	Package string
+	// Name is the name of the conflicting object. It is empty for packages
+	// and deprecations.
+	Name string
+	// Entry identifies the conflicting channel entry or deprecation entry
+	// when entries are merged with MergePolicyUnion.
+	Entry string
+	// Fields lists the fields whose values differ, if known.
+	Fields []string
+
+	// Existing is the origin of the definition merged so far, and Incoming
+	// is the origin of the definition that conflicts with it.
+	Existing MergeOrigin
+	Incoming MergeOrigin
+}
+
+func (c MergeConflict) String() string {
+	var subject string
+	switch c.Schema {
+	case SchemaPackage:
+		subject = fmt.Sprintf("package %q", c.Package)
+	case SchemaDeprecation:
+		subject = fmt.Sprintf("deprecations of package %q", c.Package)
+	default:
+		kind := strings.TrimPrefix(c.Schema, "olm.")
+		switch {
+		case c.Package != "" && c.Name != "":
+			subject = fmt.Sprintf("%s %q", kind, c.Package+"/"+c.Name)
+		case c.Name != "":
+			subject = fmt.Sprintf("%s %q", kind, c.Name)
+		default:
+			subject = kind
+		}
+	}
+	if c.Entry != "" {
+		subject = fmt.Sprintf("%s entry %q", subject, c.Entry)
+	}
+	msg := fmt.Sprintf("%s is defined differently by %s and %s", subject, c.Existing, c.Incoming)
+	if len(c.Fields) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(c.Fields, ", "))
+	}
+	return msg
+}
+
+// MergeConfigs merges the given sources, in order, into a single declarative
+// config. Objects that are defined identically by several sources are
+// included once. Objects that are defined differently are resolved according
+// to policy, and are returned as conflicts. With MergePolicyError, an error
+// is returned if there are any conflicts.
+//
+// The merged config preserves the order in which objects are first defined,
+// so merging the same sources in the same order always produces the same
+// result.
+func MergeConfigs(policy MergePolicy, sources ...MergeSource) (*DeclarativeConfig, []MergeConflict, error) {
+	switch policy {
+	case MergePolicyError, MergePolicyLastWins, MergePolicyUnion:
+	default:
+		return nil, nil, fmt.Errorf("unknown merge policy %q, expected one of %v", policy, MergePolicies)
+	}
+
+	m := newMerger(policy)
+	for i, src := range sources {
+		name := src.Name
+		if name == "" {
+			name = fmt.Sprintf("source[%d]", i)
+		}
+		m.add(name, src.Config)
+	}
+
+	if policy == MergePolicyError && len(m.conflicts) > 0 {
+		errs := make([]error, 0, len(m.conflicts))
+		for _, c := range m.conflicts {
+			errs = append(errs, errors.New(c.String()))
+		}
+		return nil, m.conflicts, fmt.Errorf("found %d merge conflict(s): %w", len(m.conflicts), errors.Join(errs...))
+	}
+	return &m.cfg, m.conflicts, nil
+}
+
+// mergedObject records the position of an object in the merged config and
+// the origin of its current definition.
+type mergedObject struct {
+	index  int
+	origin MergeOrigin
+}
+
+type otherKey struct {
+	Schema  string
+	Package string
+	Name    string
+}
+
+type merger struct {
+	policy    MergePolicy
+	cfg       DeclarativeConfig
+	conflicts []MergeConflict
+
+	packages     map[string]mergedObject
+	channels     map[packageScopedKey]mergedObject
+	bundles      map[packageScopedKey]mergedObject
+	deprecations map[string]mergedObject
+	others       map[otherKey]mergedObject
+
+	// channelEntries and deprecationEntries track the origins of individual
+	// entries, which are merged separately with MergePolicyUnion.
+	channelEntries     map[packageScopedKey]map[string]mergedObject
+	deprecationEntries map[string]map[PackageScopedReference]mergedObject
+}
+
+func newMerger(policy MergePolicy) *merger {
+	return &merger{
+		policy:             policy,
+		packages:           map[string]mergedObject{},
+		channels:           map[packageScopedKey]mergedObject{},
+		bundles:            map[packageScopedKey]mergedObject{},
+		deprecations:       map[string]mergedObject{},
+		others:             map[otherKey]mergedObject{},
+		channelEntries:     map[packageScopedKey]map[string]mergedObject{},
+		deprecationEntries: map[string]map[PackageScopedReference]mergedObject{},
+	}
+}
+
+func (m *merger) add(source string, cfg DeclarativeConfig) {
+	for _, p := range cfg.Packages {
+		m.addPackage(MergeOrigin{Source: source, Location: p.Location}, p)
+	}
+	for _, c := range cfg.Channels {
+		m.addChannel(MergeOrigin{Source: source, Location: c.Location}, c)
+	}
+	for _, b := range cfg.Bundles {
+		m.addBundle(MergeOrigin{Source: source, Location: b.Location}, b)
+	}
+	for _, d := range cfg.Deprecations {
+		m.addDeprecation(MergeOrigin{Source: source, Location: d.Location}, d)
+	}
+	for _, o := range cfg.Others {
+		m.addOther(MergeOrigin{Source: source, Location: o.Location}, o)
+	}
+}
+
+// resolve records a conflict and reports whether the incoming definition
+// should replace the existing one.
+func (m *merger) resolve(c MergeConflict) bool {
+	m.conflicts = append(m.conflicts, c)
+	return m.policy != MergePolicyError
+}
+
+func (m *merger) addPackage(origin MergeOrigin, p Package) {
+	existing, ok := m.packages[p.Name]
+	if !ok {
+		m.packages[p.Name] = mergedObject{index: len(m.cfg.Packages), origin: origin}
+		m.cfg.Packages = append(m.cfg.Packages, p)
+		return
+	}
+	fields := packageChangedFields(m.cfg.Packages[existing.index], p)
+	if len(fields) == 0 {
+		return
+	}
+	if m.resolve(MergeConflict{Schema: SchemaPackage, Package: p.Name, Fields: fields, Existing: existing.origin, Incoming: origin}) {
+		m.cfg.Packages[existing.index] = p
+		m.packages[p.Name] = mergedObject{index: existing.index, origin: origin}
+	}
+}
+
+func (m *merger) addChannel(origin MergeOrigin, c Channel) {
+	key := packageScopedKey{c.Package, c.Name}
+	existing, ok := m.channels[key]
+	if !ok {
+		m.cfg.Channels = append(m.cfg.Channels, Channel{})
+		m.setChannel(len(m.cfg.Channels)-1, origin, c)
+		return
+	}
+
+	cur := &m.cfg.Channels[existing.index]
+	if m.policy != MergePolicyUnion {
+		var fields []string
+		if len(diffChannelEntries(cur.Entries, c.Entries)) > 0 {
+			fields = append(fields, "entries")
+		}
+		if !propertiesEqual(cur.Properties, c.Properties) {
+			fields = append(fields, "properties")
+		}
+		if len(fields) == 0 {
+			return
+		}
+		if m.resolve(MergeConflict{Schema: SchemaChannel, Package: c.Package, Name: c.Name, Fields: fields, Existing: existing.origin, Incoming: origin}) {
+			m.setChannel(existing.index, origin, c)
+		}
+		return
+	}
+
+	if !propertiesEqual(cur.Properties, c.Properties) {
+		m.resolve(MergeConflict{Schema: SchemaChannel, Package: c.Package, Name: c.Name, Fields: []string{"properties"}, Existing: existing.origin, Incoming: origin})
+		cur.Properties = c.Properties
+		m.channels[key] = mergedObject{index: existing.index, origin: origin}
+	}
+	entries := m.channelEntries[key]
+	for _, e := range c.Entries {
+		existingEntry, ok := entries[e.Name]
+		if !ok {
+			entries[e.Name] = mergedObject{index: len(cur.Entries), origin: origin}
+			cur.Entries = append(cur.Entries, e)
+			continue
+		}
+		fields := channelEntryChangedFields(cur.Entries[existingEntry.index], e)
+		if len(fields) == 0 {
+			continue
+		}
+		m.resolve(MergeConflict{Schema: SchemaChannel, Package: c.Package, Name: c.Name, Entry: e.Name, Fields: fields, Existing: existingEntry.origin, Incoming: origin})
+		cur.Entries[existingEntry.index] = e
+		entries[e.Name] = mergedObject{index: existingEntry.index, origin: origin}
+	}
+}
+
+// setChannel stores c at index in the merged channels. The entries of c are
+// copied, since they may be modified by later unions.
+func (m *merger) setChannel(index int, origin MergeOrigin, c Channel) {
+	key := packageScopedKey{c.Package, c.Name}
+	c.Entries = append([]ChannelEntry(nil), c.Entries...)
+	m.channels[key] = mergedObject{index: index, origin: origin}
+	m.cfg.Channels[index] = c
+	entries := map[string]mergedObject{}
+	for i, e := range c.Entries {
+		entries[e.Name] = mergedObject{index: i, origin: origin}
+	}
+	m.channelEntries[key] = entries
+}
+
+func (m *merger) addBundle(origin MergeOrigin, b Bundle) {
+	key := packageScopedKey{b.Package, b.Name}
+	existing, ok := m.bundles[key]
+	if !ok {
+		m.bundles[key] = mergedObject{index: len(m.cfg.Bundles), origin: origin}
+		m.cfg.Bundles = append(m.cfg.Bundles, b)
+		return
+	}
+	fields := bundleChangedFields(m.cfg.Bundles[existing.index], b)
+	if len(fields) == 0 {
+		return
+	}
+	if m.resolve(MergeConflict{Schema: SchemaBundle, Package: b.Package, Name: b.Name, Fields: fields, Existing: existing.origin, Incoming: origin}) {
+		m.cfg.Bundles[existing.index] = b
+		m.bundles[key] = mergedObject{index: existing.index, origin: origin}
+	}
+}
+
+func (m *merger) addDeprecation(origin MergeOrigin, d Deprecation) {
+	existing, ok := m.deprecations[d.Package]
+	if !ok {
+		d.Entries = append([]DeprecationEntry(nil), d.Entries...)
+		m.deprecations[d.Package] = mergedObject{index: len(m.cfg.Deprecations), origin: origin}
+		m.cfg.Deprecations = append(m.cfg.Deprecations, d)
+		m.deprecationEntries[d.Package] = deprecationEntryOrigins(origin, d)
+		return
+	}
+
+	cur := &m.cfg.Deprecations[existing.index]
+	if m.policy != MergePolicyUnion {
+		if len(diffDeprecations([]Deprecation{*cur}, []Deprecation{d})) == 0 {
+			return
+		}
+		if m.resolve(MergeConflict{Schema: SchemaDeprecation, Package: d.Package, Fields: []string{"entries"}, Existing: existing.origin, Incoming: origin}) {
+			d.Entries = append([]DeprecationEntry(nil), d.Entries...)
+			*cur = d
+			m.deprecations[d.Package] = mergedObject{index: existing.index, origin: origin}
+			m.deprecationEntries[d.Package] = deprecationEntryOrigins(origin, d)
+		}
+		return
+	}
+
+	entries := m.deprecationEntries[d.Package]
+	for _, e := range d.Entries {
+		existingEntry, ok := entries[e.Reference]
+		if !ok {
+			entries[e.Reference] = mergedObject{index: len(cur.Entries), origin: origin}
+			cur.Entries = append(cur.Entries, e)
+			continue
+		}
+		if cur.Entries[existingEntry.index].Message == e.Message {
+			continue
+		}
+		m.resolve(MergeConflict{Schema: SchemaDeprecation, Package: d.Package, Entry: referenceString(e.Reference), Fields: []string{"message"}, Existing: existingEntry.origin, Incoming: origin})
+		cur.Entries[existingEntry.index] = e
+		entries[e.Reference] = mergedObject{index: existingEntry.index, origin: origin}
+	}
+}
+
+func deprecationEntryOrigins(origin MergeOrigin, d Deprecation) map[PackageScopedReference]mergedObject {
+	entries := map[PackageScopedReference]mergedObject{}
+	for i, e := range d.Entries {
+		entries[e.Reference] = mergedObject{index: i, origin: origin}
+	}
+	return entries
+}
+
+func referenceString(ref PackageScopedReference) string {
+	if ref.Name == "" {
+		return ref.Schema
+	}
+	return ref.Schema + "/" + ref.Name
+}
+
+// addOther merges objects with unrecognized schemas. Named objects are
+// identified by schema, package and name. Unnamed objects cannot conflict,
+// and are only deduplicated if their blobs are identical.
+func (m *merger) addOther(origin MergeOrigin, o Meta) {
+	key := otherKey{Schema: o.Schema, Package: o.Package, Name: o.Name}
+	if o.Name == "" {
+		key.Name = "\x00" + compactJSON(o.Blob)
+	}
+	existing, ok := m.others[key]
+	if !ok {
+		m.others[key] = mergedObject{index: len(m.cfg.Others), origin: origin}
+		m.cfg.Others = append(m.cfg.Others, o)
+		return
+	}
+	if compactJSON(m.cfg.Others[existing.index].Blob) == compactJSON(o.Blob) {
+		return
+	}
+	if m.resolve(MergeConflict{Schema: o.Schema, Package: o.Package, Name: o.Name, Existing: existing.origin, Incoming: origin}) {
+		m.cfg.Others[existing.index] = o
+		m.others[key] = mergedObject{index: existing.index, origin: origin}
+	}
+}
+
+func compactJSON(in json.RawMessage) string {
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, in); err != nil {
+		return string(in)
+	}
+	return buf.String()
+}
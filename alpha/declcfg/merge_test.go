@@ -0,0 +1,217 @@
+package declcfg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfigs(t *testing.T) {
+	type spec struct {
+		name            string
+		policy          MergePolicy
+		sources         []MergeSource
+		assertion       require.ErrorAssertionFunc
+		expected        *DeclarativeConfig
+		expectConflicts []MergeConflict
+	}
+
+	teamA := MergeSource{
+		Name: "team-a",
+		Config: DeclarativeConfig{
+			Packages: []Package{{Schema: SchemaPackage, Name: "foo", DefaultChannel: "stable"}},
+			Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{
+				{Name: "foo.v0.1.0"},
+			}, Location: Location{Path: "a/foo.yaml", Line: 5}}},
+			Bundles: []Bundle{newTestBundle("foo", "0.1.0")},
+			Deprecations: []Deprecation{{Schema: SchemaDeprecation, Package: "foo", Entries: []DeprecationEntry{
+				{Reference: PackageScopedReference{Schema: SchemaBundle, Name: "foo.v0.1.0"}, Message: "foo.v0.1.0 is deprecated"},
+			}}},
+			Others: []Meta{{Schema: "custom", Package: "foo", Name: "x", Blob: json.RawMessage(`{"schema":"custom","package":"foo","name":"x"}`)}},
+		},
+	}
+	teamB := MergeSource{
+		Name: "team-b",
+		Config: DeclarativeConfig{
+			Packages: []Package{{Schema: SchemaPackage, Name: "foo", DefaultChannel: "stable"}},
+			Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{
+				{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0"},
+			}, Location: Location{Path: "b/foo.yaml", Line: 9}}},
+			Bundles: []Bundle{newTestBundle("foo", "0.2.0")},
+			Deprecations: []Deprecation{{Schema: SchemaDeprecation, Package: "foo", Entries: []DeprecationEntry{
+				{Reference: PackageScopedReference{Schema: SchemaPackage}, Message: "foo is deprecated"},
+			}}},
+			Others: []Meta{{Schema: "custom", Package: "foo", Name: "x", Blob: json.RawMessage(`{ "schema": "custom", "package": "foo", "name": "x" }`)}},
+		},
+	}
+	teamC := MergeSource{
+		Name: "team-c",
+		Config: DeclarativeConfig{
+			Packages: []Package{{Schema: SchemaPackage, Name: "foo", DefaultChannel: "fast"}},
+			Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{
+				{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", Skips: []string{"foo.v0.1.1"}},
+			}}},
+			Bundles: []Bundle{newTestBundle("foo", "0.2.0", func(b *Bundle) {
+				b.Image = "foo-bundle:v0.2.0-rebuilt"
+			})},
+		},
+	}
+
+	stableChannelConflict := MergeConflict{
+		Schema:   SchemaChannel,
+		Package:  "foo",
+		Name:     "stable",
+		Fields:   []string{"entries"},
+		Existing: MergeOrigin{Source: "team-a", Location: Location{Path: "a/foo.yaml", Line: 5}},
+		Incoming: MergeOrigin{Source: "team-b", Location: Location{Path: "b/foo.yaml", Line: 9}},
+	}
+	deprecationConflict := MergeConflict{
+		Schema:   SchemaDeprecation,
+		Package:  "foo",
+		Fields:   []string{"entries"},
+		Existing: MergeOrigin{Source: "team-a"},
+		Incoming: MergeOrigin{Source: "team-b"},
+	}
+
+	specs := []spec{
+		{
+			name:      "Error/UnknownPolicy",
+			policy:    "first-wins",
+			sources:   []MergeSource{teamA},
+			assertion: hasError(`unknown merge policy "first-wins", expected one of [error last-wins union]`),
+		},
+		{
+			name:            "Error/Conflicts",
+			policy:          MergePolicyError,
+			sources:         []MergeSource{teamA, teamB},
+			assertion:       require.Error,
+			expectConflicts: []MergeConflict{stableChannelConflict, deprecationConflict},
+		},
+		{
+			name:      "Success/ErrorPolicyIdenticalSources",
+			policy:    MergePolicyError,
+			sources:   []MergeSource{teamA, teamA},
+			assertion: require.NoError,
+			expected:  &teamA.Config,
+		},
+		{
+			name:      "Success/LastWins",
+			policy:    MergePolicyLastWins,
+			sources:   []MergeSource{teamA, teamB},
+			assertion: require.NoError,
+			expected: &DeclarativeConfig{
+				Packages:     teamA.Config.Packages,
+				Channels:     teamB.Config.Channels,
+				Bundles:      append(append([]Bundle{}, teamA.Config.Bundles...), teamB.Config.Bundles...),
+				Deprecations: teamB.Config.Deprecations,
+				Others:       teamA.Config.Others,
+			},
+			expectConflicts: []MergeConflict{stableChannelConflict, deprecationConflict},
+		},
+		{
+			name:      "Success/Union",
+			policy:    MergePolicyUnion,
+			sources:   []MergeSource{teamA, teamB},
+			assertion: require.NoError,
+			expected: &DeclarativeConfig{
+				Packages: teamA.Config.Packages,
+				Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{
+					{Name: "foo.v0.1.0"},
+					{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0"},
+				}, Location: Location{Path: "a/foo.yaml", Line: 5}}},
+				Bundles: append(append([]Bundle{}, teamA.Config.Bundles...), teamB.Config.Bundles...),
+				Deprecations: []Deprecation{{Schema: SchemaDeprecation, Package: "foo", Entries: []DeprecationEntry{
+					{Reference: PackageScopedReference{Schema: SchemaBundle, Name: "foo.v0.1.0"}, Message: "foo.v0.1.0 is deprecated"},
+					{Reference: PackageScopedReference{Schema: SchemaPackage}, Message: "foo is deprecated"},
+				}}},
+				Others: teamA.Config.Others,
+			},
+		},
+		{
+			name:      "Success/UnionConflicts",
+			policy:    MergePolicyUnion,
+			sources:   []MergeSource{teamA, teamB, teamC},
+			assertion: require.NoError,
+			expected: &DeclarativeConfig{
+				Packages: teamC.Config.Packages,
+				Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{
+					{Name: "foo.v0.1.0"},
+					{Name: "foo.v0.2.0", Replaces: "foo.v0.1.0", Skips: []string{"foo.v0.1.1"}},
+				}, Location: Location{Path: "a/foo.yaml", Line: 5}}},
+				Bundles: append(append([]Bundle{}, teamA.Config.Bundles...), teamC.Config.Bundles...),
+				Deprecations: []Deprecation{{Schema: SchemaDeprecation, Package: "foo", Entries: []DeprecationEntry{
+					{Reference: PackageScopedReference{Schema: SchemaBundle, Name: "foo.v0.1.0"}, Message: "foo.v0.1.0 is deprecated"},
+					{Reference: PackageScopedReference{Schema: SchemaPackage}, Message: "foo is deprecated"},
+				}}},
+				Others: teamA.Config.Others,
+			},
+			expectConflicts: []MergeConflict{
+				{
+					Schema:   SchemaPackage,
+					Package:  "foo",
+					Fields:   []string{"defaultChannel"},
+					Existing: MergeOrigin{Source: "team-a"},
+					Incoming: MergeOrigin{Source: "team-c"},
+				},
+				{
+					Schema:   SchemaChannel,
+					Package:  "foo",
+					Name:     "stable",
+					Entry:    "foo.v0.2.0",
+					Fields:   []string{"skips"},
+					Existing: MergeOrigin{Source: "team-b", Location: Location{Path: "b/foo.yaml", Line: 9}},
+					Incoming: MergeOrigin{Source: "team-c"},
+				},
+				{
+					Schema:   SchemaBundle,
+					Package:  "foo",
+					Name:     "foo.v0.2.0",
+					Fields:   []string{"image"},
+					Existing: MergeOrigin{Source: "team-b"},
+					Incoming: MergeOrigin{Source: "team-c"},
+				},
+			},
+		},
+	}
+
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			actual, conflicts, err := MergeConfigs(s.policy, s.sources...)
+			s.assertion(t, err)
+			require.Equal(t, s.expectConflicts, conflicts)
+			require.Equal(t, s.expected, actual)
+		})
+	}
+}
+
+func TestMergeConfigsDoesNotModifySources(t *testing.T) {
+	a := DeclarativeConfig{Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{{Name: "foo.v0.1.0"}}}}}
+	b := DeclarativeConfig{Channels: []Channel{{Schema: SchemaChannel, Package: "foo", Name: "stable", Entries: []ChannelEntry{{Name: "foo.v0.2.0"}}}}}
+
+	_, _, err := MergeConfigs(MergePolicyUnion, MergeSource{Config: a}, MergeSource{Config: b})
+	require.NoError(t, err)
+	require.Equal(t, []ChannelEntry{{Name: "foo.v0.1.0"}}, a.Channels[0].Entries)
+}
+
+func TestMergeConflictString(t *testing.T) {
+	c := MergeConflict{
+		Schema:   SchemaBundle,
+		Package:  "foo",
+		Name:     "foo.v0.2.0",
+		Fields:   []string{"image"},
+		Existing: MergeOrigin{Source: "team-a", Location: Location{Path: "a/foo.yaml", Line: 12}},
+		Incoming: MergeOrigin{Source: "team-b"},
+	}
+	require.Equal(t, `bundle "foo/foo.v0.2.0" is defined differently by "team-a" (a/foo.yaml:12) and "team-b": image`, c.String())
+
+	c = MergeConflict{
+		Schema:   SchemaDeprecation,
+		Package:  "foo",
+		Entry:    referenceString(PackageScopedReference{Schema: SchemaChannel, Name: "stable"}),
+		Fields:   []string{"message"},
+		Existing: MergeOrigin{Source: "team-a"},
+		Incoming: MergeOrigin{Source: "team-b"},
+	}
+	require.Equal(t, `deprecations of package "foo" entry "olm.channel/stable" is defined differently by "team-a" and "team-b": message`, c.String())
+}
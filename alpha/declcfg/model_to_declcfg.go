@@ -29,6 +29,7 @@ func ConvertFromModel(mpkgs model.Model) DeclarativeConfig {
 			DefaultChannel: defaultChannel,
 			Icon:           i,
 			Description:    mpkg.Description,
+			Location:       mpkg.Location,
 		})
 		cfg.Channels = append(cfg.Channels, channels...)
 		cfg.Bundles = append(cfg.Bundles, bundles...)
@@ -68,6 +69,7 @@ func traverseModelChannels(mpkg model.Package) ([]Channel, []Bundle) {
 			//   DO NOT use it for any public-facing functionalities.
 			//   This API is in alpha stage and it is subject to change.
 			Properties: ch.Properties,
+			Location:   ch.Location,
 		}
 
 		for _, chb := range ch.Bundles {
@@ -90,6 +92,7 @@ func traverseModelChannels(mpkg model.Package) ([]Channel, []Bundle) {
 					RelatedImages: ModelRelatedImagesToRelatedImages(chb.RelatedImages),
 					CsvJSON:       chb.CsvJSON,
 					Objects:       chb.Objects,
+					Location:      chb.Location,
 				}
 				bundleMap[b.Name] = b
 			}
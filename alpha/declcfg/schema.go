@@ -0,0 +1,162 @@
+package declcfg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// SchemaValidateFunc validates an object that was decoded into the Go type
+// registered for its schema. obj is a pointer to that type.
+type SchemaValidateFunc func(obj interface{}) error
+
+type schemaRegistration struct {
+	typ      reflect.Type
+	validate SchemaValidateFunc
+}
+
+// SchemaRegistry maps the schemas of objects that are not one of the olm.*
+// schemas to Go types and validators. Objects with a registered schema are
+// decoded into their Go type when loaded, and checked by their validator
+// when the registry validates a declarative config.
+//
+// A SchemaRegistry is safe for concurrent use.
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]schemaRegistration
+}
+
+// NewSchemaRegistry returns an empty schema registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: map[string]schemaRegistration{}}
+}
+
+// DefaultSchemaRegistry is the registry used when loading declarative
+// configs, unless another registry is provided with WithSchemaRegistry.
+var DefaultSchemaRegistry = NewSchemaRegistry()
+
+// RegisterSchema registers prototype and validate for schema in the
+// DefaultSchemaRegistry. It panics if the registration fails, and is intended
+// to be called from init functions.
+func RegisterSchema(schema string, prototype interface{}, validate SchemaValidateFunc) {
+	if err := DefaultSchemaRegistry.Register(schema, prototype, validate); err != nil {
+		panic(err)
+	}
+}
+
+// Register registers the type of prototype, which must be a pointer, for
+// objects with the given schema. Objects are decoded with encoding/json and
+// unknown fields are rejected, so the type must declare every field that
+// objects of the schema may have, including the schema field itself.
+// validate is optional.
+func (r *SchemaRegistry) Register(schema string, prototype interface{}, validate SchemaValidateFunc) error {
+	switch schema {
+	case "":
+		return fmt.Errorf("schema must not be empty")
+	case SchemaPackage, SchemaChannel, SchemaBundle, SchemaDeprecation:
+		return fmt.Errorf("schema %q is reserved", schema)
+	}
+	t := reflect.TypeOf(prototype)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return fmt.Errorf("prototype for schema %q must be a pointer to a type", schema)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.schemas[schema]; ok {
+		return fmt.Errorf("schema %q is already registered", schema)
+	}
+	r.schemas[schema] = schemaRegistration{typ: t.Elem(), validate: validate}
+	return nil
+}
+
+// Schemas returns the sorted list of registered schemas.
+func (r *SchemaRegistry) Schemas() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schemas := make([]string, 0, len(r.schemas))
+	for schema := range r.schemas {
+		schemas = append(schemas, schema)
+	}
+	sort.Strings(schemas)
+	return schemas
+}
+
+func (r *SchemaRegistry) lookup(schema string) (schemaRegistration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, ok := r.schemas[schema]
+	return reg, ok
+}
+
+// Decode decodes the blob of meta into the Go type registered for its schema
+// and stores the result in meta.Object. Metas with unregistered schemas are
+// left unchanged.
+func (r *SchemaRegistry) Decode(meta *Meta) error {
+	reg, ok := r.lookup(meta.Schema)
+	if !ok {
+		return nil
+	}
+	obj, err := reg.decode(meta.Blob)
+	if err != nil {
+		return locationErrorf(meta.Location, "parse %s%s: %v", meta.Schema, describeMetaName(*meta), err)
+	}
+	meta.Object = obj
+	return nil
+}
+
+func (reg schemaRegistration) decode(blob json.RawMessage) (interface{}, error) {
+	obj := reflect.New(reg.typ).Interface()
+	dec := json.NewDecoder(bytes.NewReader(blob))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Validate runs the validators of the registered schemas against the
+// matching objects in cfg.Others. Objects that have not already been decoded
+// by this registry are decoded first, so decoding errors are also reported.
+// All errors are aggregated.
+func (r *SchemaRegistry) Validate(cfg DeclarativeConfig) error {
+	var errs []error
+	for _, o := range cfg.Others {
+		reg, ok := r.lookup(o.Schema)
+		if !ok {
+			continue
+		}
+		obj := o.Object
+		if obj == nil || reflect.TypeOf(obj) != reflect.PointerTo(reg.typ) {
+			var err error
+			if obj, err = reg.decode(o.Blob); err != nil {
+				errs = append(errs, locationErrorf(o.Location, "parse %s%s: %v", o.Schema, describeMetaName(o), err))
+				continue
+			}
+		}
+		if reg.validate == nil {
+			continue
+		}
+		if err := reg.validate(obj); err != nil {
+			errs = append(errs, locationErrorf(o.Location, "invalid %s%s: %v", o.Schema, describeMetaName(o), err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func describeMetaName(m Meta) string {
+	switch {
+	case m.Package != "" && m.Name != "":
+		return fmt.Sprintf(" %q", m.Package+"/"+m.Name)
+	case m.Name != "":
+		return fmt.Sprintf(" %q", m.Name)
+	case m.Package != "":
+		return fmt.Sprintf(" in package %q", m.Package)
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package declcfg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetadata struct {
+	Schema  string `json:"schema"`
+	Package string `json:"package"`
+	Name    string `json:"name"`
+	Owner   string `json:"owner"`
+}
+
+func validateTestMetadata(obj interface{}) error {
+	if obj.(*testMetadata).Owner == "" {
+		return errors.New("owner must be set")
+	}
+	return nil
+}
+
+func TestSchemaRegistryRegister(t *testing.T) {
+	type spec struct {
+		name      string
+		schema    string
+		prototype interface{}
+		assertion require.ErrorAssertionFunc
+	}
+	specs := []spec{
+		{
+			name:      "Success",
+			schema:    "example.com/metadata",
+			prototype: &testMetadata{},
+			assertion: require.NoError,
+		},
+		{
+			name:      "Error/AlreadyRegistered",
+			schema:    "example.com/existing",
+			prototype: &testMetadata{},
+			assertion: hasError(`schema "example.com/existing" is already registered`),
+		},
+		{
+			name:      "Error/Reserved",
+			schema:    SchemaBundle,
+			prototype: &testMetadata{},
+			assertion: hasError(`schema "olm.bundle" is reserved`),
+		},
+		{
+			name:      "Error/EmptySchema",
+			schema:    "",
+			prototype: &testMetadata{},
+			assertion: hasError(`schema must not be empty`),
+		},
+		{
+			name:      "Error/NotAPointer",
+			schema:    "example.com/metadata",
+			prototype: testMetadata{},
+			assertion: hasError(`prototype for schema "example.com/metadata" must be a pointer to a type`),
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			r := NewSchemaRegistry()
+			require.NoError(t, r.Register("example.com/existing", &testMetadata{}, nil))
+			s.assertion(t, r.Register(s.schema, s.prototype, validateTestMetadata))
+		})
+	}
+}
+
+func TestSchemaRegistryLoadAndValidate(t *testing.T) {
+	r := NewSchemaRegistry()
+	require.NoError(t, r.Register("example.com/metadata", &testMetadata{}, validateTestMetadata))
+	require.Equal(t, []string{"example.com/metadata"}, r.Schemas())
+
+	t.Run("Decode", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"foo/catalog.yaml": &fstest.MapFile{Data: []byte(`---
+schema: example.com/metadata
+package: foo
+name: ownership
+owner: team-a
+---
+schema: example.com/other
+package: foo
+`)},
+		}
+		cfg, err := LoadFS(context.Background(), fsys, WithSchemaRegistry(r))
+		require.NoError(t, err)
+		require.Len(t, cfg.Others, 2)
+		objects := map[string]interface{}{}
+		for _, o := range cfg.Others {
+			objects[o.Schema] = o.Object
+		}
+		assert.Equal(t, map[string]interface{}{
+			"example.com/metadata": &testMetadata{Schema: "example.com/metadata", Package: "foo", Name: "ownership", Owner: "team-a"},
+			"example.com/other":    nil,
+		}, objects)
+		require.NoError(t, r.Validate(*cfg))
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"foo/catalog.yaml": &fstest.MapFile{Data: []byte(`---
+schema: example.com/metadata
+package: foo
+name: ownership
+ownr: team-a
+`)},
+		}
+		_, err := LoadFS(context.Background(), fsys, WithSchemaRegistry(r))
+		require.EqualError(t, err, `foo/catalog.yaml:2: parse example.com/metadata "foo/ownership": json: unknown field "ownr"`)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		cfg := DeclarativeConfig{Others: []Meta{
+			{
+				Schema:   "example.com/metadata",
+				Package:  "foo",
+				Name:     "ownership",
+				Blob:     []byte(`{"schema":"example.com/metadata","package":"foo","name":"ownership"}`),
+				Location: Location{Path: "foo/catalog.yaml", Line: 7},
+			},
+			{
+				Schema: "example.com/metadata",
+				Name:   "global",
+				Blob:   []byte(`{"schema":"example.com/metadata","name":"global","owner":{}}`),
+			},
+			{
+				Schema: "example.com/other",
+				Blob:   []byte(`{"schema":"example.com/other"}`),
+			},
+		}}
+		err := r.Validate(cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `foo/catalog.yaml:7: invalid example.com/metadata "foo/ownership": owner must be set`)
+		assert.Contains(t, err.Error(), `parse example.com/metadata "global": json: cannot unmarshal object into Go struct field testMetadata.owner of type string`)
+	})
+}
@@ -498,6 +498,10 @@ func writeToEncoder(cfg DeclarativeConfig, enc encoder) error {
 
 type WriteFunc func(config DeclarativeConfig, w io.Writer) error
 
+// WriteFS writes the packages of cfg, along with their channels and bundles,
+// to <rootDir>/<package>/catalog<fileExt>. If fileExt ends with the extension
+// of a supported compression, such as ".json.gz" or ".yaml.zst", the files
+// are compressed.
 func WriteFS(cfg DeclarativeConfig, rootDir string, writeFunc WriteFunc, fileExt string) error {
 	channelsByPackage := map[string][]Channel{}
 	for _, c := range cfg.Channels {
@@ -530,11 +534,21 @@ func WriteFS(cfg DeclarativeConfig, rootDir string, writeFunc WriteFunc, fileExt
 	return nil
 }
 
+// writeFile writes cfg to filename using writeFunc. If filename has the
+// extension of a supported compression (e.g. catalog.json.gz), the output of
+// writeFunc is compressed.
 func writeFile(cfg DeclarativeConfig, filename string, writeFunc WriteFunc) error {
 	buf := &bytes.Buffer{}
-	if err := writeFunc(cfg, buf); err != nil {
+	w, err := compressWriter(filename, buf)
+	if err != nil {
+		return fmt.Errorf("create writer for %q: %v", filename, err)
+	}
+	if err := writeFunc(cfg, w); err != nil {
 		return fmt.Errorf("write to buffer for %q: %v", filename, err)
 	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("compress %q: %v", filename, err)
+	}
 	if err := os.WriteFile(filename, buf.Bytes(), 0666); err != nil {
 		return fmt.Errorf("write file %q: %v", filename, err)
 	}
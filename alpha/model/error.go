@@ -2,19 +2,176 @@ package model
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 )
 
+// ErrorCode identifies the validation rule that a ValidationError reports.
+// Codes are stable, so they can be relied upon by tools that process
+// validation results.
+type ErrorCode string
+
+const (
+	// ErrorCodeUnknown is used for errors that are not reported by model
+	// validation, e.g. errors that occur while loading a catalog.
+	ErrorCodeUnknown ErrorCode = "Unknown"
+
+	ErrorCodeNameRequired           ErrorCode = "NameRequired"
+	ErrorCodeKeyMismatch            ErrorCode = "KeyMismatch"
+	ErrorCodeParentMismatch         ErrorCode = "ParentMismatch"
+	ErrorCodeDefaultChannelRequired ErrorCode = "DefaultChannelRequired"
+	ErrorCodeDefaultChannelNotFound ErrorCode = "DefaultChannelNotFound"
+	ErrorCodeNoChannels             ErrorCode = "NoChannels"
+	ErrorCodeNoBundles              ErrorCode = "NoBundles"
+	ErrorCodeDuplicateVersion       ErrorCode = "DuplicateVersion"
+	ErrorCodeInvalidDeprecation     ErrorCode = "InvalidDeprecation"
+	ErrorCodeInvalidChannelHead     ErrorCode = "InvalidChannelHead"
+	ErrorCodeReplacesCycle          ErrorCode = "ReplacesCycle"
+	ErrorCodeStrandedBundles        ErrorCode = "StrandedBundles"
+	ErrorCodeInvalidProperty        ErrorCode = "InvalidProperty"
+	ErrorCodeInvalidPackageProperty ErrorCode = "InvalidPackageProperty"
+	ErrorCodeInvalidConstraint      ErrorCode = "InvalidConstraint"
+	ErrorCodeEmptySkip              ErrorCode = "EmptySkip"
+	ErrorCodeImageRequired          ErrorCode = "ImageRequired"
+	ErrorCodeInvalidIcon            ErrorCode = "InvalidIcon"
+
+	// The following codes are reported while a declarative config is
+	// converted to a model, before the model is validated.
+
+	ErrorCodeInvalidName        ErrorCode = "InvalidName"
+	ErrorCodeInvalidVersion     ErrorCode = "InvalidVersion"
+	ErrorCodeDuplicatePackage   ErrorCode = "DuplicatePackage"
+	ErrorCodeDuplicateChannel   ErrorCode = "DuplicateChannel"
+	ErrorCodeDuplicateEntry     ErrorCode = "DuplicateEntry"
+	ErrorCodeDuplicateBundle    ErrorCode = "DuplicateBundle"
+	ErrorCodeUnknownPackage     ErrorCode = "UnknownPackage"
+	ErrorCodeBundleNotInChannel ErrorCode = "BundleNotInChannel"
+	ErrorCodeBundleNotFound     ErrorCode = "BundleNotFound"
+)
+
+// ValidationPath identifies the package, channel and bundle that failed
+// validation. Fields that do not apply to an error are empty.
+type ValidationPath struct {
+	Package string `json:"package,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	Bundle  string `json:"bundle,omitempty"`
+}
+
+func (p ValidationPath) String() string {
+	var parts []string
+	for _, part := range []string{p.Package, p.Channel, p.Bundle} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// ValidationError is a single failed validation rule. The errors returned by
+// the Validate methods of the model types are trees of ValidationErrors,
+// which can be found with errors.As, or all listed with ValidationErrors.
+type ValidationError struct {
+	Path    ValidationPath `json:"path"`
+	Code    ErrorCode      `json:"code"`
+	Message string         `json:"message"`
+	// Location is the position of the invalid object in its file-based
+	// catalog, if it is known.
+	Location *Location `json:"location,omitempty"`
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationErrors returns the ValidationErrors contained in err, in the
+// order in which they are reported by err.Error(). Errors that are not
+// ValidationErrors, and that do not wrap any, are returned with the code
+// ErrorCodeUnknown. ValidationErrors returns nil if err is nil.
+func ValidationErrors(err error) []*ValidationError {
+	var (
+		out  []*ValidationError
+		seen = map[*validationError]struct{}{}
+		walk func(error)
+	)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+			return
+		case *ValidationError:
+			out = append(out, e)
+			return
+		case *validationError:
+			if _, ok := seen[e]; ok {
+				return
+			}
+			seen[e] = struct{}{}
+		}
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, err := range u.Unwrap() {
+				walk(err)
+			}
+			return
+		case interface{ Unwrap() error }:
+			if inner := u.Unwrap(); errors.As(inner, new(*ValidationError)) {
+				walk(inner)
+				return
+			}
+		}
+		out = append(out, &ValidationError{Code: ErrorCodeUnknown, Message: err.Error()})
+	}
+	walk(err)
+	return out
+}
+
 type validationError struct {
 	message   string
 	subErrors []error
+
+	path     ValidationPath
+	location Location
 }
 
 func newValidationError(message string) *validationError {
 	return &validationError{message: message}
 }
 
+// withPath sets the path and location of the ValidationErrors created by
+// errorf and wrap.
+func (v *validationError) withPath(path ValidationPath, location Location) *validationError {
+	v.path = path
+	v.location = location
+	return v
+}
+
+// errorf adds a ValidationError with the given code to the sub-errors of v.
+func (v *validationError) errorf(code ErrorCode, format string, args ...interface{}) {
+	v.subErrors = append(v.subErrors, v.newError(code, fmt.Sprintf(format, args...)))
+}
+
+// wrap adds err to the sub-errors of v as a ValidationError with the given
+// code. If err is a ValidationError without a path, its code is kept.
+func (v *validationError) wrap(code ErrorCode, err error) {
+	if verr, ok := err.(*ValidationError); ok && verr.Path == (ValidationPath{}) {
+		code = verr.Code
+	}
+	v.subErrors = append(v.subErrors, v.newError(code, err.Error()))
+}
+
+func (v *validationError) newError(code ErrorCode, message string) *ValidationError {
+	verr := &ValidationError{Path: v.path, Code: code, Message: message}
+	if v.location != (Location{}) {
+		loc := v.location
+		verr.Location = &loc
+	}
+	return verr
+}
+
+func (v *validationError) Unwrap() []error {
+	return v.subErrors
+}
+
 func (v *validationError) orNil() error {
 	if len(v.subErrors) == 0 {
 		return nil
@@ -127,3 +127,47 @@ func TestValidationError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationErrors(t *testing.T) {
+	pkg := &Package{Name: "anakin", Location: Location{Path: "anakin/index.yaml", Line: 1}}
+	ch := &Channel{Package: pkg, Name: "dark", Location: Location{Path: "anakin/index.yaml", Line: 5}}
+	pkg.Channels = map[string]*Channel{"dark": ch}
+	ch.Bundles = map[string]*Bundle{
+		"anakin.v0.1.0": {Package: pkg, Channel: ch, Name: "anakin.v0.1.0", Image: "registry.io/image", Skips: []string{""}},
+	}
+
+	err := pkg.Validate()
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, ErrorCodeDefaultChannelRequired, verr.Code)
+
+	require.Equal(t, []*ValidationError{
+		{
+			Path:     ValidationPath{Package: "anakin"},
+			Code:     ErrorCodeDefaultChannelRequired,
+			Message:  "default channel must be set",
+			Location: &Location{Path: "anakin/index.yaml", Line: 1},
+		},
+		{
+			Path:    ValidationPath{Package: "anakin", Channel: "dark", Bundle: "anakin.v0.1.0"},
+			Code:    ErrorCodeEmptySkip,
+			Message: "skip[0] is empty",
+		},
+		{
+			Path:    ValidationPath{Package: "anakin", Channel: "dark", Bundle: "anakin.v0.1.0"},
+			Code:    ErrorCodeInvalidPackageProperty,
+			Message: `must be exactly one property with type "olm.package"`,
+		},
+	}, ValidationErrors(err))
+}
+
+func TestValidationErrorsWrapped(t *testing.T) {
+	verr := &ValidationError{Path: ValidationPath{Package: "anakin"}, Code: ErrorCodeNoChannels, Message: "package must contain at least one channel"}
+	tree := &validationError{message: "invalid index", subErrors: []error{verr, fmt.Errorf("unexpected")}}
+
+	require.Nil(t, ValidationErrors(nil))
+	require.Equal(t, []*ValidationError{verr, {Code: ErrorCodeUnknown, Message: "unexpected"}}, ValidationErrors(fmt.Errorf("convert: %w", tree)))
+	require.Equal(t, []*ValidationError{{Code: ErrorCodeUnknown, Message: "load: unexpected"}}, ValidationErrors(fmt.Errorf("load: %w", fmt.Errorf("unexpected"))))
+}
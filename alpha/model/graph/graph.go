@@ -0,0 +1,459 @@
+// Package graph provides analysis of the upgrade graphs of channels in a
+// model.Model.
+//
+// The upgrade graph of a channel has one node per bundle in the channel, and
+// an edge from bundle A to bundle B if B can be installed as an upgrade of A,
+// i.e. if B replaces A, skips A, or has a skipRange that includes the version
+// of A. Edges that refer to bundles that are not in the channel are ignored.
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/alpha/model"
+)
+
+// EdgeKind is the reason for an edge in the upgrade graph.
+type EdgeKind string
+
+const (
+	// EdgeReplaces is an edge from a bundle to the bundle that replaces it.
+	EdgeReplaces EdgeKind = "replaces"
+	// EdgeSkips is an edge from a bundle to a bundle that skips it.
+	EdgeSkips EdgeKind = "skips"
+	// EdgeSkipRange is an edge from a bundle to a bundle whose skipRange
+	// includes its version.
+	EdgeSkipRange EdgeKind = "skipRange"
+)
+
+// ErrNoBundle is returned, wrapped, by BundleForVersion when no bundle in the
+// channel has the requested version.
+var ErrNoBundle = errors.New("no bundle")
+
+// Option configures how New builds a graph.
+type Option func(*options)
+
+type options struct {
+	skipRange bool
+}
+
+// WithoutSkipRange builds a graph that only has replaces and skips edges.
+// This is the upgrade graph that the registry's GetBundleThatReplaces and
+// GetChannelEntriesThatReplace queries expose.
+func WithoutSkipRange() Option {
+	return func(o *options) {
+		o.skipRange = false
+	}
+}
+
+// Edge is an upgrade from the bundle From to the bundle To.
+type Edge struct {
+	From string
+	To   string
+	Kind EdgeKind
+}
+
+func (e Edge) String() string {
+	return fmt.Sprintf("%s -(%s)-> %s", e.From, e.Kind, e.To)
+}
+
+// Graph is the upgrade graph of a channel.
+type Graph struct {
+	channel *model.Channel
+	names   []string
+	out     map[string][]Edge
+	in      map[string][]Edge
+}
+
+// New builds the upgrade graph of ch. If a bundle is an upgrade of another
+// bundle for several reasons, the graph contains a single edge between them,
+// whose kind is the first of replaces, skips and skipRange that applies.
+func New(ch *model.Channel, opts ...Option) (*Graph, error) {
+	o := options{skipRange: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	g := &Graph{
+		channel: ch,
+		out:     map[string][]Edge{},
+		in:      map[string][]Edge{},
+	}
+	for name := range ch.Bundles {
+		g.names = append(g.names, name)
+	}
+	sort.Strings(g.names)
+
+	for _, name := range g.names {
+		b := ch.Bundles[name]
+		from := map[string]EdgeKind{}
+		if _, ok := ch.Bundles[b.Replaces]; ok {
+			from[b.Replaces] = EdgeReplaces
+		}
+		for _, skip := range b.Skips {
+			if _, ok := ch.Bundles[skip]; !ok {
+				continue
+			}
+			if _, ok := from[skip]; !ok {
+				from[skip] = EdgeSkips
+			}
+		}
+		if o.skipRange && b.SkipRange != "" {
+			skipRange, err := semver.ParseRange(b.SkipRange)
+			if err != nil {
+				return nil, fmt.Errorf("bundle %q: parse skipRange %q: %v", b.Name, b.SkipRange, err)
+			}
+			for _, other := range g.names {
+				if _, ok := from[other]; ok || other == b.Name {
+					continue
+				}
+				if skipRange(ch.Bundles[other].Version) {
+					from[other] = EdgeSkipRange
+				}
+			}
+		}
+		for f, kind := range from {
+			e := Edge{From: f, To: b.Name, Kind: kind}
+			g.out[f] = append(g.out[f], e)
+			g.in[b.Name] = append(g.in[b.Name], e)
+		}
+	}
+	for _, edges := range g.out {
+		sortEdges(edges)
+	}
+	for _, edges := range g.in {
+		sortEdges(edges)
+	}
+	return g, nil
+}
+
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+}
+
+// Channel returns the channel the graph was built from.
+func (g *Graph) Channel() *model.Channel {
+	return g.channel
+}
+
+// Bundles returns the sorted names of the bundles in the graph.
+func (g *Graph) Bundles() []string {
+	return append([]string(nil), g.names...)
+}
+
+// Upgrades returns the edges from the named bundle to the bundles that can
+// be installed as an upgrade of it, sorted by the name of the target bundle.
+func (g *Graph) Upgrades(name string) []Edge {
+	return append([]Edge(nil), g.out[name]...)
+}
+
+// UpgradesTo returns the edges to the named bundle from the bundles that it
+// can upgrade, sorted by the name of the source bundle.
+func (g *Graph) UpgradesTo(name string) []Edge {
+	return append([]Edge(nil), g.in[name]...)
+}
+
+func (g *Graph) has(name string) bool {
+	_, ok := g.channel.Bundles[name]
+	return ok
+}
+
+// Reachable returns the sorted names of the bundles that can be reached from
+// the named bundle by one or more upgrades.
+func (g *Graph) Reachable(from string) ([]string, error) {
+	if !g.has(from) {
+		return nil, fmt.Errorf("bundle %q not found in channel %q", from, g.channel.Name)
+	}
+	seen := map[string]struct{}{}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, e := range g.out[cur] {
+			if _, ok := seen[e.To]; ok {
+				continue
+			}
+			seen[e.To] = struct{}{}
+			queue = append(queue, e.To)
+		}
+	}
+	reachable := make([]string, 0, len(seen))
+	for name := range seen {
+		reachable = append(reachable, name)
+	}
+	sort.Strings(reachable)
+	return reachable, nil
+}
+
+// Heads returns the sorted names of the bundles that are not replaced or
+// skipped by any other bundle in the channel. This is the same rule that
+// model.Channel.Head uses, which requires exactly one head: skipRange is
+// not considered, so a bundle that is only included in another bundle's
+// skipRange is still a head.
+func (g *Graph) Heads() []string {
+	var heads []string
+	for _, name := range g.names {
+		isHead := true
+		for _, e := range g.out[name] {
+			if e.Kind != EdgeSkipRange {
+				isHead = false
+				break
+			}
+		}
+		if isHead {
+			heads = append(heads, name)
+		}
+	}
+	return heads
+}
+
+// Stranded returns the sorted names of the bundles that are neither on the
+// replaces chain of a channel head nor skipped by another bundle. This is
+// the rule that model.Channel.Validate uses to reject channels with stranded
+// bundles.
+func (g *Graph) Stranded() []string {
+	reached := map[string]struct{}{}
+	for _, head := range g.Heads() {
+		for cur := g.channel.Bundles[head]; cur != nil; cur = g.channel.Bundles[cur.Replaces] {
+			if _, ok := reached[cur.Name]; ok {
+				break
+			}
+			reached[cur.Name] = struct{}{}
+		}
+	}
+	for _, name := range g.names {
+		for _, skip := range g.channel.Bundles[name].Skips {
+			reached[skip] = struct{}{}
+		}
+	}
+
+	var stranded []string
+	for _, name := range g.names {
+		if _, ok := reached[name]; !ok {
+			stranded = append(stranded, name)
+		}
+	}
+	return stranded
+}
+
+// Orphans returns the sorted names of the bundles, other than the heads,
+// from which no channel head can be reached. Installations of orphaned
+// bundles can never be upgraded to the head of the channel.
+func (g *Graph) Orphans() []string {
+	heads := map[string]struct{}{}
+	for _, head := range g.Heads() {
+		heads[head] = struct{}{}
+	}
+
+	// Walk the graph backwards from the heads to find every bundle that can
+	// reach one of them.
+	canReachHead := map[string]struct{}{}
+	var queue []string
+	for head := range heads {
+		canReachHead[head] = struct{}{}
+		queue = append(queue, head)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, e := range g.in[cur] {
+			if _, ok := canReachHead[e.From]; ok {
+				continue
+			}
+			canReachHead[e.From] = struct{}{}
+			queue = append(queue, e.From)
+		}
+	}
+
+	var orphans []string
+	for _, name := range g.names {
+		if _, ok := canReachHead[name]; !ok {
+			orphans = append(orphans, name)
+		}
+	}
+	return orphans
+}
+
+// Cycles returns the cycles in the graph. Each cycle is returned as the
+// sorted names of the bundles in a strongly connected component with more
+// than one bundle, or a single bundle that is an upgrade of itself. Cycles
+// are sorted by their first bundle name.
+func (g *Graph) Cycles() [][]string {
+	// Tarjan's strongly connected components algorithm.
+	var (
+		index    = map[string]int{}
+		lowlink  = map[string]int{}
+		onStack  = map[string]bool{}
+		stack    []string
+		next     int
+		cycles   [][]string
+		strongly func(string)
+	)
+	strongly = func(v string) {
+		index[v] = next
+		lowlink[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, e := range g.out[v] {
+			if _, ok := index[e.To]; !ok {
+				strongly(e.To)
+				lowlink[v] = min(lowlink[v], lowlink[e.To])
+			} else if onStack[e.To] {
+				lowlink[v] = min(lowlink[v], index[e.To])
+			}
+		}
+
+		if lowlink[v] != index[v] {
+			return
+		}
+		var component []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		if len(component) > 1 || g.isSelfLoop(v) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, name := range g.names {
+		if _, ok := index[name]; !ok {
+			strongly(name)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+func (g *Graph) isSelfLoop(name string) bool {
+	for _, e := range g.out[name] {
+		if e.To == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ShortestPath returns the edges of a shortest upgrade path from the bundle
+// named from to the bundle named to. If there are several shortest paths,
+// the path whose bundle names are lexically smallest at the first point of
+// difference is returned. The path is empty if from and to are the same
+// bundle.
+func (g *Graph) ShortestPath(from, to string) ([]Edge, error) {
+	for _, name := range []string{from, to} {
+		if !g.has(name) {
+			return nil, fmt.Errorf("bundle %q not found in channel %q", name, g.channel.Name)
+		}
+	}
+	if from == to {
+		return []Edge{}, nil
+	}
+
+	prev := map[string]Edge{}
+	visited := map[string]struct{}{from: {}}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, e := range g.out[cur] {
+			if _, ok := visited[e.To]; ok {
+				continue
+			}
+			visited[e.To] = struct{}{}
+			prev[e.To] = e
+			if e.To == to {
+				var path []Edge
+				for n := to; n != from; n = prev[n].From {
+					path = append(path, prev[n])
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path, nil
+			}
+			queue = append(queue, e.To)
+		}
+	}
+	return nil, fmt.Errorf("no upgrade path from %q to %q in channel %q", from, to, g.channel.Name)
+}
+
+// Paths returns every upgrade path from the bundle named from to the bundle
+// named to that does not visit a bundle more than once. Paths are sorted by
+// length, and then by the names of their bundles. The number of paths can
+// grow exponentially with the size of the graph, so Paths is intended for
+// channels of moderate size.
+func (g *Graph) Paths(from, to string) ([][]Edge, error) {
+	for _, name := range []string{from, to} {
+		if !g.has(name) {
+			return nil, fmt.Errorf("bundle %q not found in channel %q", name, g.channel.Name)
+		}
+	}
+	if from == to {
+		return [][]Edge{{}}, nil
+	}
+
+	var (
+		paths   [][]Edge
+		path    []Edge
+		visited = map[string]bool{from: true}
+		walk    func(string)
+	)
+	walk = func(cur string) {
+		for _, e := range g.out[cur] {
+			if visited[e.To] {
+				continue
+			}
+			path = append(path, e)
+			if e.To == to {
+				paths = append(paths, append([]Edge(nil), path...))
+			} else {
+				visited[e.To] = true
+				walk(e.To)
+				visited[e.To] = false
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	walk(from)
+
+	sort.SliceStable(paths, func(i, j int) bool {
+		return len(paths[i]) < len(paths[j])
+	})
+	return paths, nil
+}
+
+// BundleForVersion returns the name of the bundle in the channel with the
+// given version. It returns an error if there is not exactly one such bundle,
+// which wraps ErrNoBundle if there is none.
+func (g *Graph) BundleForVersion(v semver.Version) (string, error) {
+	var matches []string
+	for _, name := range g.names {
+		if g.channel.Bundles[name].Version.Equals(v) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w with version %q found in channel %q", ErrNoBundle, v, g.channel.Name)
+	case 1:
+		return matches[0], nil
+	}
+	return "", fmt.Errorf("multiple bundles with version %q found in channel %q: %v", v, g.channel.Name, matches)
+}
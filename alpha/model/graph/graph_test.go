@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/alpha/model"
+)
+
+type bundleSpec struct {
+	name      string
+	version   string
+	replaces  string
+	skips     []string
+	skipRange string
+}
+
+func newChannel(bundles ...bundleSpec) *model.Channel {
+	ch := &model.Channel{Name: "stable", Bundles: map[string]*model.Bundle{}}
+	for _, b := range bundles {
+		ch.Bundles[b.name] = &model.Bundle{
+			Channel:   ch,
+			Name:      b.name,
+			Version:   semver.MustParse(b.version),
+			Replaces:  b.replaces,
+			Skips:     b.skips,
+			SkipRange: b.skipRange,
+		}
+	}
+	return ch
+}
+
+// validChannel is a channel with a replaces chain from foo.v0.1.0 to
+// foo.v0.4.0. foo.v0.2.1 is skipped by foo.v0.3.0, and foo.v0.4.0 skips
+// everything below 0.4.0 by range.
+func validChannel() *model.Channel {
+	return newChannel(
+		bundleSpec{name: "foo.v0.1.0", version: "0.1.0"},
+		bundleSpec{name: "foo.v0.2.0", version: "0.2.0", replaces: "foo.v0.1.0"},
+		bundleSpec{name: "foo.v0.2.1", version: "0.2.1", replaces: "foo.v0.2.0"},
+		bundleSpec{name: "foo.v0.3.0", version: "0.3.0", replaces: "foo.v0.2.0", skips: []string{"foo.v0.2.1"}},
+		bundleSpec{name: "foo.v0.4.0", version: "0.4.0", replaces: "foo.v0.3.0", skipRange: "<0.4.0"},
+	)
+}
+
+func TestNew(t *testing.T) {
+	g, err := New(validChannel())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo.v0.1.0", "foo.v0.2.0", "foo.v0.2.1", "foo.v0.3.0", "foo.v0.4.0"}, g.Bundles())
+	assert.Equal(t, []Edge{
+		{From: "foo.v0.2.0", To: "foo.v0.2.1", Kind: EdgeReplaces},
+		{From: "foo.v0.2.0", To: "foo.v0.3.0", Kind: EdgeReplaces},
+		{From: "foo.v0.2.0", To: "foo.v0.4.0", Kind: EdgeSkipRange},
+	}, g.Upgrades("foo.v0.2.0"))
+	assert.Equal(t, []Edge{
+		{From: "foo.v0.1.0", To: "foo.v0.4.0", Kind: EdgeSkipRange},
+		{From: "foo.v0.2.0", To: "foo.v0.4.0", Kind: EdgeSkipRange},
+		{From: "foo.v0.2.1", To: "foo.v0.4.0", Kind: EdgeSkipRange},
+		{From: "foo.v0.3.0", To: "foo.v0.4.0", Kind: EdgeReplaces},
+	}, g.UpgradesTo("foo.v0.4.0"))
+
+	t.Run("WithoutSkipRange", func(t *testing.T) {
+		g, err := New(validChannel(), WithoutSkipRange())
+		require.NoError(t, err)
+		assert.Equal(t, []Edge{
+			{From: "foo.v0.2.0", To: "foo.v0.2.1", Kind: EdgeReplaces},
+			{From: "foo.v0.2.0", To: "foo.v0.3.0", Kind: EdgeReplaces},
+		}, g.Upgrades("foo.v0.2.0"))
+		assert.Equal(t, []Edge{
+			{From: "foo.v0.3.0", To: "foo.v0.4.0", Kind: EdgeReplaces},
+		}, g.UpgradesTo("foo.v0.4.0"))
+	})
+
+	t.Run("Error/InvalidSkipRange", func(t *testing.T) {
+		_, err := New(newChannel(bundleSpec{name: "foo.v0.1.0", version: "0.1.0", skipRange: "not-a-range"}))
+		require.EqualError(t, err, `bundle "foo.v0.1.0": parse skipRange "not-a-range": Could not get version from string: "not-a-range"`)
+	})
+}
+
+func TestGraph_Reachable(t *testing.T) {
+	g, err := New(validChannel())
+	require.NoError(t, err)
+
+	reachable, err := g.Reachable("foo.v0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo.v0.3.0", "foo.v0.4.0"}, reachable)
+
+	reachable, err = g.Reachable("foo.v0.4.0")
+	require.NoError(t, err)
+	assert.Empty(t, reachable)
+
+	_, err = g.Reachable("bar.v0.1.0")
+	require.EqualError(t, err, `bundle "bar.v0.1.0" not found in channel "stable"`)
+}
+
+func TestGraph_Analysis(t *testing.T) {
+	type spec struct {
+		name           string
+		channel        *model.Channel
+		expectHeads    []string
+		expectStranded []string
+		expectOrphans  []string
+		expectCycles   [][]string
+	}
+	specs := []spec{
+		{
+			name:        "Valid",
+			channel:     validChannel(),
+			expectHeads: []string{"foo.v0.4.0"},
+		},
+		{
+			name: "MultipleHeads",
+			channel: newChannel(
+				bundleSpec{name: "foo.v0.1.0", version: "0.1.0"},
+				bundleSpec{name: "foo.v0.2.0", version: "0.2.0", replaces: "foo.v0.1.0"},
+				bundleSpec{name: "foo.v0.3.0", version: "0.3.0", skipRange: "<0.3.0"},
+			),
+			expectHeads:    []string{"foo.v0.2.0", "foo.v0.3.0"},
+			expectStranded: nil,
+			expectOrphans:  nil,
+		},
+		{
+			name: "Stranded",
+			channel: newChannel(
+				bundleSpec{name: "foo.v0.1.0", version: "0.1.0"},
+				bundleSpec{name: "foo.v0.1.5", version: "0.1.5"},
+				bundleSpec{name: "foo.v0.1.6", version: "0.1.6", replaces: "foo.v0.1.5"},
+				bundleSpec{name: "foo.v0.2.0", version: "0.2.0", replaces: "foo.v0.1.0"},
+				bundleSpec{name: "foo.v0.3.0", version: "0.3.0", replaces: "foo.v0.2.0", skips: []string{"foo.v0.1.6"}},
+			),
+			expectHeads:    []string{"foo.v0.3.0"},
+			expectStranded: []string{"foo.v0.1.5"},
+			expectOrphans:  nil,
+		},
+		{
+			name: "Orphaned",
+			channel: newChannel(
+				bundleSpec{name: "foo.v0.1.0", version: "0.1.0"},
+				bundleSpec{name: "foo.v0.2.0", version: "0.2.0", replaces: "foo.v0.1.0", skips: []string{"foo.v0.1.1"}},
+				bundleSpec{name: "foo.v0.1.1", version: "0.1.1"},
+				bundleSpec{name: "foo.v0.1.2", version: "0.1.2", replaces: "foo.v0.3.0"},
+				bundleSpec{name: "foo.v0.3.0", version: "0.3.0", replaces: "foo.v0.2.0", skips: []string{"foo.v0.1.2"}},
+			),
+			expectHeads:    nil,
+			expectStranded: []string{"foo.v0.1.0", "foo.v0.2.0", "foo.v0.3.0"},
+			expectOrphans:  []string{"foo.v0.1.0", "foo.v0.1.1", "foo.v0.1.2", "foo.v0.2.0", "foo.v0.3.0"},
+			expectCycles:   [][]string{{"foo.v0.1.2", "foo.v0.3.0"}},
+		},
+		{
+			name: "SelfLoop",
+			channel: newChannel(
+				bundleSpec{name: "foo.v0.1.0", version: "0.1.0", replaces: "foo.v0.1.0"},
+			),
+			expectOrphans:  []string{"foo.v0.1.0"},
+			expectStranded: []string{"foo.v0.1.0"},
+			expectCycles:   [][]string{{"foo.v0.1.0"}},
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			g, err := New(s.channel)
+			require.NoError(t, err)
+			assert.Equal(t, s.expectHeads, g.Heads(), "heads")
+			assert.Equal(t, s.expectStranded, g.Stranded(), "stranded")
+			assert.Equal(t, s.expectOrphans, g.Orphans(), "orphans")
+			assert.Equal(t, s.expectCycles, g.Cycles(), "cycles")
+		})
+	}
+}
+
+func TestGraph_ShortestPath(t *testing.T) {
+	g, err := New(validChannel())
+	require.NoError(t, err)
+
+	type spec struct {
+		name      string
+		from, to  string
+		assertion require.ErrorAssertionFunc
+		expected  []Edge
+	}
+	specs := []spec{
+		{
+			name:      "SkipRange",
+			from:      "foo.v0.1.0",
+			to:        "foo.v0.4.0",
+			assertion: require.NoError,
+			expected:  []Edge{{From: "foo.v0.1.0", To: "foo.v0.4.0", Kind: EdgeSkipRange}},
+		},
+		{
+			name:      "ReplacesChain",
+			from:      "foo.v0.1.0",
+			to:        "foo.v0.3.0",
+			assertion: require.NoError,
+			expected: []Edge{
+				{From: "foo.v0.1.0", To: "foo.v0.2.0", Kind: EdgeReplaces},
+				{From: "foo.v0.2.0", To: "foo.v0.3.0", Kind: EdgeReplaces},
+			},
+		},
+		{
+			name:      "Same",
+			from:      "foo.v0.3.0",
+			to:        "foo.v0.3.0",
+			assertion: require.NoError,
+			expected:  []Edge{},
+		},
+		{
+			name:      "Error/NoPath",
+			from:      "foo.v0.4.0",
+			to:        "foo.v0.1.0",
+			assertion: require.Error,
+		},
+		{
+			name:      "Error/UnknownBundle",
+			from:      "foo.v0.0.1",
+			to:        "foo.v0.4.0",
+			assertion: require.Error,
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			path, err := g.ShortestPath(s.from, s.to)
+			s.assertion(t, err)
+			assert.Equal(t, s.expected, path)
+		})
+	}
+}
+
+func TestGraph_Paths(t *testing.T) {
+	g, err := New(validChannel())
+	require.NoError(t, err)
+
+	paths, err := g.Paths("foo.v0.2.0", "foo.v0.4.0")
+	require.NoError(t, err)
+	assert.Equal(t, [][]Edge{
+		{
+			{From: "foo.v0.2.0", To: "foo.v0.4.0", Kind: EdgeSkipRange},
+		},
+		{
+			{From: "foo.v0.2.0", To: "foo.v0.2.1", Kind: EdgeReplaces},
+			{From: "foo.v0.2.1", To: "foo.v0.4.0", Kind: EdgeSkipRange},
+		},
+		{
+			{From: "foo.v0.2.0", To: "foo.v0.3.0", Kind: EdgeReplaces},
+			{From: "foo.v0.3.0", To: "foo.v0.4.0", Kind: EdgeReplaces},
+		},
+		{
+			{From: "foo.v0.2.0", To: "foo.v0.2.1", Kind: EdgeReplaces},
+			{From: "foo.v0.2.1", To: "foo.v0.3.0", Kind: EdgeSkips},
+			{From: "foo.v0.3.0", To: "foo.v0.4.0", Kind: EdgeReplaces},
+		},
+	}, paths)
+
+	paths, err = g.Paths("foo.v0.4.0", "foo.v0.1.0")
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestGraph_BundleForVersion(t *testing.T) {
+	g, err := New(validChannel())
+	require.NoError(t, err)
+
+	name, err := g.BundleForVersion(semver.MustParse("0.2.1"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo.v0.2.1", name)
+
+	_, err = g.BundleForVersion(semver.MustParse("1.0.0"))
+	require.EqualError(t, err, `no bundle with version "1.0.0" found in channel "stable"`)
+	require.ErrorIs(t, err, ErrNoBundle)
+}
@@ -27,6 +27,35 @@ func init() {
 	matchers.Image[types.NewType("svg", "image/svg+xml")] = svg.Is
 }
 
+// Location identifies the file and line from which an object was loaded.
+// Either field may be unset, e.g. for objects that were not read from a
+// file or for file formats without line information.
+type Location struct {
+	Path string `json:"path,omitempty"`
+	Line int    `json:"line,omitempty"`
+}
+
+func (l Location) String() string {
+	switch {
+	case l.Path != "" && l.Line > 0:
+		return fmt.Sprintf("%s:%d", l.Path, l.Line)
+	case l.Path != "":
+		return l.Path
+	case l.Line > 0:
+		return fmt.Sprintf("line %d", l.Line)
+	}
+	return ""
+}
+
+// describe appends the location to the description of an object, if the
+// location is known.
+func (l Location) describe(desc string) string {
+	if loc := l.String(); loc != "" {
+		return fmt.Sprintf("%s (%s)", desc, loc)
+	}
+	return desc
+}
+
 type Model map[string]*Package
 
 func (m Model) Validate() error {
@@ -34,7 +63,11 @@ func (m Model) Validate() error {
 
 	for name, pkg := range m {
 		if name != pkg.Name {
-			result.subErrors = append(result.subErrors, fmt.Errorf("package key %q does not match package name %q", name, pkg.Name))
+			result.subErrors = append(result.subErrors, &ValidationError{
+				Path:    ValidationPath{Package: name},
+				Code:    ErrorCodeKeyMismatch,
+				Message: fmt.Sprintf("package key %q does not match package name %q", name, pkg.Name),
+			})
 		}
 		if err := pkg.Validate(); err != nil {
 			result.subErrors = append(result.subErrors, err)
@@ -43,6 +76,23 @@ func (m Model) Validate() error {
 	return result.orNil()
 }
 
+// ValidateConstraints checks the olm.constraint properties of the bundles
+// of m with Bundle.ValidateConstraints.
+func (m Model) ValidateConstraints() error {
+	result := newValidationError("invalid index")
+
+	for _, pkg := range m {
+		for _, ch := range pkg.Channels {
+			for _, b := range ch.Bundles {
+				if err := b.ValidateConstraints(); err != nil {
+					result.subErrors = append(result.subErrors, err)
+				}
+			}
+		}
+	}
+	return result.orNil()
+}
+
 type Package struct {
 	Name           string
 	Description    string
@@ -50,31 +100,33 @@ type Package struct {
 	DefaultChannel *Channel
 	Channels       map[string]*Channel
 	Deprecation    *Deprecation
+	Location       Location
 }
 
 func (m *Package) Validate() error {
-	result := newValidationError(fmt.Sprintf("invalid package %q", m.Name))
+	result := newValidationError(m.Location.describe(fmt.Sprintf("invalid package %q", m.Name))).
+		withPath(ValidationPath{Package: m.Name}, m.Location)
 
 	if m.Name == "" {
-		result.subErrors = append(result.subErrors, errors.New("package name must not be empty"))
+		result.errorf(ErrorCodeNameRequired, "package name must not be empty")
 	}
 
-	if err := m.Icon.Validate(); err != nil {
+	if err := m.Icon.validate(ValidationPath{Package: m.Name}, m.Location); err != nil {
 		result.subErrors = append(result.subErrors, err)
 	}
 
 	if m.DefaultChannel == nil {
-		result.subErrors = append(result.subErrors, fmt.Errorf("default channel must be set"))
+		result.errorf(ErrorCodeDefaultChannelRequired, "default channel must be set")
 	}
 
 	if len(m.Channels) == 0 {
-		result.subErrors = append(result.subErrors, fmt.Errorf("package must contain at least one channel"))
+		result.errorf(ErrorCodeNoChannels, "package must contain at least one channel")
 	}
 
 	foundDefault := false
 	for name, ch := range m.Channels {
 		if name != ch.Name {
-			result.subErrors = append(result.subErrors, fmt.Errorf("channel key %q does not match channel name %q", name, ch.Name))
+			result.errorf(ErrorCodeKeyMismatch, "channel key %q does not match channel name %q", name, ch.Name)
 		}
 		if err := ch.Validate(); err != nil {
 			result.subErrors = append(result.subErrors, err)
@@ -83,20 +135,20 @@ func (m *Package) Validate() error {
 			foundDefault = true
 		}
 		if ch.Package != m {
-			result.subErrors = append(result.subErrors, fmt.Errorf("channel %q not correctly linked to parent package", ch.Name))
+			result.errorf(ErrorCodeParentMismatch, "channel %q not correctly linked to parent package", ch.Name)
 		}
 	}
 
 	if err := m.validateUniqueBundleVersions(); err != nil {
-		result.subErrors = append(result.subErrors, err)
+		result.wrap(ErrorCodeDuplicateVersion, err)
 	}
 
 	if m.DefaultChannel != nil && !foundDefault {
-		result.subErrors = append(result.subErrors, fmt.Errorf("default channel %q not found in channels list", m.DefaultChannel.Name))
+		result.errorf(ErrorCodeDefaultChannelNotFound, "default channel %q not found in channels list", m.DefaultChannel.Name)
 	}
 
 	if err := m.Deprecation.Validate(); err != nil {
-		result.subErrors = append(result.subErrors, fmt.Errorf("invalid deprecation: %v", err))
+		result.errorf(ErrorCodeInvalidDeprecation, "invalid deprecation: %v", err)
 	}
 
 	return result.orNil()
@@ -138,6 +190,12 @@ type Icon struct {
 }
 
 func (i *Icon) Validate() error {
+	return i.validate(ValidationPath{}, Location{})
+}
+
+// validate is like Validate, but reports errors with the path and location
+// of the package that the icon belongs to.
+func (i *Icon) validate(path ValidationPath, location Location) error {
 	if i == nil {
 		return nil
 	}
@@ -146,16 +204,16 @@ func (i *Icon) Validate() error {
 	//   mediatype listed in the icon field? Currently, some production
 	//   index databases are failing these tests, so leaving this
 	//   commented out for now.
-	result := newValidationError("invalid icon")
+	result := newValidationError("invalid icon").withPath(path, location)
 	//if len(i.Data) == 0 {
-	//	result.subErrors = append(result.subErrors, errors.New("icon data must be set if icon is defined"))
+	//	result.errorf(ErrorCodeInvalidIcon, "icon data must be set if icon is defined")
 	//}
 	//if len(i.MediaType) == 0 {
-	//	result.subErrors = append(result.subErrors, errors.New("icon mediatype must be set if icon is defined"))
+	//	result.errorf(ErrorCodeInvalidIcon, "icon mediatype must be set if icon is defined")
 	//}
 	//if len(i.Data) > 0 {
 	//	if err := i.validateData(); err != nil {
-	//		result.subErrors = append(result.subErrors, err)
+	//		result.wrap(ErrorCodeInvalidIcon, err)
 	//	}
 	//}
 	return result.orNil()
@@ -184,6 +242,7 @@ type Channel struct {
 	//   DO NOT use it for any public-facing functionalities.
 	//   This API is in alpha stage and it is subject to change.
 	Properties []property.Property
+	Location   Location
 }
 
 // TODO(joelanford): This function determines the channel head by finding the bundle that has 0
@@ -221,40 +280,45 @@ func (c Channel) Head() (*Bundle, error) {
 }
 
 func (c *Channel) Validate() error {
-	result := newValidationError(fmt.Sprintf("invalid channel %q", c.Name))
+	path := ValidationPath{Channel: c.Name}
+	if c.Package != nil {
+		path.Package = c.Package.Name
+	}
+	result := newValidationError(c.Location.describe(fmt.Sprintf("invalid channel %q", c.Name))).
+		withPath(path, c.Location)
 
 	if c.Name == "" {
-		result.subErrors = append(result.subErrors, errors.New("channel name must not be empty"))
+		result.errorf(ErrorCodeNameRequired, "channel name must not be empty")
 	}
 
 	if c.Package == nil {
-		result.subErrors = append(result.subErrors, errors.New("package must be set"))
+		result.errorf(ErrorCodeParentMismatch, "package must be set")
 	}
 
 	if len(c.Bundles) == 0 {
-		result.subErrors = append(result.subErrors, fmt.Errorf("channel must contain at least one bundle"))
+		result.errorf(ErrorCodeNoBundles, "channel must contain at least one bundle")
 	}
 
 	if len(c.Bundles) > 0 {
 		if err := c.validateReplacesChain(); err != nil {
-			result.subErrors = append(result.subErrors, err)
+			result.wrap(ErrorCodeInvalidChannelHead, err)
 		}
 	}
 
 	for name, b := range c.Bundles {
 		if name != b.Name {
-			result.subErrors = append(result.subErrors, fmt.Errorf("bundle key %q does not match bundle name %q", name, b.Name))
+			result.errorf(ErrorCodeKeyMismatch, "bundle key %q does not match bundle name %q", name, b.Name)
 		}
 		if err := b.Validate(); err != nil {
 			result.subErrors = append(result.subErrors, err)
 		}
 		if b.Channel != c {
-			result.subErrors = append(result.subErrors, fmt.Errorf("bundle %q not correctly linked to parent channel", b.Name))
+			result.errorf(ErrorCodeParentMismatch, "bundle %q not correctly linked to parent channel", b.Name)
 		}
 	}
 
 	if err := c.Deprecation.Validate(); err != nil {
-		result.subErrors = append(result.subErrors, fmt.Errorf("invalid deprecation: %v", err))
+		result.errorf(ErrorCodeInvalidDeprecation, "invalid deprecation: %v", err)
 	}
 
 	return result.orNil()
@@ -291,7 +355,10 @@ func (c *Channel) validateReplacesChain() error {
 			chainFrom[k] = append(chainFrom[k], cur.Replaces)
 		}
 		if replacesChainFromHead.Has(cur.Replaces) {
-			return fmt.Errorf("detected cycle in replaces chain of upgrade graph: %s", strings.Join(chainFrom[cur.Replaces], " -> "))
+			return &ValidationError{
+				Code:    ErrorCodeReplacesCycle,
+				Message: fmt.Sprintf("detected cycle in replaces chain of upgrade graph: %s", strings.Join(chainFrom[cur.Replaces], " -> ")),
+			}
 		}
 		replacesChainFromHead = replacesChainFromHead.Insert(cur.Replaces)
 		cur = c.Bundles[cur.Replaces]
@@ -299,7 +366,10 @@ func (c *Channel) validateReplacesChain() error {
 
 	strandedBundles := allBundles.Difference(replacesChainFromHead).Difference(skippedBundles).List()
 	if len(strandedBundles) > 0 {
-		return fmt.Errorf("channel contains one or more stranded bundles: %s", strings.Join(strandedBundles, ", "))
+		return &ValidationError{
+			Code:    ErrorCodeStrandedBundles,
+			Message: fmt.Sprintf("channel contains one or more stranded bundles: %s", strings.Join(strandedBundles, ", ")),
+		}
 	}
 
 	return nil
@@ -326,30 +396,33 @@ type Bundle struct {
 	// These fields are used to compare bundles in a diff.
 	PropertiesP *property.Properties
 	Version     semver.Version
+
+	Location Location
 }
 
 func (b *Bundle) Validate() error {
-	result := newValidationError(fmt.Sprintf("invalid bundle %q", b.Name))
+	result := newValidationError(b.Location.describe(fmt.Sprintf("invalid bundle %q", b.Name))).
+		withPath(b.validationPath(), b.Location)
 
 	if b.Name == "" {
-		result.subErrors = append(result.subErrors, errors.New("name must be set"))
+		result.errorf(ErrorCodeNameRequired, "name must be set")
 	}
 	if b.Channel == nil {
-		result.subErrors = append(result.subErrors, errors.New("channel must be set"))
+		result.errorf(ErrorCodeParentMismatch, "channel must be set")
 	}
 	if b.Package == nil {
-		result.subErrors = append(result.subErrors, errors.New("package must be set"))
+		result.errorf(ErrorCodeParentMismatch, "package must be set")
 	}
 	if b.Channel != nil && b.Package != nil && b.Package != b.Channel.Package {
-		result.subErrors = append(result.subErrors, errors.New("package does not match channel's package"))
+		result.errorf(ErrorCodeParentMismatch, "package does not match channel's package")
 	}
 	props, err := property.Parse(b.Properties)
 	if err != nil {
-		result.subErrors = append(result.subErrors, err)
+		result.wrap(ErrorCodeInvalidProperty, err)
 	}
 	for i, skip := range b.Skips {
 		if skip == "" {
-			result.subErrors = append(result.subErrors, fmt.Errorf("skip[%d] is empty", i))
+			result.errorf(ErrorCodeEmptySkip, "skip[%d] is empty", i)
 		}
 	}
 	// TODO(joelanford): Validate related images? It looks like some
@@ -363,20 +436,53 @@ func (b *Bundle) Validate() error {
 	//}
 
 	if props != nil && len(props.Packages) != 1 {
-		result.subErrors = append(result.subErrors, fmt.Errorf("must be exactly one property with type %q", property.TypePackage))
+		result.errorf(ErrorCodeInvalidPackageProperty, "must be exactly one property with type %q", property.TypePackage)
 	}
-
 	if b.Image == "" && len(b.Objects) == 0 {
-		result.subErrors = append(result.subErrors, errors.New("bundle image must be set"))
+		result.errorf(ErrorCodeImageRequired, "bundle image must be set")
 	}
 
 	if err := b.Deprecation.Validate(); err != nil {
-		result.subErrors = append(result.subErrors, fmt.Errorf("invalid deprecation: %v", err))
+		result.errorf(ErrorCodeInvalidDeprecation, "invalid deprecation: %v", err)
 	}
 
 	return result.orNil()
 }
 
+// ValidateConstraints checks that the olm.constraint properties of the
+// bundle can be decoded and that their CEL rules compile. It is not part of
+// Validate, so that a catalog with a constraint that this version of the
+// model does not understand can still be served.
+func (b *Bundle) ValidateConstraints() error {
+	result := newValidationError(b.Location.describe(fmt.Sprintf("invalid bundle %q", b.Name))).
+		withPath(b.validationPath(), b.Location)
+
+	for _, p := range b.Properties {
+		if p.Type != property.TypeConstraint {
+			continue
+		}
+		c, err := property.ParseConstraint(p.Value)
+		if err == nil {
+			err = c.Validate()
+		}
+		if err != nil {
+			result.errorf(ErrorCodeInvalidConstraint, "invalid property with type %q: %v", property.TypeConstraint, err)
+		}
+	}
+	return result.orNil()
+}
+
+func (b *Bundle) validationPath() ValidationPath {
+	path := ValidationPath{Bundle: b.Name}
+	if b.Package != nil {
+		path.Package = b.Package.Name
+	}
+	if b.Channel != nil {
+		path.Channel = b.Channel.Name
+	}
+	return path
+}
+
 type RelatedImage struct {
 	Name  string
 	Image string
@@ -385,7 +491,7 @@ type RelatedImage struct {
 func (i RelatedImage) Validate() error {
 	result := newValidationError("invalid related image")
 	if i.Image == "" {
-		result.subErrors = append(result.subErrors, fmt.Errorf("image must be set"))
+		result.errorf(ErrorCodeImageRequired, "image must be set")
 	}
 	return result.orNil()
 }
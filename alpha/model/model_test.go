@@ -559,6 +559,22 @@ func TestValidators(t *testing.T) {
 			},
 			assertion: hasError(`parse property[0] of type "broken": unexpected end of JSON input`),
 		},
+		{
+			name: "Bundle/Success/ConstraintsNotValidated",
+			v: &Bundle{
+				Package:  pkg,
+				Channel:  ch,
+				Name:     "anakin.v0.1.0",
+				Image:    "registry.io/image",
+				Replaces: "anakin.v0.0.1",
+				Properties: []property.Property{
+					property.MustBuildPackage("anakin", "0.1.0"),
+					property.MustBuildConstraint(property.Constraint{Cel: &property.CelConstraint{Rule: "properties.size()"}}),
+					{Type: property.TypeConstraint, Value: json.RawMessage(`{"gvk":"skywalker.me/v1alpha1/PodRacer"}`)},
+				},
+			},
+			assertion: require.NoError,
+		},
 		{
 			name: "Bundle/Error/EmptySkipsValue",
 			v: &Bundle{
@@ -624,6 +640,84 @@ func TestValidators(t *testing.T) {
 	}
 }
 
+func TestValidateConstraints(t *testing.T) {
+	type spec struct {
+		name      string
+		bundle    *Bundle
+		assertion require.ErrorAssertionFunc
+	}
+
+	pkg, ch := makePackageChannelBundle()
+
+	specs := []spec{
+		{
+			name: "Success/Valid",
+			bundle: &Bundle{
+				Package: pkg,
+				Channel: ch,
+				Name:    "anakin.v0.1.0",
+				Properties: []property.Property{
+					property.MustBuildPackage("anakin", "0.1.0"),
+					property.MustBuildConstraint(property.Constraint{Cel: &property.CelConstraint{Rule: "properties.size() > 0"}}),
+				},
+			},
+			assertion: require.NoError,
+		},
+		{
+			name: "Error/InvalidConstraint",
+			bundle: &Bundle{
+				Package:  pkg,
+				Channel:  ch,
+				Name:     "anakin.v0.1.0",
+				Image:    "registry.io/image",
+				Replaces: "anakin.v0.0.1",
+				Properties: []property.Property{
+					property.MustBuildPackage("anakin", "0.1.0"),
+					property.MustBuildConstraint(property.Constraint{All: &property.CompoundConstraint{Constraints: []property.Constraint{
+						{GVK: &property.GVKConstraint{Group: "skywalker.me", Version: "v1alpha1", Kind: "PodRacer"}},
+						{Cel: &property.CelConstraint{Rule: "properties.size()"}},
+					}}}),
+				},
+			},
+			assertion: hasError(`invalid property with type "olm.constraint": all.constraints[1]: cel: cel expressions must have type Bool`),
+		},
+		{
+			name: "Error/UndecodableConstraint",
+			bundle: &Bundle{
+				Package:  pkg,
+				Channel:  ch,
+				Name:     "anakin.v0.1.0",
+				Image:    "registry.io/image",
+				Replaces: "anakin.v0.0.1",
+				Properties: []property.Property{
+					property.MustBuildPackage("anakin", "0.1.0"),
+					{Type: property.TypeConstraint, Value: json.RawMessage(`{"gvk":"skywalker.me/v1alpha1/PodRacer"}`)},
+				},
+			},
+			assertion: hasError(`invalid property with type "olm.constraint": json: cannot unmarshal string into Go struct field Constraint.gvk of type property.GVKConstraint`),
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			s.assertion(t, s.bundle.ValidateConstraints())
+		})
+	}
+
+	t.Run("Model", func(t *testing.T) {
+		m := Model{pkg.Name: pkg}
+		require.NoError(t, m.ValidateConstraints())
+
+		b := ch.Bundles["anakin.v0.0.1"]
+		b.Properties = append(b.Properties, property.MustBuildConstraint(property.Constraint{Cel: &property.CelConstraint{Rule: "properties.size()"}}))
+		err := m.ValidateConstraints()
+		require.Equal(t, []*ValidationError{{
+			Path:    ValidationPath{Package: "anakin", Channel: "light", Bundle: "anakin.v0.0.1"},
+			Code:    ErrorCodeInvalidConstraint,
+			Message: `invalid property with type "olm.constraint": cel: cel expressions must have type Bool`,
+		}}, ValidationErrors(err))
+	})
+}
+
 func makePackageChannelBundle() (*Package, *Channel) {
 	bundle1 := &Bundle{
 		Name:  "anakin.v0.0.1",
@@ -759,3 +853,29 @@ func countBundles(m Model, pkg string) int {
 	}
 	return count
 }
+
+func TestLocation(t *testing.T) {
+	type spec struct {
+		name     string
+		location Location
+		expected string
+	}
+	specs := []spec{
+		{name: "Empty", location: Location{}, expected: ""},
+		{name: "PathOnly", location: Location{Path: "foo/catalog.yaml"}, expected: "foo/catalog.yaml"},
+		{name: "LineOnly", location: Location{Line: 3}, expected: "line 3"},
+		{name: "PathAndLine", location: Location{Path: "foo/catalog.yaml", Line: 3}, expected: "foo/catalog.yaml:3"},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, s.location.String())
+		})
+	}
+
+	t.Run("ValidationHeading", func(t *testing.T) {
+		pkg := &Package{Name: "foo", Location: Location{Path: "foo/catalog.yaml", Line: 3}}
+		err := pkg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid package "foo" (foo/catalog.yaml:3)`)
+	})
+}
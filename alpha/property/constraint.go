@@ -0,0 +1,271 @@
+package property
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/operator-framework/api/pkg/constraints"
+)
+
+// Constraint is the value of an olm.constraint property, which declares a
+// dependency that must be satisfied by another bundle. Exactly one of Cel,
+// Package, GVK, All, Any and Not must be set.
+type Constraint struct {
+	// FailureMessage is surfaced by OLM when the constraint cannot be
+	// satisfied.
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	Cel     *CelConstraint     `json:"cel,omitempty"`
+	Package *PackageConstraint `json:"package,omitempty"`
+	GVK     *GVKConstraint     `json:"gvk,omitempty"`
+
+	// All is satisfied by a bundle that satisfies all of its constraints.
+	All *CompoundConstraint `json:"all,omitempty"`
+	// Any is satisfied by a bundle that satisfies any of its constraints.
+	Any *CompoundConstraint `json:"any,omitempty"`
+	// Not is satisfied by a bundle that satisfies none of its constraints.
+	// It is intended to be used within All, alongside other constraints.
+	Not *CompoundConstraint `json:"not,omitempty"`
+}
+
+// CelConstraint is satisfied by a bundle for which Rule evaluates to true.
+// The bundle's properties are available to the rule as a list of maps with
+// "type" and "value" keys in the "properties" variable, and versions can be
+// compared with the semver_compare function.
+type CelConstraint struct {
+	Rule string `json:"rule"`
+}
+
+// PackageConstraint is satisfied by a bundle of the package PackageName whose
+// version is in VersionRange.
+type PackageConstraint struct {
+	PackageName  string `json:"packageName"`
+	VersionRange string `json:"versionRange"`
+}
+
+// GVKConstraint is satisfied by a bundle that provides the GVK.
+type GVKConstraint struct {
+	Group   string `json:"group"`
+	Kind    string `json:"kind"`
+	Version string `json:"version"`
+}
+
+type CompoundConstraint struct {
+	Constraints []Constraint `json:"constraints"`
+}
+
+// maxConstraintSize is the maximum size of an olm.constraint value, which is
+// the same limit that OLM enforces to bound the nesting depth of compound
+// constraints.
+const maxConstraintSize = 2 << 16
+
+// ParseConstraint decodes the value of an olm.constraint property. Unknown
+// fields are ignored, so that constraints written for newer versions of OLM
+// can still be read.
+func ParseConstraint(v json.RawMessage) (Constraint, error) {
+	var c Constraint
+	if len(v) > maxConstraintSize {
+		return c, fmt.Errorf("value is greater than max constraint size %d bytes", maxConstraintSize)
+	}
+	err := json.Unmarshal(v, &c)
+	return c, err
+}
+
+// Validate checks that exactly one kind of constraint is set in c and in each
+// of its nested constraints, that version ranges can be parsed, and that CEL
+// rules compile to boolean expressions.
+func (c Constraint) Validate() error {
+	return defaultConstraintEvaluator().Validate(c)
+}
+
+// ConstraintEvaluator checks constraints against the properties of bundles.
+// Compiled CEL rules are cached, so an evaluator should be reused to check
+// many constraints. A ConstraintEvaluator is safe for concurrent use.
+type ConstraintEvaluator struct {
+	env *constraints.CelEnvironment
+
+	mu       sync.Mutex
+	programs map[string]constraints.CelProgram
+}
+
+var defaultConstraintEvaluator = sync.OnceValue(NewConstraintEvaluator)
+
+func NewConstraintEvaluator() *ConstraintEvaluator {
+	return &ConstraintEvaluator{
+		env:      constraints.NewCelEnvironment(),
+		programs: map[string]constraints.CelProgram{},
+	}
+}
+
+// Validate is like Constraint.Validate, but uses the evaluator's cache of
+// compiled CEL rules.
+func (e *ConstraintEvaluator) Validate(c Constraint) error {
+	switch n := c.kinds(); len(n) {
+	case 0:
+		return errors.New("constraint must have one of cel, package, gvk, all, any or not set")
+	case 1:
+	default:
+		return fmt.Errorf("constraint must have exactly one of cel, package, gvk, all, any or not set, found %s", strings.Join(n, ", "))
+	}
+
+	switch {
+	case c.Cel != nil:
+		if c.Cel.Rule == "" {
+			return errors.New("cel: rule must be set")
+		}
+		if _, err := e.program(c.Cel.Rule); err != nil {
+			return fmt.Errorf("cel: %v", err)
+		}
+	case c.Package != nil:
+		if c.Package.PackageName == "" {
+			return errors.New("package: packageName must be set")
+		}
+		if _, err := semver.ParseRange(c.Package.VersionRange); err != nil {
+			return fmt.Errorf("package: invalid versionRange %q: %v", c.Package.VersionRange, err)
+		}
+	case c.GVK != nil:
+		if c.GVK.Version == "" || c.GVK.Kind == "" {
+			return errors.New("gvk: version and kind must be set")
+		}
+	default:
+		name, cc := c.compound()
+		if len(cc.Constraints) == 0 {
+			return fmt.Errorf("%s: constraints must not be empty", name)
+		}
+		for i, sub := range cc.Constraints {
+			if err := e.Validate(sub); err != nil {
+				return fmt.Errorf("%s.constraints[%d]: %v", name, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate reports whether a bundle with the properties props satisfies c.
+// An error is returned if c is invalid, or if props cannot be evaluated
+// against it.
+func (e *ConstraintEvaluator) Evaluate(c Constraint, props []Property) (bool, error) {
+	if err := e.Validate(c); err != nil {
+		return false, err
+	}
+	parsed, err := Parse(props)
+	if err != nil {
+		return false, err
+	}
+	return e.evaluate(c, props, parsed)
+}
+
+func (e *ConstraintEvaluator) evaluate(c Constraint, props []Property, parsed *Properties) (bool, error) {
+	switch {
+	case c.Cel != nil:
+		return e.evaluateCel(c.Cel.Rule, props)
+	case c.Package != nil:
+		versionRange, err := semver.ParseRange(c.Package.VersionRange)
+		if err != nil {
+			return false, err
+		}
+		for _, p := range parsed.Packages {
+			if p.PackageName != c.Package.PackageName {
+				continue
+			}
+			v, err := semver.Parse(p.Version)
+			if err != nil {
+				return false, fmt.Errorf("parse version %q of package %q: %v", p.Version, p.PackageName, err)
+			}
+			if versionRange(v) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case c.GVK != nil:
+		for _, gvk := range parsed.GVKs {
+			if gvk.Group == c.GVK.Group && gvk.Version == c.GVK.Version && gvk.Kind == c.GVK.Kind {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	_, cc := c.compound()
+	for _, sub := range cc.Constraints {
+		ok, err := e.evaluate(sub, props, parsed)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case c.All != nil && !ok:
+			return false, nil
+		case c.Any != nil && ok:
+			return true, nil
+		case c.Not != nil && ok:
+			return false, nil
+		}
+	}
+	return c.Any == nil, nil
+}
+
+func (e *ConstraintEvaluator) evaluateCel(rule string, props []Property) (bool, error) {
+	prog, err := e.program(rule)
+	if err != nil {
+		return false, err
+	}
+	celProps := make([]map[string]interface{}, 0, len(props))
+	for _, p := range props {
+		var v interface{}
+		if err := json.Unmarshal(p.Value, &v); err != nil {
+			return false, fmt.Errorf("parse property of type %q: %v", p.Type, err)
+		}
+		celProps = append(celProps, map[string]interface{}{"type": p.Type, "value": v})
+	}
+	return prog.Evaluate(map[string]interface{}{constraints.PropertiesKey: celProps})
+}
+
+func (e *ConstraintEvaluator) program(rule string) (constraints.CelProgram, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if prog, ok := e.programs[rule]; ok {
+		return prog, nil
+	}
+	prog, err := e.env.Validate(rule)
+	if err != nil {
+		return prog, err
+	}
+	e.programs[rule] = prog
+	return prog, nil
+}
+
+func (c Constraint) kinds() []string {
+	var kinds []string
+	for _, k := range []struct {
+		name string
+		set  bool
+	}{
+		{"cel", c.Cel != nil},
+		{"package", c.Package != nil},
+		{"gvk", c.GVK != nil},
+		{"all", c.All != nil},
+		{"any", c.Any != nil},
+		{"not", c.Not != nil},
+	} {
+		if k.set {
+			kinds = append(kinds, k.name)
+		}
+	}
+	return kinds
+}
+
+func (c Constraint) compound() (string, *CompoundConstraint) {
+	switch {
+	case c.All != nil:
+		return "all", c.All
+	case c.Any != nil:
+		return "any", c.Any
+	case c.Not != nil:
+		return "not", c.Not
+	}
+	return "", nil
+}
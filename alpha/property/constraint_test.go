@@ -0,0 +1,173 @@
+package property
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintValidate(t *testing.T) {
+	type spec struct {
+		name       string
+		constraint Constraint
+		expectErr  string
+	}
+
+	specs := []spec{
+		{
+			name:       "Success/Cel",
+			constraint: Constraint{Cel: &CelConstraint{Rule: `properties.exists(p, p.type == "certified")`}},
+		},
+		{
+			name: "Success/Compound",
+			constraint: Constraint{All: &CompoundConstraint{Constraints: []Constraint{
+				{Package: &PackageConstraint{PackageName: "etcd", VersionRange: ">=0.9.0"}},
+				{Not: &CompoundConstraint{Constraints: []Constraint{
+					{GVK: &GVKConstraint{Group: "etcd.database.coreos.com", Version: "v1beta1", Kind: "EtcdCluster"}},
+				}}},
+			}}},
+		},
+		{
+			name:       "Error/Empty",
+			constraint: Constraint{FailureMessage: "nothing"},
+			expectErr:  "constraint must have one of cel, package, gvk, all, any or not set",
+		},
+		{
+			name: "Error/MultipleKinds",
+			constraint: Constraint{
+				Package: &PackageConstraint{PackageName: "etcd", VersionRange: ">=0.9.0"},
+				Any:     &CompoundConstraint{},
+			},
+			expectErr: "constraint must have exactly one of cel, package, gvk, all, any or not set, found package, any",
+		},
+		{
+			name:       "Error/CelNotBool",
+			constraint: Constraint{Cel: &CelConstraint{Rule: `properties.size()`}},
+			expectErr:  "cel: cel expressions must have type Bool",
+		},
+		{
+			name:       "Error/InvalidVersionRange",
+			constraint: Constraint{Package: &PackageConstraint{PackageName: "etcd", VersionRange: "latest"}},
+			expectErr:  `package: invalid versionRange "latest": Could not get version from string: "latest"`,
+		},
+		{
+			name:       "Error/GVKMissingKind",
+			constraint: Constraint{GVK: &GVKConstraint{Group: "etcd.database.coreos.com", Version: "v1beta2"}},
+			expectErr:  "gvk: version and kind must be set",
+		},
+		{
+			name: "Error/NestedEmptyCompound",
+			constraint: Constraint{Any: &CompoundConstraint{Constraints: []Constraint{
+				{Package: &PackageConstraint{PackageName: "etcd", VersionRange: ">=0.9.0"}},
+				{Not: &CompoundConstraint{}},
+			}}},
+			expectErr: "any.constraints[1]: not: constraints must not be empty",
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.constraint.Validate()
+			if s.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, s.expectErr)
+			}
+		})
+	}
+}
+
+func TestConstraintEvaluatorEvaluate(t *testing.T) {
+	type spec struct {
+		name       string
+		constraint Constraint
+		expected   bool
+		expectErr  string
+	}
+
+	props := []Property{
+		MustBuildPackage("etcd", "0.9.2"),
+		MustBuildGVK("etcd.database.coreos.com", "v1beta2", "EtcdCluster"),
+		{Type: "certified", Value: []byte(`true`)},
+	}
+	etcdGVK := func(version string) Constraint {
+		return Constraint{GVK: &GVKConstraint{Group: "etcd.database.coreos.com", Version: version, Kind: "EtcdCluster"}}
+	}
+	etcdPackage := func(versionRange string) Constraint {
+		return Constraint{Package: &PackageConstraint{PackageName: "etcd", VersionRange: versionRange}}
+	}
+	cel := func(rule string) Constraint {
+		return Constraint{Cel: &CelConstraint{Rule: rule}}
+	}
+
+	specs := []spec{
+		{name: "GVK/Satisfied", constraint: etcdGVK("v1beta2"), expected: true},
+		{name: "GVK/NotSatisfied", constraint: etcdGVK("v1beta1"), expected: false},
+		{name: "Package/Satisfied", constraint: etcdPackage(">=0.9.0 <1.0.0"), expected: true},
+		{name: "Package/NotSatisfied", constraint: etcdPackage(">=1.0.0"), expected: false},
+		{name: "Package/OtherPackage", constraint: Constraint{Package: &PackageConstraint{PackageName: "etcd-operator", VersionRange: ">=0.0.0"}}, expected: false},
+		{name: "Cel/Satisfied", constraint: cel(`properties.exists(p, p.type == "certified" && p.value == true)`), expected: true},
+		{name: "Cel/NotSatisfied", constraint: cel(`properties.exists(p, p.type == "olm.maxOpenShiftVersion")`), expected: false},
+		{name: "Cel/SemverCompare", constraint: cel(`properties.exists(p, p.type == "olm.package" && semver_compare(p.value.version, "0.9.0") > 0)`), expected: true},
+		{
+			name:       "All/Satisfied",
+			constraint: Constraint{All: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta2"), etcdPackage(">=0.9.0")}}},
+			expected:   true,
+		},
+		{
+			name:       "All/NotSatisfied",
+			constraint: Constraint{All: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta2"), etcdPackage(">=1.0.0")}}},
+			expected:   false,
+		},
+		{
+			name:       "Any/Satisfied",
+			constraint: Constraint{Any: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta1"), etcdPackage(">=0.9.0")}}},
+			expected:   true,
+		},
+		{
+			name:       "Any/NotSatisfied",
+			constraint: Constraint{Any: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta1"), etcdPackage(">=1.0.0")}}},
+			expected:   false,
+		},
+		{
+			name:       "Not/Satisfied",
+			constraint: Constraint{Not: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta1"), etcdPackage(">=1.0.0")}}},
+			expected:   true,
+		},
+		{
+			name:       "Not/NotSatisfied",
+			constraint: Constraint{Not: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta1"), etcdPackage(">=0.9.0")}}},
+			expected:   false,
+		},
+		{
+			name: "Nested",
+			constraint: Constraint{All: &CompoundConstraint{Constraints: []Constraint{
+				etcdPackage(">=0.9.0"),
+				{Not: &CompoundConstraint{Constraints: []Constraint{etcdGVK("v1beta1")}}},
+			}}},
+			expected: true,
+		},
+		{
+			name:       "Error/Invalid",
+			constraint: Constraint{},
+			expectErr:  "constraint must have one of cel, package, gvk, all, any or not set",
+		},
+		{
+			name:       "Error/CelEvaluation",
+			constraint: cel(`properties.exists(p, semver_compare(p.value, "1.0.0") > 0)`),
+			expectErr:  "unable to parse 'map[packageName:etcd version:0.9.2]' to semver format",
+		},
+	}
+
+	e := NewConstraintEvaluator()
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := e.Evaluate(s.constraint, props)
+			if s.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, s.expectErr)
+			}
+			require.Equal(t, s.expected, actual)
+		})
+	}
+}
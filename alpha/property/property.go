@@ -95,6 +95,7 @@ type Properties struct {
 	BundleObjects    []BundleObject    `hash:"set"`
 	Channels         []Channel         `hash:"set"`
 	CSVMetadatas     []CSVMetadata     `hash:"set"`
+	Constraints      []Constraint      `hash:"set"`
 
 	Others []Property `hash:"set"`
 }
@@ -150,6 +151,20 @@ func Parse(in []Property) (*Properties, error) {
 				return nil, ParseError{Idx: i, Typ: prop.Type, Err: err}
 			}
 			out.CSVMetadatas = append(out.CSVMetadatas, p)
+		case TypeConstraint:
+			p, err := ParseConstraint(prop.Value)
+			if err != nil {
+				// Constraints that cannot be decoded are kept with the other
+				// properties rather than failing the whole catalog. Bundle
+				// validation reports them.
+				var raw json.RawMessage
+				if err := json.Unmarshal(prop.Value, &raw); err != nil {
+					return nil, ParseError{Idx: i, Typ: prop.Type, Err: err}
+				}
+				out.Others = append(out.Others, prop)
+				continue
+			}
+			out.Constraints = append(out.Constraints, p)
 		// NOTICE: The Channel properties are for internal use only.
 		//   DO NOT use it for any public-facing functionalities.
 		//   This API is in alpha stage and it is subject to change.
@@ -277,6 +292,10 @@ func MustBuildCSVMetadata(csv v1alpha1.ClusterServiceVersion) Property {
 	})
 }
 
+func MustBuildConstraint(c Constraint) Property {
+	return MustBuild(&c)
+}
+
 // NOTICE: The Channel properties are for internal use only.
 //
 //	DO NOT use it for any public-facing functionalities.
@@ -108,6 +108,33 @@ func TestParse(t *testing.T) {
 			},
 			assertion: assert.Error,
 		},
+		{
+			name: "Error/InvalidConstraint",
+			input: []Property{
+				{Type: TypeConstraint, Value: json.RawMessage(`{`)},
+			},
+			assertion: assert.Error,
+		},
+		{
+			name: "Success/ConstraintUnknownField",
+			input: []Property{
+				{Type: TypeConstraint, Value: json.RawMessage(`{"gvk":{"group":"g","kind":"K","version":"v1"},"unknown":true}`)},
+			},
+			expectProps: &Properties{
+				Constraints: []Constraint{{GVK: &GVKConstraint{Group: "g", Kind: "K", Version: "v1"}}},
+			},
+			assertion: assert.NoError,
+		},
+		{
+			name: "Success/UndecodableConstraint",
+			input: []Property{
+				{Type: TypeConstraint, Value: json.RawMessage(`{"gvk":"g/v1/K"}`)},
+			},
+			expectProps: &Properties{
+				Others: []Property{{Type: TypeConstraint, Value: json.RawMessage(`{"gvk":"g/v1/K"}`)}},
+			},
+			assertion: assert.NoError,
+		},
 		{
 			name: "Error/InvalidOther",
 			input: []Property{
@@ -127,6 +154,7 @@ func TestParse(t *testing.T) {
 				MustBuildGVKRequired("other", "v2", "Kind3"),
 				MustBuildGVKRequired("other", "v2", "Kind4"),
 				MustBuildBundleObject([]byte("testdata2")),
+				MustBuildConstraint(Constraint{FailureMessage: "requires Kind5", GVK: &GVKConstraint{"other", "Kind5", "v2"}}),
 				{Type: "otherType1", Value: json.RawMessage(`{"v":"otherValue1"}`)},
 				{Type: "otherType2", Value: json.RawMessage(`["otherValue2"]`)},
 			},
@@ -150,6 +178,9 @@ func TestParse(t *testing.T) {
 				BundleObjects: []BundleObject{
 					{Data: []byte("testdata2")},
 				},
+				Constraints: []Constraint{
+					{FailureMessage: "requires Kind5", GVK: &GVKConstraint{"other", "Kind5", "v2"}},
+				},
 				Others: []Property{
 					{Type: "otherType1", Value: json.RawMessage(`{"v":"otherValue1"}`)},
 					{Type: "otherType2", Value: json.RawMessage(`["otherValue2"]`)},
@@ -234,6 +265,12 @@ func TestBuild(t *testing.T) {
 			assertion:        require.NoError,
 			expectedProperty: propPtr(MustBuildBundleObject([]byte("test"))),
 		},
+		{
+			name:             "Success/Constraint",
+			input:            &Constraint{Package: &PackageConstraint{"name", ">=0.1.0"}},
+			assertion:        require.NoError,
+			expectedProperty: &Property{Type: TypeConstraint, Value: json.RawMessage(`{"package":{"packageName":"name","versionRange":">=0.1.0"}}`)},
+		},
 		{
 			name:             "Success/Property",
 			input:            &Property{Type: "foo", Value: json.RawMessage(`"bar"`)},
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/operator-registry/pkg/cache"
+)
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect, verify and convert serve caches",
+		Long: `The cache subcommands operate on the cache directories that are built by
+"opm serve --cache-dir", for example to find out why a catalog fails its cache
+integrity check.`,
+		Args: cobra.NoArgs,
+	}
+	cmd.AddCommand(newInspectCmd(), newVerifyCmd(), newConvertCmd())
+	return cmd
+}
+
+func newInspectCmd() *cobra.Command {
+	var output string
+	logger := logrus.New()
+	cmd := &cobra.Command{
+		Use:   "inspect <cache-dir>",
+		Short: "Print the content of a cache",
+		Long: `Print the backend, the digest and the package index of a cache, with the
+number of bundles in each channel. The integrity of the cache is not checked.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			info, err := cache.Inspect(cmd.Context(), args[0])
+			if err != nil {
+				logger.Fatal(err)
+			}
+			switch output {
+			case "text":
+				err = writeInfoText(os.Stdout, info)
+			case "json":
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "    ")
+				err = enc.Encode(info)
+			default:
+				logger.Fatalf("invalid --output value %q, expected (text|json)", output)
+			}
+			if err != nil {
+				logger.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text|json)")
+	return cmd
+}
+
+func writeInfoText(w io.Writer, info *cache.Info) error {
+	digest := info.Digest
+	if digest == "" {
+		digest = "<none>"
+	}
+	fmt.Fprintf(w, "Backend:  %s\n", info.Backend)
+	fmt.Fprintf(w, "Digest:   %s\n", digest)
+	fmt.Fprintf(w, "Packages: %d\n", len(info.Packages))
+	fmt.Fprintf(w, "Bundles:  %d\n\n", info.Bundles)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PACKAGE\tCHANNEL\tHEAD\tBUNDLES\tDEFAULT\tDEPRECATED")
+	for _, pkg := range info.Packages {
+		for _, ch := range pkg.Channels {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", pkg.Name, ch.Name, ch.Head, ch.Bundles, yesNo(ch.Name == pkg.DefaultChannel), yesNo(pkg.Deprecated || ch.Deprecated))
+		}
+	}
+	return tw.Flush()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return ""
+}
+
+func newVerifyCmd() *cobra.Command {
+	logger := logrus.New()
+	return &cobra.Command{
+		Use:   "verify <cache-dir> <fbc-dir>",
+		Short: "Verify a cache against a file-based catalog",
+		Long: `Verify that the digest of a cache matches the file-based catalog it is served
+with, and that its content is equivalent to a cache built from the catalog.
+The differences that are found are printed, and the command exits with a
+non-zero status if there are any.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			diffs, err := cache.Verify(cmd.Context(), args[0], os.DirFS(args[1]))
+			if err != nil {
+				logger.Fatal(err)
+			}
+			for _, diff := range diffs {
+				fmt.Fprintln(os.Stdout, diff)
+			}
+			if len(diffs) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func newConvertCmd() *cobra.Command {
+	var to string
+	logger := logrus.New()
+	cmd := &cobra.Command{
+		Use:   "convert <cache-dir> <fbc-dir> <output-dir>",
+		Short: "Convert a cache to another backend",
+		Long: fmt.Sprintf(`Convert a cache to the backend given by --to (%s or %s), and write the
+converted cache to an empty or non-existent output directory.
+
+The cache must be valid for the file-based catalog it is served with, which is
+needed to compute the digest of the converted cache. The converted cache is
+identical to a cache built from the catalog with the target backend.`, cache.FormatPogrebV1, cache.FormatJSON),
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cache.Convert(cmd.Context(), args[0], os.DirFS(args[1]), args[2], to); err != nil {
+				logger.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&to, "to", "", fmt.Sprintf("Backend of the converted cache (%s|%s)", cache.FormatPogrebV1, cache.FormatJSON))
+	if err := cmd.MarkFlagRequired("to"); err != nil {
+		logger.Fatalf("Failed to mark `to` flag for `convert` subcommand as required")
+	}
+	return cmd
+}
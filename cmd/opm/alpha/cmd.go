@@ -4,10 +4,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/operator-framework/operator-registry/cmd/opm/alpha/bundle"
+	"github.com/operator-framework/operator-registry/cmd/opm/alpha/cache"
 	converttemplate "github.com/operator-framework/operator-registry/cmd/opm/alpha/convert-template"
+	"github.com/operator-framework/operator-registry/cmd/opm/alpha/diff"
+	"github.com/operator-framework/operator-registry/cmd/opm/alpha/format"
 	"github.com/operator-framework/operator-registry/cmd/opm/alpha/list"
 	rendergraph "github.com/operator-framework/operator-registry/cmd/opm/alpha/render-graph"
 	"github.com/operator-framework/operator-registry/cmd/opm/alpha/template"
+	"github.com/operator-framework/operator-registry/cmd/opm/alpha/upgradepath"
 )
 
 func NewCmd(showAlphaHelp bool) *cobra.Command {
@@ -28,6 +32,10 @@ func NewCmd(showAlphaHelp bool) *cobra.Command {
 		rendergraph.NewCmd(),
 		template.NewCmd(),
 		converttemplate.NewCmd(),
+		diff.NewCmd(),
+		format.NewCmd(),
+		upgradepath.NewCmd(),
+		cache.NewCmd(),
 	)
 	return runCmd
 }
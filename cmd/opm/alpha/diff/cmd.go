@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/operator-registry/alpha/action"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/cmd/opm/internal/util"
+)
+
+func NewCmd() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "diff <old-ref> <new-ref>",
+		Short: "Show the changes between two catalogs",
+		Long: `Show the packages, channels, bundles and deprecations that were added, removed
+or modified between two catalogs, including changes to the replaces, skips and
+skipRange upgrade edges of each channel entry.
+
+Each reference can be any catalog image, file-based catalog directory, bundle
+image, bundle directory or sqlite file supported by the "render" command.
+`,
+		Example: `
+#
+# Show the changes between two versions of a catalog image
+#
+$ opm alpha diff quay.io/operatorhubio/catalog:v1 quay.io/operatorhubio/catalog:v2
+
+#
+# Show the changes between a catalog image and a local file-based catalog as JSON
+#
+$ opm alpha diff quay.io/operatorhubio/catalog:latest ./catalog -o json
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var write func(declcfg.ChangeSet, io.Writer) error
+			switch output {
+			case "text":
+				write = func(cs declcfg.ChangeSet, w io.Writer) error {
+					return cs.WriteText(w)
+				}
+			case "json":
+				write = writeJSON
+			case "yaml":
+				write = writeYAML
+			default:
+				log.Fatalf("invalid --output value %q, expected (text|json|yaml)", output)
+			}
+
+			// The bundle loading impl is somewhat verbose, even on the happy path,
+			// so discard all logrus default logger logs. Any important failures will be
+			// returned from diff.Run and logged as fatal errors.
+			logrus.SetOutput(io.Discard)
+
+			reg, err := util.CreateCLIRegistry(cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer reg.Destroy()
+
+			diff := action.Diff{
+				OldRef:   args[0],
+				NewRef:   args[1],
+				Registry: reg,
+			}
+			cs, err := diff.Run(cmd.Context())
+			if err != nil {
+				log.Fatal(err)
+			}
+			if err := write(*cs, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text|json|yaml)")
+	return cmd
+}
+
+func writeJSON(cs declcfg.ChangeSet, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(cs)
+}
+
+func writeYAML(cs declcfg.ChangeSet, w io.Writer) error {
+	data, err := yaml.Marshal(cs)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,73 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/operator-framework/operator-registry/alpha/action"
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		format action.Fmt
+		layout string
+		output string
+	)
+	cmd := &cobra.Command{
+		Use:   "fmt <fbc-dir>",
+		Short: "Rewrite a file-based catalog in canonical form",
+		Long: fmt.Sprintf(`Rewrite a file-based catalog directory in place in a canonical, deterministic
+form, and print the paths of the files that were created, changed or removed.
+
+The --layout flag controls how objects are distributed across files:
+  - %[1]s: one file per package (<package>/catalog.<ext>)
+  - %[2]s: a directory per package with a file per schema (<package>/<schema>.<ext>)
+  - %[3]s: a file per bundle (<package>/bundles/<bundle>.<ext>), with all other
+    objects of the package in <package>/package.<ext>
+
+Files matched by .indexignore are neither read nor modified. All other files
+in the directory are treated as part of the catalog, and are removed if the
+layout does not place any objects in them.
+
+With --check, no files are written. Instead, the paths of the files that are
+not in canonical form are printed, and the command exits with a non-zero
+status if there are any.
+`, declcfg.LayoutPackage, declcfg.LayoutSchema, declcfg.LayoutBundle),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			format.CatalogDir = args[0]
+			format.Layout = declcfg.Layout(layout)
+
+			switch output {
+			case "yaml":
+				format.WriteFunc = declcfg.WriteYAML
+				format.FileExt = ".yaml"
+			case "json":
+				format.WriteFunc = declcfg.WriteJSON
+				format.FileExt = ".json"
+			default:
+				log.Fatalf("invalid --output value %q, expected (json|yaml)", output)
+			}
+
+			paths, err := format.Run(cmd.Context())
+			for _, p := range paths {
+				fmt.Fprintln(os.Stdout, p)
+			}
+			if errors.Is(err, action.ErrNotFormatted) {
+				os.Exit(1)
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&layout, "layout", string(declcfg.LayoutPackage), fmt.Sprintf("Layout of the formatted catalog %v", declcfg.Layouts))
+	cmd.Flags().StringVarP(&output, "output", "o", "yaml", "Output format (json|yaml)")
+	cmd.Flags().BoolVar(&format.Check, "check", false, "Report files that are not in canonical form without modifying them")
+	return cmd
+}
@@ -0,0 +1,123 @@
+package upgradepath
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/operator-registry/alpha/action"
+	"github.com/operator-framework/operator-registry/cmd/opm/internal/util"
+)
+
+func NewCmd() *cobra.Command {
+	var (
+		upgradePath action.UpgradePath
+		output      string
+	)
+	cmd := &cobra.Command{
+		Use:   "upgrade-path <catalog-ref>",
+		Short: "Show the upgrades OLM performs to reach the head of a channel",
+		Long: `Show the sequence of bundles OLM installs to upgrade a package from an installed
+version to the head of a channel.
+
+At each step, the candidate upgrades of the installed bundle are the bundles in
+the channel that replace or skip it, as reported by the registry's
+GetBundleThatReplaces and GetChannelEntriesThatReplace queries. With
+--include-skip-range, bundles whose skipRange includes the installed version are
+candidates too. The candidate that is closest to the channel head is installed.
+The installed version does not need to be in the channel, in which case only
+skipRanges can upgrade it.
+
+The catalog reference can be any catalog image, file-based catalog directory or
+sqlite file supported by the "render" command.
+
+The command exits with status 1 if there is no upgrade path to the channel
+head.
+`,
+		Example: `
+#
+# Show how customers on foo 1.2.3 are upgraded to the head of the stable channel
+#
+$ opm alpha upgrade-path quay.io/operatorhubio/catalog:latest --package=foo --channel=stable --installed-version=1.2.3
+
+#
+# Also show every alternative upgrade path, as JSON
+#
+$ opm alpha upgrade-path ./catalog --package=foo --channel=stable --installed-version=1.2.3 --all -o json
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var write func(action.UpgradePathResult, io.Writer) error
+			switch output {
+			case "text":
+				write = func(res action.UpgradePathResult, w io.Writer) error {
+					return res.WriteText(w)
+				}
+			case "json":
+				write = writeJSON
+			case "yaml":
+				write = writeYAML
+			default:
+				log.Fatalf("invalid --output value %q, expected (text|json|yaml)", output)
+			}
+
+			// The bundle loading impl is somewhat verbose, even on the happy path,
+			// so discard all logrus default logger logs. Any important failures will be
+			// returned from upgradePath.Run and logged as fatal errors.
+			logrus.SetOutput(io.Discard)
+
+			reg, err := util.CreateCLIRegistry(cmd)
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer reg.Destroy()
+
+			upgradePath.CatalogRef = args[0]
+			upgradePath.Registry = reg
+			res, err := upgradePath.Run(cmd.Context())
+			if err != nil && !errors.Is(err, action.ErrNoUpgradePath) {
+				log.Fatal(err)
+			}
+			if werr := write(*res, os.Stdout); werr != nil {
+				log.Fatal(werr)
+			}
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&upgradePath.Package, "package", "", "Name of the package")
+	cmd.Flags().StringVar(&upgradePath.Channel, "channel", "", "Name of the channel")
+	cmd.Flags().StringVar(&upgradePath.InstalledVersion, "installed-version", "", "Version of the installed bundle")
+	cmd.Flags().BoolVar(&upgradePath.AllPaths, "all", false, "Also show every upgrade path to the channel head")
+	cmd.Flags().BoolVar(&upgradePath.IncludeSkipRange, "include-skip-range", false, "Also upgrade to bundles whose skipRange includes the installed version")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format (text|json|yaml)")
+	for _, name := range []string{"package", "channel", "installed-version"} {
+		if err := cmd.MarkFlagRequired(name); err != nil {
+			log.Fatal(err)
+		}
+	}
+	return cmd
+}
+
+func writeJSON(res action.UpgradePathResult, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(res)
+}
+
+func writeYAML(res action.UpgradePathResult, w io.Writer) error {
+	data, err := yaml.Marshal(res)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
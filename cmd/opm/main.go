@@ -6,9 +6,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/operator-framework/operator-registry/cmd/opm/root"
+	"github.com/operator-framework/operator-registry/pkg/lib/tracing"
 	registrylib "github.com/operator-framework/operator-registry/pkg/registry"
 )
 
@@ -19,7 +21,17 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	if err := cmd.ExecuteContext(ctx); err != nil {
+	shutdownTracing, err := tracing.Setup(ctx, "opm")
+	if err != nil {
+		logrus.WithError(err).Warn("unable to set up tracing")
+		shutdownTracing = func(context.Context) error { return nil }
+	}
+
+	err = cmd.ExecuteContext(ctx)
+	if err := shutdownTracing(context.Background()); err != nil {
+		logrus.WithError(err).Warn("unable to flush traces")
+	}
+	if err != nil {
 		agg, ok := err.(utilerrors.Aggregate)
 		if !ok {
 			os.Exit(1)
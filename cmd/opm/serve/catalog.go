@@ -0,0 +1,241 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+	"github.com/operator-framework/operator-registry/pkg/server"
+)
+
+// registryHealthServices are the names of the health services that report
+// the status of the registry as a whole.
+var registryHealthServices = []string{"", server.RegistryHealthService, server.APIRegistryHealthService}
+
+// catalogNameRegexp matches valid catalog names. Names are used as directory
+// names and as request metadata values, so they are restricted to DNS labels.
+var catalogNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// catalog is a declarative config directory served by the serve command.
+type catalog struct {
+	// name is empty for the catalog of a single catalog server.
+	name      string
+	configDir string
+	cacheDir  string
+	// port is the dedicated port of the catalog, if any.
+	port string
+
+	// store serves the catalog. Until the catalog has been loaded, requests
+	// fail with codes.Unavailable.
+	store *cache.Swappable
+	// loaded is set once the catalog has been loaded.
+	loaded bool
+}
+
+func newCatalog(name, configDir, cacheDir, port string) *catalog {
+	return &catalog{
+		name:      name,
+		configDir: configDir,
+		cacheDir:  cacheDir,
+		port:      port,
+		store:     cache.NewSwappable(notLoadedCache{}),
+	}
+}
+
+// catalogs returns the catalogs to serve. If a source path was given, it is
+// the only catalog, and it has an empty name. Otherwise, the catalogs are
+// named by --catalog, and their caches are in subdirectories of cacheRoot.
+func (s *serve) catalogs(cacheRoot string) ([]*catalog, error) {
+	if s.configDir != "" {
+		if len(s.namedCatalogs) > 0 {
+			return nil, fmt.Errorf("--catalog cannot be specified with a source path")
+		}
+		if len(s.catalogPorts) > 0 {
+			return nil, fmt.Errorf("--catalog-port can only be specified with --catalog")
+		}
+		return []*catalog{newCatalog("", s.configDir, cacheRoot, "")}, nil
+	}
+	if len(s.namedCatalogs) == 0 {
+		return nil, fmt.Errorf("a source path or at least one --catalog must be specified")
+	}
+
+	for name := range s.catalogPorts {
+		if _, ok := s.namedCatalogs[name]; !ok {
+			return nil, fmt.Errorf("--catalog-port specified for unknown catalog %q", name)
+		}
+	}
+	catalogs := make([]*catalog, 0, len(s.namedCatalogs))
+	for name, configDir := range s.namedCatalogs {
+		if !catalogNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid catalog name %q: must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character", name)
+		}
+		catalogs = append(catalogs, newCatalog(name, configDir, filepath.Join(cacheRoot, name), s.catalogPorts[name]))
+	}
+	sort.Slice(catalogs, func(i, j int) bool { return catalogs[i].name < catalogs[j].name })
+	return catalogs, nil
+}
+
+// load loads the cache of c, after checking its integrity or rebuilding it,
+// and swaps it into c.store.
+func (c *catalog) load(ctx context.Context, enforceIntegrity bool, m *metrics, logger *logrus.Entry) error {
+	if err := removeReloadDirs(c.cacheDir, logger); err != nil {
+		return err
+	}
+	store, err := cache.New(c.cacheDir, cache.WithLog(logger))
+	if err != nil {
+		return err
+	}
+	store = m.instrument(store, c.name)
+	if enforceIntegrity {
+		if err := store.CheckIntegrity(ctx, os.DirFS(c.configDir)); err != nil {
+			store.Close()
+			return fmt.Errorf("integrity check failed: %v", err)
+		}
+		if err := store.Load(ctx); err != nil {
+			store.Close()
+			return fmt.Errorf("failed to load cache: %v", err)
+		}
+	} else {
+		if err := cache.LoadOrRebuild(ctx, store, os.DirFS(c.configDir)); err != nil {
+			store.Close()
+			return fmt.Errorf("failed to load or rebuild cache: %v", err)
+		}
+	}
+	// The store is swappable so that the watcher can replace the cache while
+	// the server is running. Closing it closes the cache that is current.
+	c.store.Swap(store)
+	c.loaded = true
+	return nil
+}
+
+// healthServices returns the names of the health services that report the
+// status of c. The catalog of a single catalog server is the registry itself,
+// while a named catalog is reported under its name.
+func (c *catalog) healthServices() []string {
+	if c.name == "" {
+		return registryHealthServices
+	}
+	return []string{c.name}
+}
+
+func (c *catalog) setServingStatus(hs *server.HealthServer, servingStatus health.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.healthServices() {
+		hs.SetServingStatus(service, servingStatus)
+	}
+}
+
+func (c *catalog) logFields() logrus.Fields {
+	fields := logrus.Fields{
+		"configs": c.configDir,
+		"cache":   c.cacheDir,
+	}
+	if c.name != "" {
+		fields["catalog"] = c.name
+	}
+	return fields
+}
+
+// notLoadedCache is the cache of a catalog that has not been loaded. It fails
+// every query with codes.Unavailable, so that clients retry once the catalog
+// is ready.
+type notLoadedCache struct {
+	cache.Cache
+}
+
+var errNotLoaded = status.Error(codes.Unavailable, "catalog has not been loaded")
+
+func (notLoadedCache) Close() error {
+	return nil
+}
+
+func (notLoadedCache) ListPackages(context.Context) ([]string, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) SendBundles(context.Context, registry.BundleSender) error {
+	return errNotLoaded
+}
+
+func (notLoadedCache) SendFilteredBundles(context.Context, registry.BundleSender, registry.BundleFilter) error {
+	return errNotLoaded
+}
+
+func (notLoadedCache) ListBundles(context.Context) ([]*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetPackage(context.Context, string) (*registry.PackageManifest, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetBundle(context.Context, string, string, string) (*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetBundleForChannel(context.Context, string, string) (*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetChannelEntriesThatReplace(context.Context, string) ([]*registry.ChannelEntry, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetBundleThatReplaces(context.Context, string, string, string) (*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetChannelEntriesThatProvide(context.Context, string, string, string) ([]*registry.ChannelEntry, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetLatestChannelEntriesThatProvide(context.Context, string, string, string) ([]*registry.ChannelEntry, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetBundleThatProvides(context.Context, string, string, string) (*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) ListChannelEntries(context.Context, string, string) ([]*registry.ChannelEntry, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetDeprecations(context.Context, string) (*api.Deprecations, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetCatalogInfo(context.Context) (*api.CatalogInfo, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetBundlesInRange(context.Context, string, string) ([]*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetBundleByImage(context.Context, string) (*api.Bundle, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) FindBundles(context.Context, registry.PropertyQuery) ([]*api.BundleKey, error) {
+	return nil, errNotLoaded
+}
+
+func (notLoadedCache) GetKindForPlural(context.Context, string, string, string) (string, error) {
+	return "", errNotLoaded
+}
+
+func (notLoadedCache) SendMetas(context.Context, cache.MetaFilter, func(*declcfg.Meta) error) error {
+	return errNotLoaded
+}
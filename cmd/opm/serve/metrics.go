@@ -0,0 +1,205 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"time"
+
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+const metricsPath = "/metrics"
+
+// metrics holds the prometheus metrics of the serve command. A nil *metrics
+// is valid and records nothing, so that callers do not need to check whether
+// metrics are enabled.
+type metrics struct {
+	registry *prometheus.Registry
+	grpc     *grpcprom.ServerMetrics
+
+	packages             *prometheus.GaugeVec
+	bundles              *prometheus.GaugeVec
+	backend              *prometheus.GaugeVec
+	buildDuration        *prometheus.GaugeVec
+	loadDuration         *prometheus.GaugeVec
+	integrityCheckPassed *prometheus.GaugeVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		grpc:     grpcprom.NewServerMetrics(grpcprom.WithServerHandlingTimeHistogram()),
+		packages: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "opm_cache_packages",
+			Help: "Number of packages in the served cache.",
+		}, []string{"catalog"}),
+		bundles: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "opm_cache_bundles",
+			Help: "Number of distinct bundles in the served cache.",
+		}, []string{"catalog"}),
+		backend: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "opm_cache_backend_info",
+			Help: "Backend of the served cache. The value is always 1.",
+		}, []string{"catalog", "backend"}),
+		buildDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "opm_cache_build_duration_seconds",
+			Help: "Duration of the last successful cache build.",
+		}, []string{"catalog"}),
+		loadDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "opm_cache_load_duration_seconds",
+			Help: "Duration of the last successful cache load.",
+		}, []string{"catalog"}),
+		integrityCheckPassed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "opm_cache_integrity_check_passed",
+			Help: "Whether the last cache integrity check passed (1) or failed (0).",
+		}, []string{"catalog"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpc,
+		m.packages,
+		m.bundles,
+		m.backend,
+		m.buildDuration,
+		m.loadDuration,
+		m.integrityCheckPassed,
+	)
+	return m
+}
+
+// serverOptions returns the options that install the request metrics
+// interceptors, chained after the given interceptors.
+func (m *metrics) serverOptions(stream grpc.StreamServerInterceptor, unary grpc.UnaryServerInterceptor) []grpc.ServerOption {
+	if m == nil {
+		return []grpc.ServerOption{
+			grpc.ChainStreamInterceptor(stream),
+			grpc.ChainUnaryInterceptor(unary),
+		}
+	}
+	return []grpc.ServerOption{
+		grpc.ChainStreamInterceptor(stream, m.grpc.StreamServerInterceptor()),
+		grpc.ChainUnaryInterceptor(unary, m.grpc.UnaryServerInterceptor()),
+	}
+}
+
+// initializeServer initializes the request metrics of all methods registered
+// on s, so that they are reported before the methods are first called.
+func (m *metrics) initializeServer(s *grpc.Server) {
+	if m == nil {
+		return
+	}
+	m.grpc.InitializeMetrics(s)
+}
+
+// instrument returns a cache that records the results of integrity checks,
+// the durations of builds and loads, and the content of the cache when it is
+// loaded, labelled with the name of the catalog. The catalog served by a
+// single catalog server has an empty name, so that its metrics have no
+// catalog label.
+func (m *metrics) instrument(c cache.Cache, catalog string) cache.Cache {
+	if m == nil {
+		return c
+	}
+	return &instrumentedCache{Cache: c, metrics: m, catalog: catalog}
+}
+
+// startEndpoint serves the metrics on addr. The returned function shuts the
+// endpoint down.
+func (m *metrics) startEndpoint(addr string, logger *logrus.Entry) (func(context.Context) error, error) {
+	if m == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	closeErr := make(chan error, 1)
+	go func() {
+		logger.WithField("address", addr).Info("starting metrics endpoint")
+		if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			closeErr <- err
+			return
+		}
+		closeErr <- nil
+	}()
+	return func(ctx context.Context) error {
+		if err := server.Shutdown(ctx); err != nil {
+			return err
+		}
+		return <-closeErr
+	}, nil
+}
+
+type instrumentedCache struct {
+	cache.Cache
+	metrics *metrics
+	catalog string
+}
+
+func (c *instrumentedCache) CheckIntegrity(ctx context.Context, fbc fs.FS) error {
+	err := c.Cache.CheckIntegrity(ctx, fbc)
+	if err != nil {
+		c.metrics.integrityCheckPassed.WithLabelValues(c.catalog).Set(0)
+	} else {
+		c.metrics.integrityCheckPassed.WithLabelValues(c.catalog).Set(1)
+	}
+	return err
+}
+
+func (c *instrumentedCache) Build(ctx context.Context, fbc fs.FS) error {
+	start := time.Now()
+	if err := c.Cache.Build(ctx, fbc); err != nil {
+		return err
+	}
+	c.metrics.buildDuration.WithLabelValues(c.catalog).Set(time.Since(start).Seconds())
+	return nil
+}
+
+func (c *instrumentedCache) Load(ctx context.Context) error {
+	start := time.Now()
+	if err := c.Cache.Load(ctx); err != nil {
+		return err
+	}
+	c.metrics.loadDuration.WithLabelValues(c.catalog).Set(time.Since(start).Seconds())
+
+	r, ok := c.Cache.(cache.StatsReporter)
+	if !ok {
+		return nil
+	}
+	stats := r.Stats()
+	c.metrics.packages.WithLabelValues(c.catalog).Set(float64(stats.Packages))
+	c.metrics.bundles.WithLabelValues(c.catalog).Set(float64(stats.Bundles))
+	c.metrics.backend.DeletePartialMatch(prometheus.Labels{"catalog": c.catalog})
+	c.metrics.backend.WithLabelValues(c.catalog, stats.Backend).Set(1)
+	return nil
+}
+
+func (c *instrumentedCache) SendFilteredBundles(ctx context.Context, stream registry.BundleSender, filter registry.BundleFilter) error {
+	return registry.SendFilteredBundles(ctx, c.Cache, stream, filter)
+}
+
+func (c *instrumentedCache) SendMetas(ctx context.Context, filter cache.MetaFilter, send func(*declcfg.Meta) error) error {
+	return cache.SendMetas(ctx, c.Cache, filter, send)
+}
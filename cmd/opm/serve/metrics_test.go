@@ -0,0 +1,138 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/phayes/freeport"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/server"
+)
+
+// writeCatalog writes a declarative config with a single package, containing
+// a single bundle at version, to dir.
+func writeCatalog(t *testing.T, dir, pkg, version string) {
+	t.Helper()
+	fbc := fmt.Sprintf(`{"schema":"olm.package","name":%[1]q,"defaultChannel":"stable"}
+{"schema":"olm.channel","name":"stable","package":%[1]q,"entries":[{"name":"%[1]s.v%[2]s"}]}
+{"schema":"olm.bundle","name":"%[1]s.v%[2]s","package":%[1]q,"image":"quay.io/test/%[1]s:v%[2]s","properties":[{"type":"olm.package","value":{"packageName":%[1]q,"version":%[2]q}}]}
+`, pkg, version)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, pkg), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, pkg, "catalog.json"), []byte(fbc), 0644))
+}
+
+func discardLogger() *logrus.Entry {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logrus.NewEntry(logger)
+}
+
+// scrape returns the metrics exposed on addr, in the text exposition format.
+func scrape(t *testing.T, addr string) string {
+	t.Helper()
+	resp, err := http.Get("http://" + addr + metricsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func startMetricsEndpoint(t *testing.T, m *metrics) string {
+	t.Helper()
+	port, err := freeport.GetFreePort()
+	require.NoError(t, err)
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	stop, err := m.startEndpoint(addr, discardLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, stop(context.Background())) })
+	return addr
+}
+
+func TestMetrics_Interceptors(t *testing.T) {
+	m := newMetrics()
+	addr := startMetricsEndpoint(t, m)
+
+	s := grpc.NewServer(m.serverOptions(loggingInterceptors(discardLogger()))...)
+	health.RegisterHealthServer(s, server.NewHealthServer())
+	m.initializeServer(s)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go s.Serve(lis) //nolint:errcheck
+	defer s.Stop()
+
+	// Registered methods are reported before they are called.
+	require.Contains(t, scrape(t, addr), `grpc_server_handled_total{grpc_code="OK",grpc_method="Check",grpc_service="grpc.health.v1.Health",grpc_type="unary"} 0`)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = health.NewHealthClient(conn).Check(context.Background(), &health.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	metrics := scrape(t, addr)
+	require.Contains(t, metrics, `grpc_server_handled_total{grpc_code="OK",grpc_method="Check",grpc_service="grpc.health.v1.Health",grpc_type="unary"} 1`)
+	require.Contains(t, metrics, `grpc_server_handling_seconds_count{grpc_method="Check",grpc_service="grpc.health.v1.Health",grpc_type="unary"} 1`)
+}
+
+func TestMetrics_InstrumentedCache(t *testing.T) {
+	configDir := t.TempDir()
+	writeCatalog(t, configDir, "foo", "0.1.0")
+	writeCatalog(t, configDir, "bar", "0.1.0")
+
+	m := newMetrics()
+	addr := startMetricsEndpoint(t, m)
+
+	c, err := cache.New(t.TempDir(), cache.WithLog(discardLogger()))
+	require.NoError(t, err)
+	c = m.instrument(c, "test")
+	defer c.Close()
+
+	ctx := context.Background()
+	fbc := os.DirFS(configDir)
+	require.Error(t, c.CheckIntegrity(ctx, fbc))
+	require.Contains(t, scrape(t, addr), `opm_cache_integrity_check_passed{catalog="test"} 0`)
+
+	require.NoError(t, c.Build(ctx, fbc))
+	require.NoError(t, c.CheckIntegrity(ctx, fbc))
+	require.NoError(t, c.Load(ctx))
+
+	metrics := scrape(t, addr)
+	for _, expected := range []string{
+		`opm_cache_integrity_check_passed{catalog="test"} 1`,
+		`opm_cache_packages{catalog="test"} 2`,
+		`opm_cache_bundles{catalog="test"} 2`,
+		`opm_cache_backend_info{backend="pogreb.v1",catalog="test"} 1`,
+		`opm_cache_build_duration_seconds{catalog="test"}`,
+		`opm_cache_load_duration_seconds{catalog="test"}`,
+	} {
+		require.Contains(t, metrics, expected)
+	}
+}
+
+func TestMetrics_Disabled(t *testing.T) {
+	var m *metrics
+	stop, err := m.startEndpoint("127.0.0.1:0", discardLogger())
+	require.NoError(t, err)
+	require.NoError(t, stop(context.Background()))
+
+	c, err := cache.New(t.TempDir(), cache.WithLog(discardLogger()))
+	require.NoError(t, err)
+	defer c.Close()
+	require.Same(t, c, m.instrument(c, "test"))
+	require.Len(t, m.serverOptions(loggingInterceptors(discardLogger())), 2)
+}
@@ -3,6 +3,7 @@ package serve
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
@@ -11,34 +12,51 @@ import (
 	"os"
 	"runtime/pprof"
 	"sync"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	health "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/operator-framework/operator-registry/pkg/api"
-	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/lib/certs"
 	"github.com/operator-framework/operator-registry/pkg/lib/dns"
 	"github.com/operator-framework/operator-registry/pkg/lib/log"
+	"github.com/operator-framework/operator-registry/pkg/lib/tracing"
+	"github.com/operator-framework/operator-registry/pkg/registry"
 	"github.com/operator-framework/operator-registry/pkg/server"
 )
 
 type serve struct {
 	configDir             string
+	namedCatalogs         map[string]string
+	catalogPorts          map[string]string
 	cacheDir              string
 	cacheOnly             bool
 	cacheEnforceIntegrity bool
 
+	watch         bool
+	watchInterval time.Duration
+
 	port           string
+	httpAddr       string
 	terminationLog string
 
+	tlsCertFile     string
+	tlsKeyFile      string
+	tlsClientCAFile string
+
 	debug           bool
 	pprofAddr       string
 	captureProfiles bool
+	metricsAddr     string
 
 	logger *logrus.Entry
 }
@@ -53,17 +71,74 @@ func NewCmd() *cobra.Command {
 		logger: logrus.NewEntry(logger),
 	}
 	cmd := &cobra.Command{
-		Use:   "serve <source_path>",
+		Use:   "serve [<source_path>]",
 		Short: "serve declarative configs",
 		Long: `This command serves declarative configs via a GRPC server.
 
 NOTE: The declarative config directory is loaded by the serve command at
 startup. Changes made to the declarative config after the this command starts
-will not be reflected in the served content.
+will not be reflected in the served content, unless --watch is set.
+
+With --watch, the declarative config directory is polled for changes. When a
+change is detected, a new cache is built in a directory in the cache
+directory, and new requests are served from it once it is ready. Requests that
+are in flight, including streams, complete using the previous cache. If the
+new cache cannot be built, the previous cache continues to be served, but the
+catalog is reported as NOT_SERVING by the gRPC health service until a rebuild
+succeeds. If --cache-dir is set, the cache in it is rebuilt as well once the
+previous cache is no longer in use, so that it is up to date on restart.
+
+The gRPC health service reports the registry as NOT_SERVING until its cache
+has been loaded, and registry requests fail with UNAVAILABLE until then. The
+status is reported for the "" and "api.Registry" services, and for the legacy
+"Registry" service. The Watch method streams status changes.
+
+With --metrics-addr, prometheus metrics are served at /metrics on the given
+address. They include request counts, latencies and status codes per RPC, the
+number of packages and bundles in the served cache, the cache backend, the
+durations of the last cache build and load, and whether the last cache
+integrity check passed.
+
+With --http-addr, the content of the cache is also served as JSON over HTTP
+on the given address. The following endpoints are available:
+
+  GET /api/v1/packages
+  GET /api/v1/packages/{package}
+  GET /api/v1/packages/{package}/channels/{channel}/bundles/{bundle}
+  GET /api/v1/bundles?package=&channel=
+  GET /api/v1/metas?schema=&package=&name=
+
+The metas endpoint serves the declarative config metas that the cache was
+built from as JSON lines, grouped by package. Caches built by earlier versions
+of opm do not store the metas, and must be rebuilt to serve them.
+
+With --tls-cert and --tls-key, the registry is served with TLS. With
+--tls-client-ca, clients must also present a certificate signed by one of the
+CAs in the given file (mutual TLS). The same configuration applies to the HTTP
+endpoints enabled by --http-addr. The files are reloaded when they change, so
+rotated certificates are used without a restart.
+
+Instead of a source path, multiple named catalogs can be served with
+--catalog <name>=<source_path>. Each catalog has its own cache in the
+<name> subdirectory of the cache directory, and is checked, loaded and watched
+independently. A catalog that cannot be loaded is not served, and the other
+catalogs continue to be served. Requests to the main port are routed to the
+catalog named by the "catalog" request metadata. With
+--catalog-port <name>=<port>, a catalog is also served on a dedicated port,
+without routing. The health of each catalog is reported under its name by the
+gRPC health service. With --http-addr, the HTTP endpoints of each catalog are
+served under /catalogs/<name>.
+
+When the OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+environment variable is set, OpenTelemetry spans are exported with OTLP/gRPC
+for each request and for the build, load and integrity check of each cache.
+The exporter is configured by the standard OTEL_* environment variables.
 `,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MaximumNArgs(1),
 		PreRun: func(_ *cobra.Command, args []string) {
-			s.configDir = args[0]
+			if len(args) == 1 {
+				s.configDir = args[0]
+			}
 			if s.debug {
 				logger.SetLevel(logrus.DebugLevel)
 			}
@@ -81,11 +156,20 @@ will not be reflected in the served content.
 	cmd.Flags().BoolVar(&s.debug, "debug", false, "enable debug logging")
 	cmd.Flags().StringVarP(&s.terminationLog, "termination-log", "t", "/dev/termination-log", "path to a container termination log file")
 	cmd.Flags().StringVarP(&s.port, "port", "p", "50051", "port number to serve on")
+	cmd.Flags().StringToStringVar(&s.namedCatalogs, "catalog", nil, "name and source path of a catalog to serve (<name>=<source_path>), can be repeated instead of specifying a single source path")
+	cmd.Flags().StringToStringVar(&s.catalogPorts, "catalog-port", nil, "dedicated port of a catalog specified with --catalog (<name>=<port>), can be repeated")
+	cmd.Flags().StringVar(&s.httpAddr, "http-addr", "", "address of the HTTP/JSON catalog endpoint (addr:port format), disabled if empty")
+	cmd.Flags().StringVar(&s.tlsCertFile, "tls-cert", "", "path to the PEM encoded certificate of the server, enables TLS when set with --tls-key")
+	cmd.Flags().StringVar(&s.tlsKeyFile, "tls-key", "", "path to the PEM encoded private key of the server, enables TLS when set with --tls-cert")
+	cmd.Flags().StringVar(&s.tlsClientCAFile, "tls-client-ca", "", "path to the PEM encoded CA certificates that client certificates must be signed by, requires mutual TLS when set")
 	cmd.Flags().StringVar(&s.pprofAddr, "pprof-addr", "localhost:6060", "address of startup profiling endpoint (addr:port format)")
 	cmd.Flags().BoolVar(&s.captureProfiles, "pprof-capture-profiles", false, "capture pprof CPU profiles")
+	cmd.Flags().StringVar(&s.metricsAddr, "metrics-addr", "", "address of prometheus metrics endpoint (addr:port format), disabled if empty")
 	cmd.Flags().StringVar(&s.cacheDir, "cache-dir", "", "if set, sync and persist server cache directory")
 	cmd.Flags().BoolVar(&s.cacheOnly, "cache-only", false, "sync the serve cache and exit without serving")
 	cmd.Flags().BoolVar(&s.cacheEnforceIntegrity, "cache-enforce-integrity", false, "exit with error if cache is not present or has been invalidated. (default: true when --cache-dir is set and --cache-only is false, false otherwise), ")
+	cmd.Flags().BoolVar(&s.watch, "watch", false, "watch the declarative config directory and serve a rebuilt cache when it changes")
+	cmd.Flags().DurationVar(&s.watchInterval, "watch-interval", 10*time.Second, "interval at which the declarative config directory is checked for changes when --watch is set")
 	return cmd
 }
 
@@ -119,68 +203,268 @@ func (s *serve) run(ctx context.Context) error {
 		return fmt.Errorf("--cache-dir must be specified with --cache-enforce-integrity")
 	}
 
-	if s.cacheDir == "" {
-		s.cacheDir, err = os.MkdirTemp("", "opm-serve-cache-")
+	if s.watch && s.cacheOnly {
+		return fmt.Errorf("--watch cannot be specified with --cache-only")
+	}
+	if s.watch && s.watchInterval <= 0 {
+		return fmt.Errorf("--watch-interval must be positive")
+	}
+
+	if (s.tlsCertFile == "") != (s.tlsKeyFile == "") {
+		return fmt.Errorf("--tls-cert and --tls-key must be specified together")
+	}
+	if s.tlsClientCAFile != "" && s.tlsCertFile == "" {
+		return fmt.Errorf("--tls-cert and --tls-key must be specified with --tls-client-ca")
+	}
+	var (
+		serverOpts []grpc.ServerOption
+		tlsConfig  *tls.Config
+	)
+	if s.tlsCertFile != "" {
+		tlsConfig, err = certs.ServerTLSConfig(s.tlsCertFile, s.tlsKeyFile, s.tlsClientCAFile)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to load TLS configuration: %v", err)
 		}
-		defer os.RemoveAll(s.cacheDir)
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
-	mainLogger = mainLogger.WithFields(logrus.Fields{
-		"configs": s.configDir,
-		"cache":   s.cacheDir,
-	})
 
-	store, err := cache.New(s.cacheDir, cache.WithLog(mainLogger))
+	var m *metrics
+	if s.metricsAddr != "" {
+		m = newMetrics()
+	}
+	stopMetrics, err := m.startEndpoint(s.metricsAddr, mainLogger)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not start metrics endpoint: %v", err)
 	}
-	defer store.Close()
-	if s.cacheEnforceIntegrity {
-		if err := store.CheckIntegrity(ctx, os.DirFS(s.configDir)); err != nil {
-			return fmt.Errorf("integrity check failed: %v", err)
-		}
-		if err := store.Load(ctx); err != nil {
-			return fmt.Errorf("failed to load cache: %v", err)
+	defer func() {
+		if err := stopMetrics(context.Background()); err != nil {
+			mainLogger.Warnf("error shutting down metrics server: %v", err)
 		}
-	} else {
-		if err := cache.LoadOrRebuild(ctx, store, os.DirFS(s.configDir)); err != nil {
-			return fmt.Errorf("failed to load or rebuild cache: %v", err)
+	}()
+
+	cacheRoot := s.cacheDir
+	if cacheRoot == "" {
+		cacheRoot, err = os.MkdirTemp("", "opm-serve-cache-")
+		if err != nil {
+			return err
 		}
+		defer os.RemoveAll(cacheRoot)
+	}
+	catalogs, err := s.catalogs(cacheRoot)
+	if err != nil {
+		return err
 	}
+	single := len(catalogs) == 1 && catalogs[0].name == ""
+	for _, c := range catalogs {
+		defer c.store.Close()
+	}
+
+	healthServer := newHealthServer(catalogs)
 
 	if s.cacheOnly {
-		return nil
+		return s.loadCatalogs(ctx, catalogs, healthServer, m, mainLogger)
 	}
 
-	mainLogger = mainLogger.WithFields(logrus.Fields{"port": s.port})
+	if single {
+		mainLogger = mainLogger.WithFields(catalogs[0].logFields())
+	}
+	mainLogger = mainLogger.WithFields(logrus.Fields{"port": s.port, "tls": s.tlsCertFile != "", "mtls": s.tlsClientCAFile != ""})
+
+	// In a single catalog server, the main port serves the catalog. In a
+	// multiple catalog server, it routes each request to the catalog named by
+	// its metadata, and catalogs may also have dedicated ports.
+	var (
+		mainStore   registry.GRPCQuery
+		httpHandler http.Handler
+	)
+	if single {
+		mainStore = catalogs[0].store
+		httpHandler = server.NewHTTPHandler(catalogs[0].store)
+	} else {
+		stores := make(map[string]registry.GRPCQuery, len(catalogs))
+		mux := http.NewServeMux()
+		for _, c := range catalogs {
+			stores[c.name] = c.store
+			prefix := "/catalogs/" + c.name
+			mux.Handle(prefix+"/", http.StripPrefix(prefix, server.NewHTTPHandler(c.store)))
+		}
+		mainStore = server.NewCatalogRouter(stores)
+		httpHandler = mux
+	}
 
+	streamLogger, unaryLogger := loggingInterceptors(s.logger.Dup())
+	serverOpts = append(serverOpts, m.serverOptions(streamLogger, unaryLogger)...)
+	if tracing.Enabled() {
+		serverOpts = append(serverOpts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+	newServer := func(store registry.GRPCQuery) *grpc.Server {
+		grpcServer := grpc.NewServer(serverOpts...)
+		api.RegisterRegistryServer(grpcServer, server.NewRegistryServer(store))
+		health.RegisterHealthServer(grpcServer, healthServer)
+		reflection.Register(grpcServer)
+		m.initializeServer(grpcServer)
+		return grpcServer
+	}
+
+	type listener struct {
+		lis    net.Listener
+		server *grpc.Server
+	}
 	lis, err := net.Listen("tcp", ":"+s.port)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %s", err)
 	}
+	listeners := []listener{{lis: lis, server: newServer(mainStore)}}
+	for _, c := range catalogs {
+		if c.port == "" {
+			continue
+		}
+		lis, err := net.Listen("tcp", ":"+c.port)
+		if err != nil {
+			return fmt.Errorf("catalog %q: failed to listen: %s", c.name, err)
+		}
+		listeners = append(listeners, listener{lis: lis, server: newServer(c.store)})
+		mainLogger.WithFields(logrus.Fields{"catalog": c.name, "catalogPort": c.port}).Info("serving catalog on dedicated port")
+	}
 
-	streamLogger, unaryLogger := loggingInterceptors(s.logger.Dup())
-	grpcServer := grpc.NewServer(
-		grpc.ChainStreamInterceptor(streamLogger),
-		grpc.ChainUnaryInterceptor(unaryLogger),
-	)
-	api.RegisterRegistryServer(grpcServer, server.NewRegistryServer(store))
-	health.RegisterHealthServer(grpcServer, server.NewHealthServer())
-	reflection.Register(grpcServer)
-	mainLogger.Info("serving registry")
-	p.stopCpuProfileCache()
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, l := range listeners {
+		eg.Go(func() error {
+			return l.server.Serve(l.lis)
+		})
+	}
 
-	go func() {
-		<-ctx.Done()
+	var httpServer *http.Server
+	if s.httpAddr != "" {
+		httpLis, err := net.Listen("tcp", s.httpAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for HTTP: %s", err)
+		}
+		httpServer = &http.Server{
+			Handler:   httpHandler,
+			TLSConfig: tlsConfig,
+		}
+		eg.Go(func() error {
+			mainLogger.WithField("address", s.httpAddr).Info("serving HTTP catalog endpoint")
+			var err error
+			if tlsConfig != nil {
+				err = httpServer.ServeTLS(httpLis, "", "")
+			} else {
+				err = httpServer.Serve(httpLis)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("HTTP server failed: %v", err)
+			}
+			return nil
+		})
+	}
+
+	// The catalogs are loaded while the servers are running. If they cannot
+	// be loaded, the servers are shut down and the error is returned.
+	eg.Go(func() error {
+		mainLogger.Info("loading catalogs")
+		if err := s.loadCatalogs(egCtx, catalogs, healthServer, m, mainLogger); err != nil {
+			return err
+		}
+		mainLogger.Info("serving registry")
+		p.stopCpuProfileCache()
+
+		if s.watch {
+			for _, c := range catalogs {
+				if !c.loaded {
+					continue
+				}
+				w := &watcher{
+					catalog:  c,
+					interval: s.watchInterval,
+					persist:  s.cacheDir != "",
+					health:   healthServer,
+					metrics:  m,
+					logger:   mainLogger.WithFields(c.logFields()).WithField("watchInterval", s.watchInterval),
+				}
+				// The watchers run in the group, so that the stores are
+				// not closed while a cache is rebuilt.
+				eg.Go(func() error {
+					w.run(egCtx)
+					return nil
+				})
+			}
+		}
+		return nil
+	})
+
+	eg.Go(func() error {
+		<-egCtx.Done()
 		mainLogger.Info("shutting down server")
-		grpcServer.GracefulStop()
-		if err := p.stopEndpoint(ctx); err != nil {
+		for _, l := range listeners {
+			l.server.GracefulStop()
+		}
+		if httpServer != nil {
+			if err := httpServer.Shutdown(context.Background()); err != nil {
+				mainLogger.Warnf("error shutting down HTTP server: %v", err)
+			}
+		}
+		if err := p.stopEndpoint(egCtx); err != nil {
 			mainLogger.Warnf("error shutting down pprof server: %v", err)
 		}
-	}()
+		return nil
+	})
 
-	return grpcServer.Serve(lis)
+	return eg.Wait()
+}
+
+// loadCatalogs loads the catalogs, and reports each catalog as serving once it
+// has been loaded. A single catalog server fails if its catalog cannot be
+// loaded. A multiple catalog server serves the catalogs that can be loaded,
+// and continues to report the others as not serving. It fails only if no
+// catalog can be loaded, or if a catalog cannot be loaded with --cache-only.
+func (s *serve) loadCatalogs(ctx context.Context, catalogs []*catalog, healthServer *server.HealthServer, m *metrics, logger *logrus.Entry) error {
+	if len(catalogs) == 1 && catalogs[0].name == "" {
+		if err := catalogs[0].load(ctx, s.cacheEnforceIntegrity, m, logger.WithFields(catalogs[0].logFields())); err != nil {
+			return err
+		}
+		catalogs[0].setServingStatus(healthServer, health.HealthCheckResponse_SERVING)
+		return nil
+	}
+
+	var loadErrs []error
+	for _, c := range catalogs {
+		logger := logger.WithFields(c.logFields())
+		if err := c.load(ctx, s.cacheEnforceIntegrity, m, logger); err != nil {
+			logger.WithError(err).Error("failed to load catalog, it will not be served")
+			loadErrs = append(loadErrs, fmt.Errorf("catalog %q: %v", c.name, err))
+			continue
+		}
+		c.setServingStatus(healthServer, health.HealthCheckResponse_SERVING)
+	}
+	if len(loadErrs) == len(catalogs) || (s.cacheOnly && len(loadErrs) > 0) {
+		return errors.Join(loadErrs...)
+	}
+	setRouterServingStatus(healthServer, health.HealthCheckResponse_SERVING)
+	return nil
+}
+
+// newHealthServer returns a health server that reports the catalogs, and the
+// registry of a multiple catalog server, as not serving. The registry is
+// reported as not serving until its catalogs have been loaded, so that probes
+// do not route requests to it before it is ready.
+func newHealthServer(catalogs []*catalog) *server.HealthServer {
+	healthServer := server.NewHealthServer()
+	for _, c := range catalogs {
+		c.setServingStatus(healthServer, health.HealthCheckResponse_NOT_SERVING)
+	}
+	if len(catalogs) != 1 || catalogs[0].name != "" {
+		setRouterServingStatus(healthServer, health.HealthCheckResponse_NOT_SERVING)
+	}
+	return healthServer
+}
+
+// setRouterServingStatus sets the status of the registry of a multiple
+// catalog server, which routes requests to its catalogs.
+func setRouterServingStatus(healthServer *server.HealthServer, servingStatus health.HealthCheckResponse_ServingStatus) {
+	for _, service := range registryHealthServices {
+		healthServer.SetServingStatus(service, servingStatus)
+	}
 }
 
 // manages an HTTP pprof endpoint served by `server`,
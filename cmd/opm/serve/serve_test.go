@@ -0,0 +1,350 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phayes/freeport"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/server"
+)
+
+func freePort(t *testing.T) string {
+	t.Helper()
+	port, err := freeport.GetFreePort()
+	require.NoError(t, err)
+	return strconv.Itoa(port)
+}
+
+// startServe runs s until the test ends, and returns a function that stops
+// it and returns the result of running it.
+func startServe(t *testing.T, s *serve) func() error {
+	t.Helper()
+	s.logger = discardLogger()
+	s.terminationLog = filepath.Join(t.TempDir(), "termination-log")
+	if s.port == "" {
+		s.port = freePort(t)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- s.run(ctx) }()
+
+	var (
+		stopped bool
+		err     error
+	)
+	stop := func() error {
+		if !stopped {
+			cancel()
+			err, stopped = <-result, true
+		}
+		return err
+	}
+	t.Cleanup(func() { stop() }) //nolint:errcheck
+	return stop
+}
+
+func dial(t *testing.T, port string) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient("127.0.0.1:"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func servingStatus(conn *grpc.ClientConn, service string) (health.HealthCheckResponse_ServingStatus, error) {
+	resp, err := health.NewHealthClient(conn).Check(context.Background(), &health.HealthCheckRequest{Service: service})
+	if err != nil {
+		return health.HealthCheckResponse_UNKNOWN, err
+	}
+	return resp.GetStatus(), nil
+}
+
+func requireEventuallyServingStatus(t *testing.T, conn *grpc.ClientConn, service string, expected health.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		actual, err := servingStatus(conn, service)
+		return err == nil && actual == expected
+	}, 10*time.Second, 10*time.Millisecond, "service %q is not %s", service, expected)
+}
+
+// listPackages lists the packages served on conn. If catalog is not empty,
+// the request is routed to the catalog.
+func listPackages(conn *grpc.ClientConn, catalog string) ([]string, error) {
+	ctx := context.Background()
+	if catalog != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, server.CatalogMetadataKey, catalog)
+	}
+	stream, err := api.NewRegistryClient(conn).ListPackages(ctx, &api.ListPackageRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		pkg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, pkg.GetName())
+	}
+}
+
+func TestServe_MultipleCatalogs(t *testing.T) {
+	root := t.TempDir()
+	fooDir, barDir, brokenDir := filepath.Join(root, "foo"), filepath.Join(root, "bar"), filepath.Join(root, "broken")
+	writeCatalog(t, fooDir, "foo", "0.1.0")
+	writeCatalog(t, barDir, "bar", "0.1.0")
+	require.NoError(t, os.MkdirAll(brokenDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(brokenDir, "catalog.json"), []byte(`{"schema":"olm.channel","package":"missing","name":"stable"}`), 0644))
+
+	barPort := freePort(t)
+	s := &serve{
+		namedCatalogs: map[string]string{"foo": fooDir, "bar": barDir, "broken": brokenDir},
+		catalogPorts:  map[string]string{"bar": barPort},
+	}
+	stop := startServe(t, s)
+	conn := dial(t, s.port)
+
+	// The registry is serving once the catalogs that can be loaded have been
+	// loaded, while the catalog that cannot be loaded is not serving.
+	for _, service := range registryHealthServices {
+		requireEventuallyServingStatus(t, conn, service, health.HealthCheckResponse_SERVING)
+	}
+	for service, expected := range map[string]health.HealthCheckResponse_ServingStatus{
+		"foo":    health.HealthCheckResponse_SERVING,
+		"bar":    health.HealthCheckResponse_SERVING,
+		"broken": health.HealthCheckResponse_NOT_SERVING,
+	} {
+		actual, err := servingStatus(conn, service)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, service)
+	}
+
+	t.Run("Routing", func(t *testing.T) {
+		names, err := listPackages(conn, "foo")
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo"}, names)
+
+		names, err = listPackages(conn, "bar")
+		require.NoError(t, err)
+		require.Equal(t, []string{"bar"}, names)
+	})
+
+	t.Run("Routing/NoCatalogMetadata", func(t *testing.T) {
+		_, err := listPackages(conn, "")
+		require.Equal(t, codes.InvalidArgument, status.Code(err), err)
+	})
+
+	t.Run("Routing/UnknownCatalog", func(t *testing.T) {
+		_, err := listPackages(conn, "baz")
+		require.Equal(t, codes.NotFound, status.Code(err), err)
+	})
+
+	t.Run("Routing/NotLoaded", func(t *testing.T) {
+		_, err := listPackages(conn, "broken")
+		require.Equal(t, codes.Unavailable, status.Code(err), err)
+	})
+
+	t.Run("DedicatedPort", func(t *testing.T) {
+		names, err := listPackages(dial(t, barPort), "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"bar"}, names)
+	})
+
+	require.NoError(t, stop())
+}
+
+func TestServe_NoCatalogLoaded(t *testing.T) {
+	brokenDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(brokenDir, "catalog.json"), []byte(`{"schema":"olm.channel","package":"missing","name":"stable"}`), 0644))
+
+	s := &serve{
+		namedCatalogs:  map[string]string{"broken": brokenDir},
+		port:           freePort(t),
+		terminationLog: filepath.Join(t.TempDir(), "termination-log"),
+		logger:         discardLogger(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.ErrorContains(t, s.run(ctx), `catalog "broken"`)
+}
+
+func TestServe_Watch(t *testing.T) {
+	configDir := t.TempDir()
+	writeCatalog(t, configDir, "foo", "0.1.0")
+
+	s := &serve{
+		configDir:     configDir,
+		watch:         true,
+		watchInterval: 10 * time.Millisecond,
+	}
+	startServe(t, s)
+	conn := dial(t, s.port)
+	requireEventuallyServingStatus(t, conn, "", health.HealthCheckResponse_SERVING)
+
+	names, err := listPackages(conn, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo"}, names)
+
+	// A change to the config directory is served once the cache is rebuilt.
+	writeCatalog(t, configDir, "bar", "0.1.0")
+	require.Eventually(t, func() bool {
+		names, err := listPackages(conn, "")
+		return err == nil && len(names) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// If the cache cannot be rebuilt, the registry is reported as not
+	// serving, but the previous cache continues to be served.
+	broken := filepath.Join(configDir, "broken.json")
+	require.NoError(t, os.WriteFile(broken, []byte(`{"schema":"olm.channel","package":"missing","name":"stable"}`), 0644))
+	requireEventuallyServingStatus(t, conn, "", health.HealthCheckResponse_NOT_SERVING)
+	names, err = listPackages(conn, "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"foo", "bar"}, names)
+
+	// Once the cache can be rebuilt, the registry is serving again.
+	require.NoError(t, os.Remove(broken))
+	requireEventuallyServingStatus(t, conn, "", health.HealthCheckResponse_SERVING)
+}
+
+func TestServe_WatchPersistsCache(t *testing.T) {
+	configDir, cacheDir := t.TempDir(), filepath.Join(t.TempDir(), "cache")
+	writeCatalog(t, configDir, "foo", "0.1.0")
+
+	// A cache that was built by the watcher of a previous server is removed.
+	leftover := filepath.Join(cacheDir, reloadDirPrefix+"leftover")
+	require.NoError(t, os.MkdirAll(leftover, 0755))
+
+	s := &serve{
+		configDir:     configDir,
+		cacheDir:      cacheDir,
+		watch:         true,
+		watchInterval: 10 * time.Millisecond,
+	}
+	stop := startServe(t, s)
+	conn := dial(t, s.port)
+	requireEventuallyServingStatus(t, conn, "", health.HealthCheckResponse_SERVING)
+	_, err := os.Stat(leftover)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// The rebuilt cache is built in the cache directory, and written back
+	// to it once the cache that it replaced is no longer served.
+	writeCatalog(t, configDir, "bar", "0.1.0")
+	require.Eventually(t, func() bool {
+		names, err := listPackages(conn, "")
+		return err == nil && len(names) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	entries, err := os.ReadDir(filepath.Dir(cacheDir))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Eventually(t, func() bool {
+		digests, err := os.ReadFile(filepath.Join(cacheDir, cache.FormatPogrebV1, "package-digests.json"))
+		return err == nil && strings.Contains(string(digests), `"bar"`)
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, stop())
+
+	// The served cache is removed when the server stops, and the server
+	// restarts from the rebuilt cache, even if integrity is enforced.
+	reloadDirs, err := filepath.Glob(filepath.Join(cacheDir, reloadDirPrefix+"*"))
+	require.NoError(t, err)
+	require.Empty(t, reloadDirs)
+	s = &serve{
+		configDir:             configDir,
+		cacheDir:              cacheDir,
+		cacheEnforceIntegrity: true,
+	}
+	startServe(t, s)
+	conn = dial(t, s.port)
+	requireEventuallyServingStatus(t, conn, "", health.HealthCheckResponse_SERVING)
+	names, err := listPackages(conn, "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"foo", "bar"}, names)
+}
+
+func TestServe_StartupHealth(t *testing.T) {
+	root := t.TempDir()
+	fooDir, brokenDir := filepath.Join(root, "foo"), filepath.Join(root, "broken")
+	writeCatalog(t, fooDir, "foo", "0.1.0")
+	require.NoError(t, os.MkdirAll(brokenDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(brokenDir, "catalog.json"), []byte(`{`), 0644))
+
+	check := func(t *testing.T, hs *server.HealthServer, expected map[string]health.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		for service, expectedStatus := range expected {
+			resp, err := hs.Check(context.Background(), &health.HealthCheckRequest{Service: service})
+			require.NoError(t, err)
+			require.Equal(t, expectedStatus, resp.GetStatus(), fmt.Sprintf("service %q", service))
+		}
+	}
+	const (
+		notServing = health.HealthCheckResponse_NOT_SERVING
+		serving    = health.HealthCheckResponse_SERVING
+	)
+
+	t.Run("SingleCatalog", func(t *testing.T) {
+		s := &serve{configDir: fooDir}
+		catalogs, err := s.catalogs(t.TempDir())
+		require.NoError(t, err)
+		defer catalogs[0].store.Close()
+
+		hs := newHealthServer(catalogs)
+		check(t, hs, map[string]health.HealthCheckResponse_ServingStatus{"": notServing, server.RegistryHealthService: notServing, server.APIRegistryHealthService: notServing})
+		_, err = catalogs[0].store.ListPackages(context.Background())
+		require.Equal(t, codes.Unavailable, status.Code(err))
+
+		require.NoError(t, s.loadCatalogs(context.Background(), catalogs, hs, nil, discardLogger()))
+		check(t, hs, map[string]health.HealthCheckResponse_ServingStatus{"": serving, server.RegistryHealthService: serving, server.APIRegistryHealthService: serving})
+		names, err := catalogs[0].store.ListPackages(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo"}, names)
+	})
+
+	t.Run("MultipleCatalogs", func(t *testing.T) {
+		s := &serve{namedCatalogs: map[string]string{"foo": fooDir, "broken": brokenDir}}
+		catalogs, err := s.catalogs(t.TempDir())
+		require.NoError(t, err)
+		for _, c := range catalogs {
+			defer c.store.Close()
+		}
+
+		hs := newHealthServer(catalogs)
+		check(t, hs, map[string]health.HealthCheckResponse_ServingStatus{"": notServing, "foo": notServing, "broken": notServing})
+
+		require.NoError(t, s.loadCatalogs(context.Background(), catalogs, hs, nil, discardLogger()))
+		check(t, hs, map[string]health.HealthCheckResponse_ServingStatus{"": serving, "foo": serving, "broken": notServing})
+	})
+
+	t.Run("MultipleCatalogs/CacheOnly", func(t *testing.T) {
+		s := &serve{namedCatalogs: map[string]string{"foo": fooDir, "broken": brokenDir}, cacheOnly: true}
+		catalogs, err := s.catalogs(t.TempDir())
+		require.NoError(t, err)
+		for _, c := range catalogs {
+			defer c.store.Close()
+		}
+
+		hs := newHealthServer(catalogs)
+		require.ErrorContains(t, s.loadCatalogs(context.Background(), catalogs, hs, nil, discardLogger()), `catalog "broken"`)
+		check(t, hs, map[string]health.HealthCheckResponse_ServingStatus{"": notServing})
+	})
+}
@@ -0,0 +1,201 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+	"github.com/operator-framework/operator-registry/pkg/server"
+)
+
+// reloadDirPrefix is the prefix of the directories in the cache directory in
+// which the watcher builds the caches that it serves.
+const reloadDirPrefix = ".reload-"
+
+// watcher polls the config directory for changes. When a change is detected,
+// it builds a new cache in a directory in the cache directory and swaps it
+// into the store of the catalog. If the new cache cannot be built, the live
+// cache continues to be served, but the catalog is reported as not serving
+// until a rebuild succeeds.
+//
+// The cache that was loaded at startup is served from the cache directory
+// itself, so the cache directory cannot be rebuilt in place while it is
+// served. Once the cache that it replaced is no longer in use, the cache
+// directory is rebuilt too, if persist is set, so that the cache is up to
+// date when the server restarts.
+type watcher struct {
+	catalog  *catalog
+	interval time.Duration
+	persist  bool
+	health   *server.HealthServer
+	metrics  *metrics
+	logger   *logrus.Entry
+}
+
+func (w *watcher) run(ctx context.Context) {
+	last, err := fingerprint(os.DirFS(w.catalog.configDir))
+	if err != nil {
+		w.logger.WithError(err).Warn("unable to read config directory")
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := fingerprint(os.DirFS(w.catalog.configDir))
+		if err != nil {
+			w.logger.WithError(err).Warn("unable to read config directory")
+			continue
+		}
+		if current == last {
+			continue
+		}
+		last = current
+
+		w.logger.Info("config directory changed, rebuilding cache")
+		start := time.Now()
+		if err := w.rebuild(ctx); err != nil {
+			w.logger.WithError(err).Error("failed to rebuild cache, continuing to serve previous cache")
+			w.catalog.setServingStatus(w.health, health.HealthCheckResponse_NOT_SERVING)
+			continue
+		}
+		w.catalog.setServingStatus(w.health, health.HealthCheckResponse_SERVING)
+		w.logger.WithField("duration", time.Since(start)).Info("serving rebuilt cache")
+	}
+}
+
+func (w *watcher) rebuild(ctx context.Context) error {
+	fbc := os.DirFS(w.catalog.configDir)
+	dir, err := os.MkdirTemp(w.catalog.cacheDir, reloadDirPrefix+"*")
+	if err != nil {
+		return err
+	}
+	c, err := cache.New(dir, cache.WithLog(w.logger))
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	// The directory is new, so the cache is always built rather than checked
+	// for integrity first.
+	instrumented := w.metrics.instrument(c, w.catalog.name)
+	if err := instrumented.Build(ctx, fbc); err != nil {
+		c.Close()
+		os.RemoveAll(dir)
+		return err
+	}
+	if err := instrumented.Load(ctx); err != nil {
+		c.Close()
+		os.RemoveAll(dir)
+		return err
+	}
+
+	closed := w.catalog.store.Swap(&removeOnCloseCache{Cache: c, dir: dir})
+	if !w.persist {
+		go func() {
+			if err := <-closed; err != nil {
+				w.logger.WithError(err).Warn("failed to close previous cache")
+			}
+		}()
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-closed:
+		if err != nil {
+			w.logger.WithError(err).Warn("failed to close previous cache")
+		}
+	}
+	if err := w.persistCache(ctx, fbc); err != nil {
+		w.logger.WithError(err).Warn("failed to update cache directory, the previous cache will be loaded on restart")
+	}
+	return nil
+}
+
+// persistCache rebuilds the cache in the cache directory from fbc. It must
+// only be called once the cache directory is no longer served.
+func (w *watcher) persistCache(ctx context.Context, fbc fs.FS) error {
+	c, err := cache.New(w.catalog.cacheDir, cache.WithLog(w.logger))
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return c.Build(ctx, fbc)
+}
+
+// removeReloadDirs removes the directories in cacheDir in which the caches
+// served by a previous watcher were built.
+func removeReloadDirs(cacheDir string, logger *logrus.Entry) error {
+	dirs, err := filepath.Glob(filepath.Join(cacheDir, reloadDirPrefix+"*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		logger.WithField("dir", dir).Warn("removed cache left by an interrupted watcher")
+	}
+	return nil
+}
+
+// removeOnCloseCache is a cache that removes its directory when it is
+// closed. It is used for the caches that are rebuilt by the watcher, so that
+// they are cleaned up when they are replaced.
+type removeOnCloseCache struct {
+	cache.Cache
+	dir string
+}
+
+func (c *removeOnCloseCache) SendFilteredBundles(ctx context.Context, stream registry.BundleSender, filter registry.BundleFilter) error {
+	return registry.SendFilteredBundles(ctx, c.Cache, stream, filter)
+}
+
+func (c *removeOnCloseCache) SendMetas(ctx context.Context, filter cache.MetaFilter, send func(*declcfg.Meta) error) error {
+	return cache.SendMetas(ctx, c.Cache, filter, send)
+}
+
+func (c *removeOnCloseCache) Close() error {
+	err := c.Cache.Close()
+	if rerr := os.RemoveAll(c.dir); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// fingerprint summarizes the names, sizes, modes and modification times of
+// the files in fsys, which is much cheaper than computing the cache digest
+// and detects any change to the served content.
+func fingerprint(fsys fs.FS) (string, error) {
+	h := fnv.New64a()
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(h, "%s\x00%d\x00%s\x00%d\n", path, info.Size(), info.Mode(), info.ModTime().UnixNano())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -1,23 +1,38 @@
 package validate
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/operator-framework/operator-registry/alpha/model"
 	"github.com/operator-framework/operator-registry/pkg/lib/config"
 )
 
 func NewCmd() *cobra.Command {
 	logger := logrus.New()
+	var output string
 	validate := &cobra.Command{
 		Use:   "validate <directory>",
 		Short: "Validate the declarative index config",
-		Long:  "Validate the declarative config JSON file(s) in a given directory",
-		Args:  cobra.ExactArgs(1),
+		Long: `Validate the declarative config JSON file(s) in a given directory
+
+With "-o json", the result is printed as a JSON object with a list of errors.
+Each error has the path (package, channel and bundle) of the invalid object,
+a stable error code, a message, and the location of the object in the
+directory if it is known. Errors that are not reported by the validation of
+packages, channels and bundles, e.g. errors while loading the files, have the
+code "Unknown".`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(c *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid --output value %q, expected (text|json)", output)
+			}
+
 			directory := args[0]
 			s, err := os.Stat(directory)
 			if err != nil {
@@ -27,12 +42,39 @@ func NewCmd() *cobra.Command {
 				return fmt.Errorf("%q is not a directory", directory)
 			}
 
-			if err := config.Validate(c.Context(), os.DirFS(directory)); err != nil {
+			err = config.Validate(c.Context(), os.DirFS(directory))
+			if output == "json" {
+				if werr := writeJSON(os.Stdout, err); werr != nil {
+					logger.Fatal(werr)
+				}
+				if err != nil {
+					os.Exit(1)
+				}
+				return nil
+			}
+			if err != nil {
 				logger.Fatal(err)
 			}
 			return nil
 		},
 	}
+	validate.Flags().StringVarP(&output, "output", "o", "text", "Output format (text|json)")
 
 	return validate
 }
+
+type result struct {
+	Valid  bool                     `json:"valid"`
+	Errors []*model.ValidationError `json:"errors"`
+}
+
+func writeJSON(w io.Writer, err error) error {
+	res := result{Valid: err == nil, Errors: model.ValidationErrors(err)}
+	if res.Errors == nil {
+		res.Errors = []*model.ValidationError{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(res)
+}
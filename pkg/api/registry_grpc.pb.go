@@ -29,6 +29,12 @@ const (
 	Registry_GetLatestChannelEntriesThatProvide_FullMethodName = "/api.Registry/GetLatestChannelEntriesThatProvide"
 	Registry_GetDefaultBundleThatProvides_FullMethodName       = "/api.Registry/GetDefaultBundleThatProvides"
 	Registry_ListBundles_FullMethodName                        = "/api.Registry/ListBundles"
+	Registry_ListChannelEntries_FullMethodName                 = "/api.Registry/ListChannelEntries"
+	Registry_GetDeprecations_FullMethodName                    = "/api.Registry/GetDeprecations"
+	Registry_GetCatalogInfo_FullMethodName                     = "/api.Registry/GetCatalogInfo"
+	Registry_GetBundlesInRange_FullMethodName                  = "/api.Registry/GetBundlesInRange"
+	Registry_GetBundleByImage_FullMethodName                   = "/api.Registry/GetBundleByImage"
+	Registry_FindBundles_FullMethodName                        = "/api.Registry/FindBundles"
 )
 
 // RegistryClient is the client API for Registry service.
@@ -46,6 +52,12 @@ type RegistryClient interface {
 	GetLatestChannelEntriesThatProvide(ctx context.Context, in *GetLatestProvidersRequest, opts ...grpc.CallOption) (Registry_GetLatestChannelEntriesThatProvideClient, error)
 	GetDefaultBundleThatProvides(ctx context.Context, in *GetDefaultProviderRequest, opts ...grpc.CallOption) (*Bundle, error)
 	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (Registry_ListBundlesClient, error)
+	ListChannelEntries(ctx context.Context, in *ListChannelEntriesRequest, opts ...grpc.CallOption) (Registry_ListChannelEntriesClient, error)
+	GetDeprecations(ctx context.Context, in *GetDeprecationsRequest, opts ...grpc.CallOption) (*Deprecations, error)
+	GetCatalogInfo(ctx context.Context, in *GetCatalogInfoRequest, opts ...grpc.CallOption) (*CatalogInfo, error)
+	GetBundlesInRange(ctx context.Context, in *GetBundlesInRangeRequest, opts ...grpc.CallOption) (Registry_GetBundlesInRangeClient, error)
+	GetBundleByImage(ctx context.Context, in *GetBundleByImageRequest, opts ...grpc.CallOption) (*Bundle, error)
+	FindBundles(ctx context.Context, in *FindBundlesRequest, opts ...grpc.CallOption) (Registry_FindBundlesClient, error)
 }
 
 type registryClient struct {
@@ -262,6 +274,129 @@ func (x *registryListBundlesClient) Recv() (*Bundle, error) {
 	return m, nil
 }
 
+func (c *registryClient) ListChannelEntries(ctx context.Context, in *ListChannelEntriesRequest, opts ...grpc.CallOption) (Registry_ListChannelEntriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Registry_ServiceDesc.Streams[5], Registry_ListChannelEntries_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryListChannelEntriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_ListChannelEntriesClient interface {
+	Recv() (*ChannelEntry, error)
+	grpc.ClientStream
+}
+
+type registryListChannelEntriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryListChannelEntriesClient) Recv() (*ChannelEntry, error) {
+	m := new(ChannelEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registryClient) GetDeprecations(ctx context.Context, in *GetDeprecationsRequest, opts ...grpc.CallOption) (*Deprecations, error) {
+	out := new(Deprecations)
+	err := c.cc.Invoke(ctx, Registry_GetDeprecations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) GetCatalogInfo(ctx context.Context, in *GetCatalogInfoRequest, opts ...grpc.CallOption) (*CatalogInfo, error) {
+	out := new(CatalogInfo)
+	err := c.cc.Invoke(ctx, Registry_GetCatalogInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) GetBundlesInRange(ctx context.Context, in *GetBundlesInRangeRequest, opts ...grpc.CallOption) (Registry_GetBundlesInRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Registry_ServiceDesc.Streams[6], Registry_GetBundlesInRange_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryGetBundlesInRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_GetBundlesInRangeClient interface {
+	Recv() (*Bundle, error)
+	grpc.ClientStream
+}
+
+type registryGetBundlesInRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryGetBundlesInRangeClient) Recv() (*Bundle, error) {
+	m := new(Bundle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registryClient) GetBundleByImage(ctx context.Context, in *GetBundleByImageRequest, opts ...grpc.CallOption) (*Bundle, error) {
+	out := new(Bundle)
+	err := c.cc.Invoke(ctx, Registry_GetBundleByImage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) FindBundles(ctx context.Context, in *FindBundlesRequest, opts ...grpc.CallOption) (Registry_FindBundlesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Registry_ServiceDesc.Streams[7], Registry_FindBundles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryFindBundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_FindBundlesClient interface {
+	Recv() (*BundleKey, error)
+	grpc.ClientStream
+}
+
+type registryFindBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryFindBundlesClient) Recv() (*BundleKey, error) {
+	m := new(BundleKey)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // RegistryServer is the server API for Registry service.
 // All implementations must embed UnimplementedRegistryServer
 // for forward compatibility
@@ -277,6 +412,12 @@ type RegistryServer interface {
 	GetLatestChannelEntriesThatProvide(*GetLatestProvidersRequest, Registry_GetLatestChannelEntriesThatProvideServer) error
 	GetDefaultBundleThatProvides(context.Context, *GetDefaultProviderRequest) (*Bundle, error)
 	ListBundles(*ListBundlesRequest, Registry_ListBundlesServer) error
+	ListChannelEntries(*ListChannelEntriesRequest, Registry_ListChannelEntriesServer) error
+	GetDeprecations(context.Context, *GetDeprecationsRequest) (*Deprecations, error)
+	GetCatalogInfo(context.Context, *GetCatalogInfoRequest) (*CatalogInfo, error)
+	GetBundlesInRange(*GetBundlesInRangeRequest, Registry_GetBundlesInRangeServer) error
+	GetBundleByImage(context.Context, *GetBundleByImageRequest) (*Bundle, error)
+	FindBundles(*FindBundlesRequest, Registry_FindBundlesServer) error
 	mustEmbedUnimplementedRegistryServer()
 }
 
@@ -314,6 +455,24 @@ func (UnimplementedRegistryServer) GetDefaultBundleThatProvides(context.Context,
 func (UnimplementedRegistryServer) ListBundles(*ListBundlesRequest, Registry_ListBundlesServer) error {
 	return status.Errorf(codes.Unimplemented, "method ListBundles not implemented")
 }
+func (UnimplementedRegistryServer) ListChannelEntries(*ListChannelEntriesRequest, Registry_ListChannelEntriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListChannelEntries not implemented")
+}
+func (UnimplementedRegistryServer) GetDeprecations(context.Context, *GetDeprecationsRequest) (*Deprecations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeprecations not implemented")
+}
+func (UnimplementedRegistryServer) GetCatalogInfo(context.Context, *GetCatalogInfoRequest) (*CatalogInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCatalogInfo not implemented")
+}
+func (UnimplementedRegistryServer) GetBundlesInRange(*GetBundlesInRangeRequest, Registry_GetBundlesInRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBundlesInRange not implemented")
+}
+func (UnimplementedRegistryServer) GetBundleByImage(context.Context, *GetBundleByImageRequest) (*Bundle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBundleByImage not implemented")
+}
+func (UnimplementedRegistryServer) FindBundles(*FindBundlesRequest, Registry_FindBundlesServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindBundles not implemented")
+}
 func (UnimplementedRegistryServer) mustEmbedUnimplementedRegistryServer() {}
 
 // UnsafeRegistryServer may be embedded to opt out of forward compatibility for this service.
@@ -522,6 +681,123 @@ func (x *registryListBundlesServer) Send(m *Bundle) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Registry_ListChannelEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListChannelEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).ListChannelEntries(m, &registryListChannelEntriesServer{stream})
+}
+
+type Registry_ListChannelEntriesServer interface {
+	Send(*ChannelEntry) error
+	grpc.ServerStream
+}
+
+type registryListChannelEntriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryListChannelEntriesServer) Send(m *ChannelEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Registry_GetDeprecations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeprecationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).GetDeprecations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Registry_GetDeprecations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).GetDeprecations(ctx, req.(*GetDeprecationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_GetCatalogInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCatalogInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).GetCatalogInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Registry_GetCatalogInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).GetCatalogInfo(ctx, req.(*GetCatalogInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_GetBundlesInRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBundlesInRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).GetBundlesInRange(m, &registryGetBundlesInRangeServer{stream})
+}
+
+type Registry_GetBundlesInRangeServer interface {
+	Send(*Bundle) error
+	grpc.ServerStream
+}
+
+type registryGetBundlesInRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryGetBundlesInRangeServer) Send(m *Bundle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Registry_GetBundleByImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBundleByImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).GetBundleByImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Registry_GetBundleByImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).GetBundleByImage(ctx, req.(*GetBundleByImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_FindBundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindBundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).FindBundles(m, &registryFindBundlesServer{stream})
+}
+
+type Registry_FindBundlesServer interface {
+	Send(*BundleKey) error
+	grpc.ServerStream
+}
+
+type registryFindBundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryFindBundlesServer) Send(m *BundleKey) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Registry_ServiceDesc is the grpc.ServiceDesc for Registry service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -549,6 +825,18 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDefaultBundleThatProvides",
 			Handler:    _Registry_GetDefaultBundleThatProvides_Handler,
 		},
+		{
+			MethodName: "GetDeprecations",
+			Handler:    _Registry_GetDeprecations_Handler,
+		},
+		{
+			MethodName: "GetCatalogInfo",
+			Handler:    _Registry_GetCatalogInfo_Handler,
+		},
+		{
+			MethodName: "GetBundleByImage",
+			Handler:    _Registry_GetBundleByImage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -576,6 +864,21 @@ var Registry_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Registry_ListBundles_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListChannelEntries",
+			Handler:       _Registry_ListChannelEntries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetBundlesInRange",
+			Handler:       _Registry_GetBundlesInRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindBundles",
+			Handler:       _Registry_FindBundles_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "registry.proto",
 }
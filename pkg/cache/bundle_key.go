@@ -32,12 +32,17 @@ func (b bundleKeys) Set(k bundleKey) {
 	b.t.Set(k)
 }
 
+func (b bundleKeys) Delete(k bundleKey) {
+	b.t.Delete(k)
+}
+
 func (b bundleKeys) Len() int {
 	return b.t.Len()
 }
 
 func (b bundleKeys) Walk(f func(k bundleKey) error) error {
 	it := b.t.Iter()
+	defer it.Release()
 	for it.Next() {
 		if err := f(it.Item()); err != nil {
 			return err
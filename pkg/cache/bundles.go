@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+// bundleInfos holds, for each package, the versions, images and property
+// types of its bundles. The package index does not record them, so they are collected
+// from the bundles when the cache is built, and are stored next to the cache
+// rather than in it, so that they do not change the cache digest.
+type bundleInfos map[string][]bundleInfo
+
+// bundleInfo describes a bundle of a package. A bundle that is in more than
+// one channel is described once, under the default channel of its package if
+// it is in it, and under the first of its channels by name otherwise.
+type bundleInfo struct {
+	Name    string `json:"name"`
+	Channel string `json:"channel"`
+	Version string `json:"version,omitempty"`
+	Image   string `json:"image,omitempty"`
+	// PropertyTypes holds the sorted, unique types of the properties of
+	// the bundle.
+	PropertyTypes []string `json:"propertyTypes,omitempty"`
+}
+
+func newBundleInfo(key bundleKey, b *api.Bundle) bundleInfo {
+	types := make([]string, 0, len(b.GetProperties()))
+	for _, p := range b.GetProperties() {
+		types = append(types, p.GetType())
+	}
+	slices.Sort(types)
+	return bundleInfo{
+		Name:          key.Name,
+		Channel:       key.ChannelName,
+		Version:       b.GetVersion(),
+		Image:         b.GetBundlePath(),
+		PropertyTypes: slices.Compact(types),
+	}
+}
+
+// bundleInfoKeys returns the key under which each bundle of pkg is
+// described, in order of bundle name.
+func bundleInfoKeys(pkg cPkg) []bundleKey {
+	keys := map[string]bundleKey{}
+	for _, ch := range pkg.Channels {
+		for _, b := range ch.Bundles {
+			key, ok := keys[b.Name]
+			if !ok || ch.Name == pkg.DefaultChannel || (key.ChannelName != pkg.DefaultChannel && ch.Name < key.ChannelName) {
+				keys[b.Name] = bundleKey{pkg.Name, ch.Name, b.Name}
+			}
+		}
+	}
+	sorted := make([]bundleKey, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// indexesFromBundles collects the API plurals and the bundle infos of pkgs
+// by reading each of their bundles once. It is used for caches that were
+// built without them.
+func indexesFromBundles(ctx context.Context, pkgs packageIndex, getBundle getBundleFunc) (apiPlurals, bundleInfos, error) {
+	plurals, infos := apiPlurals{}, bundleInfos{}
+	for _, pkg := range pkgs {
+		keys := bundleInfoKeys(pkg)
+		pkgPlurals := pluralSet{}
+		pkgInfos := make([]bundleInfo, 0, len(keys))
+		for _, key := range keys {
+			b, err := getBundle(ctx, key)
+			if err != nil {
+				return nil, nil, fmt.Errorf("get bundle %q: %v", key.Name, err)
+			}
+			pkgPlurals.addBundle(b)
+			pkgInfos = append(pkgInfos, newBundleInfo(key, b))
+		}
+		plurals[pkg.Name] = pkgPlurals.sorted()
+		infos[pkg.Name] = pkgInfos
+	}
+	return plurals, infos, nil
+}
+
+// bundleIndex looks up the bundles of a cache by version, by image and by
+// property type.
+type bundleIndex struct {
+	// versions holds the bundles of each package, highest version first.
+	versions map[string][]versionedBundle
+	// images maps bundle images, and the digests of digest-pinned bundle
+	// images, to bundles.
+	images map[string]bundleKey
+	// propertyTypes maps property types to the bundles with properties of
+	// that type.
+	propertyTypes map[string][]bundleKey
+}
+
+type versionedBundle struct {
+	key     bundleKey
+	version semver.Version
+}
+
+// index builds the bundleIndex of infos. Bundles whose versions cannot be
+// parsed are not indexed by version. If bundles share an image, the image is
+// indexed to the first of them by package name and then by bundle name.
+func (infos bundleInfos) index() bundleIndex {
+	idx := bundleIndex{
+		versions:      map[string][]versionedBundle{},
+		images:        map[string]bundleKey{},
+		propertyTypes: map[string][]bundleKey{},
+	}
+	pkgNames := make([]string, 0, len(infos))
+	for pkgName := range infos {
+		pkgNames = append(pkgNames, pkgName)
+	}
+	sort.Strings(pkgNames)
+
+	addImage := func(image string, key bundleKey) {
+		if _, ok := idx.images[image]; !ok {
+			idx.images[image] = key
+		}
+	}
+	for _, pkgName := range pkgNames {
+		pkgInfos := slices.Clone(infos[pkgName])
+		slices.SortFunc(pkgInfos, func(a, b bundleInfo) int { return strings.Compare(a.Name, b.Name) })
+		versions := make([]versionedBundle, 0, len(pkgInfos))
+		for _, info := range pkgInfos {
+			key := bundleKey{pkgName, info.Channel, info.Name}
+			if v, err := semver.Parse(info.Version); err == nil {
+				versions = append(versions, versionedBundle{key: key, version: v})
+			}
+			if info.Image != "" {
+				addImage(info.Image, key)
+				if _, digest, ok := strings.Cut(info.Image, "@"); ok {
+					addImage(digest, key)
+				}
+			}
+			for _, t := range info.PropertyTypes {
+				idx.propertyTypes[t] = append(idx.propertyTypes[t], key)
+			}
+		}
+		sort.SliceStable(versions, func(i, j int) bool {
+			return versions[i].version.Compare(versions[j].version) > 0
+		})
+		idx.versions[pkgName] = versions
+	}
+	return idx
+}
+
+// GetBundlesInRange returns the bundles of a package whose versions are in
+// versionRange, highest version first.
+func (idx bundleIndex) GetBundlesInRange(ctx context.Context, getBundle getBundleFunc, pkgName, versionRange string) ([]*api.Bundle, error) {
+	versions, ok := idx.versions[pkgName]
+	if !ok {
+		return nil, fmt.Errorf("package %q %w", pkgName, ErrNotFound)
+	}
+	inRange, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %v", versionRange, err)
+	}
+
+	var bundles []*api.Bundle
+	for _, vb := range versions {
+		if !inRange(vb.version) {
+			continue
+		}
+		b, err := getBundle(ctx, vb.key)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, b)
+	}
+	return bundles, nil
+}
+
+// GetBundleByImage returns the bundle whose image is image. The image may
+// also be given as the digest of a digest-pinned bundle image.
+func (idx bundleIndex) GetBundleByImage(ctx context.Context, getBundle getBundleFunc, image string) (*api.Bundle, error) {
+	key, ok := idx.images[image]
+	if !ok {
+		return nil, fmt.Errorf("bundle with image %q %w", image, ErrNotFound)
+	}
+	return getBundle(ctx, key)
+}
+
+// FindBundles returns the keys of the bundles with a property that matches
+// query, with a key for each channel of pkgs that a bundle is in. Only the
+// bundles with a property of the queried type are read, and only if the
+// query has a path.
+func (idx bundleIndex) FindBundles(ctx context.Context, pkgs packageIndex, getBundle getBundleFunc, query registry.PropertyQuery) ([]*api.BundleKey, error) {
+	var keys []*api.BundleKey
+	for _, key := range idx.propertyTypes[query.Type] {
+		if query.Path != "" {
+			b, err := getBundle(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			match, err := query.Matches(b.Properties)
+			if err != nil {
+				return nil, fmt.Errorf("package %q, bundle %q: %v", key.PackageName, key.Name, err)
+			}
+			if !match {
+				continue
+			}
+		}
+		for _, ch := range pkgs[key.PackageName].Channels {
+			if _, ok := ch.Bundles[key.Name]; ok {
+				keys = append(keys, &api.BundleKey{PackageName: key.PackageName, ChannelName: ch.Name, CsvName: key.Name})
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].PackageName != keys[j].PackageName {
+			return keys[i].PackageName < keys[j].PackageName
+		}
+		if keys[i].ChannelName != keys[j].ChannelName {
+			return keys[i].ChannelName < keys[j].ChannelName
+		}
+		return keys[i].CsvName < keys[j].CsvName
+	})
+	return keys, nil
+}
+
+// readBundleInfosFile returns the bundle infos stored in file, or nil if file
+// does not exist.
+func readBundleInfosFile(file string) (bundleInfos, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var infos bundleInfos
+	if err := json.Unmarshal(data, &infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// writeBundleInfosFile stores infos in file, or removes file if infos is nil.
+func writeBundleInfosFile(file string, infos bundleInfos, mode os.FileMode) error {
+	if infos == nil {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(infos)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, mode)
+}
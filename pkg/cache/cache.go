@@ -1,19 +1,26 @@
 package cache
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
 	"github.com/operator-framework/operator-registry/pkg/api"
@@ -21,6 +28,10 @@ import (
 	"github.com/operator-framework/operator-registry/pkg/registry"
 )
 
+// ErrNotFound is returned by queries for a package, channel or bundle that
+// is not in the cache.
+var ErrNotFound = errors.New("not found")
+
 type Cache interface {
 	registry.GRPCQuery
 
@@ -30,9 +41,32 @@ type Cache interface {
 	Close() error
 }
 
+// StatsReporter is implemented by the caches returned by New, which can
+// describe their content once they are loaded.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// Stats describes the content of a loaded cache.
+type Stats struct {
+	// Backend is the name of the backend that stores the cache.
+	Backend string
+	// Packages is the number of packages in the cache.
+	Packages int
+	// Bundles is the number of distinct bundles in the cache. A bundle that
+	// is in more than one channel of a package is counted once.
+	Bundles int
+}
+
 type backend interface {
 	Name() string
 	IsCachePresent() bool
+	// Dir returns the cache directory of the backend.
+	Dir() string
+	// Entries returns the names of the files and directories in the cache
+	// directory that store the cache, in the order in which they are
+	// replaced by a build. The last entry marks the cache as complete.
+	Entries() []string
 
 	Init() error
 	Open() error
@@ -41,13 +75,55 @@ type backend interface {
 	GetPackageIndex(context.Context) (packageIndex, error)
 	PutPackageIndex(context.Context, packageIndex) error
 
-	SendBundles(context.Context, registry.BundleSender) error
+	// SendBundles sends the bundles whose keys are selected, in key order.
+	// Bundles that are not selected are not read.
+	SendBundles(context.Context, registry.BundleSender, func(bundleKey) bool) error
 	GetBundle(context.Context, bundleKey) (*api.Bundle, error)
 	PutBundle(context.Context, bundleKey, *api.Bundle) error
+	DeleteBundle(context.Context, bundleKey) error
 
 	GetDigest(context.Context) (string, error)
 	ComputeDigest(context.Context, fs.FS) (string, error)
 	PutDigest(context.Context, string) error
+	// GetBuildTime returns the time at which the cache was built, or the
+	// zero time if it is not stored.
+	GetBuildTime(context.Context) (time.Time, error)
+	// PutBuildTime stores the time at which the cache was built.
+	PutBuildTime(context.Context, time.Time) error
+
+	// GetPackageDigests returns the digests of the FBC metas of each package
+	// that the cache was built from, or nil if they are not stored.
+	GetPackageDigests(context.Context) (map[string]string, error)
+	// PutPackageDigests stores the digests of the FBC metas of each package,
+	// or removes the stored digests if digests is nil. They are not part of
+	// the cache digest, so that storing them does not invalidate caches that
+	// were built without them.
+	PutPackageDigests(ctx context.Context, digests map[string]string) error
+
+	// GetAPIPlurals returns the API plurals of each package, or nil if they
+	// are not stored.
+	GetAPIPlurals(context.Context) (apiPlurals, error)
+	// PutAPIPlurals stores the API plurals of each package, or removes the
+	// stored plurals if plurals is nil. Like the package digests, they are
+	// not part of the cache digest.
+	PutAPIPlurals(context.Context, apiPlurals) error
+
+	// GetBundleInfos returns the bundle infos of each package, or nil if
+	// they are not stored.
+	GetBundleInfos(context.Context) (bundleInfos, error)
+	// PutBundleInfos stores the bundle infos of each package, or removes the
+	// stored infos if infos is nil. Like the package digests, they are not
+	// part of the cache digest.
+	PutBundleInfos(context.Context, bundleInfos) error
+
+	// OpenMetas returns the FBC metas that the cache was built from, as
+	// JSON lines, or an error that wraps os.ErrNotExist if they are not
+	// stored.
+	OpenMetas(context.Context) (io.ReadCloser, error)
+	// PutMetas stores the FBC metas that the cache was built from, which are
+	// read from metas as JSON lines. Like the package digests, they are not
+	// part of the cache digest.
+	PutMetas(ctx context.Context, metas io.Reader) error
 }
 
 type CacheOptions struct {
@@ -74,33 +150,63 @@ type CacheOption func(*CacheOptions)
 // latest iteration of the cache implementation. If the cache directory
 // is non-empty and a supported cache format is not found, an error is returned.
 func New(cacheDir string, cacheOpts ...CacheOption) (Cache, error) {
+	c, err := newCache(cacheDir, cacheOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func newCache(cacheDir string, cacheOpts ...CacheOption) (*cache, error) {
+	opts := newCacheOptions(cacheOpts...)
+	if err := recoverBuilds(cacheDir, opts.Log); err != nil {
+		return nil, err
+	}
+	return openCache(cacheDir, opts)
+}
+
+func newCacheOptions(cacheOpts ...CacheOption) *CacheOptions {
 	opts := &CacheOptions{
 		Log: log.Null(),
 	}
 	for _, opt := range cacheOpts {
 		opt(opts)
 	}
+	return opts
+}
+
+// openCache opens the cache in cacheDir without recovering interrupted
+// builds, so that it does not modify cacheDir until the cache is built.
+func openCache(cacheDir string, opts *CacheOptions) (*cache, error) {
 	cacheBackend, err := getBackend(cacheDir, opts.Format, opts.Log)
 	if err != nil {
 		return nil, err
 	}
 
+	cacheBackend = &tracingBackend{backend: cacheBackend}
+
 	if err := cacheBackend.Open(); err != nil {
 		return nil, fmt.Errorf("open cache: %v", err)
 	}
 	return &cache{backend: cacheBackend, log: opts.Log}, nil
 }
 
+// newBackends returns the supported backends for cacheDir, in order of
+// preference.
+func newBackends(cacheDir string) []backend {
+	return []backend{
+		newPogrebV1Backend(cacheDir),
+		newJSONBackend(cacheDir),
+	}
+}
+
 func getBackend(cacheDir string, backendName string, log *logrus.Entry) (backend, error) {
 	entries, err := os.ReadDir(cacheDir)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return nil, fmt.Errorf("detect cache format: read cache directory: %v", err)
 	}
 
-	backends := []backend{
-		newPogrebV1Backend(cacheDir),
-		newJSONBackend(cacheDir),
-	}
+	backends := newBackends(cacheDir)
 
 	if len(entries) == 0 {
 		if backendName == "" {
@@ -143,12 +249,20 @@ func LoadOrRebuild(ctx context.Context, c Cache, fbc fs.FS) error {
 	return c.Load(ctx)
 }
 
-var _ Cache = &cache{}
+var (
+	_ Cache                         = &cache{}
+	_ StatsReporter                 = &cache{}
+	_ MetaSender                    = &cache{}
+	_ registry.FilteredBundleSender = &cache{}
+)
 
 type cache struct {
 	backend backend
 	log     *logrus.Entry
 	packageIndex
+	pluralIndex
+
+	bundleIndex bundleIndex
 }
 
 type bundleStreamTransformer func(*api.Bundle)
@@ -170,6 +284,13 @@ func (s *sliceBundleSender) Send(b *api.Bundle) error {
 }
 
 func (c *cache) SendBundles(ctx context.Context, stream registry.BundleSender) error {
+	return c.SendFilteredBundles(ctx, stream, registry.BundleFilter{})
+}
+
+func (c *cache) SendFilteredBundles(ctx context.Context, stream registry.BundleSender, filter registry.BundleFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
 	transform := func(bundle *api.Bundle) {
 		if bundle.BundlePath != "" {
 			// The SQLite-based server
@@ -179,8 +300,13 @@ func (c *cache) SendBundles(ctx context.Context, stream registry.BundleSender) e
 			bundle.CsvJson = ""
 			bundle.Object = nil
 		}
+		filter.MaskFields(bundle)
+	}
+	selected := filter.Selector()
+	selectKey := func(key bundleKey) bool {
+		return selected(key.PackageName, key.ChannelName)
 	}
-	return c.backend.SendBundles(ctx, &transformingBundleSender{stream, transform})
+	return c.backend.SendBundles(ctx, &transformingBundleSender{stream, transform}, selectKey)
 }
 
 func (c *cache) ListBundles(ctx context.Context) ([]*api.Bundle, error) {
@@ -204,15 +330,15 @@ func (c *cache) getTrimmedBundle(ctx context.Context, key bundleKey) (*api.Bundl
 func (c *cache) GetBundle(ctx context.Context, pkgName, channelName, csvName string) (*api.Bundle, error) {
 	pkg, ok := c.packageIndex[pkgName]
 	if !ok {
-		return nil, fmt.Errorf("package %q not found", pkgName)
+		return nil, fmt.Errorf("package %q %w", pkgName, ErrNotFound)
 	}
 	ch, ok := pkg.Channels[channelName]
 	if !ok {
-		return nil, fmt.Errorf("package %q, channel %q not found", pkgName, channelName)
+		return nil, fmt.Errorf("package %q, channel %q %w", pkgName, channelName, ErrNotFound)
 	}
 	b, ok := ch.Bundles[csvName]
 	if !ok {
-		return nil, fmt.Errorf("package %q, channel %q, bundle %q not found", pkgName, channelName, csvName)
+		return nil, fmt.Errorf("package %q, channel %q, bundle %q %w", pkgName, channelName, csvName, ErrNotFound)
 	}
 	return c.getTrimmedBundle(ctx, bundleKey{pkg.Name, ch.Name, b.Name})
 }
@@ -237,7 +363,57 @@ func (c *cache) GetBundleThatProvides(ctx context.Context, group, version, kind
 	return c.packageIndex.GetBundleThatProvides(ctx, c, group, version, kind)
 }
 
-func (c *cache) CheckIntegrity(ctx context.Context, fbc fs.FS) error {
+func (c *cache) ListChannelEntries(ctx context.Context, pkgName, channelName string) ([]*registry.ChannelEntry, error) {
+	return c.packageIndex.ListChannelEntries(ctx, pkgName, channelName)
+}
+
+func (c *cache) GetDeprecations(ctx context.Context, pkgName string) (*api.Deprecations, error) {
+	return c.packageIndex.GetDeprecations(ctx, c.backend.GetBundle, pkgName)
+}
+
+func (c *cache) GetBundlesInRange(ctx context.Context, pkgName, versionRange string) ([]*api.Bundle, error) {
+	return c.bundleIndex.GetBundlesInRange(ctx, c.getTrimmedBundle, pkgName, versionRange)
+}
+
+func (c *cache) GetBundleByImage(ctx context.Context, image string) (*api.Bundle, error) {
+	return c.bundleIndex.GetBundleByImage(ctx, c.getTrimmedBundle, image)
+}
+
+func (c *cache) GetKindForPlural(_ context.Context, group, version, plural string) (string, error) {
+	return c.pluralIndex.GetKindForPlural(group, version, plural)
+}
+
+func (c *cache) FindBundles(ctx context.Context, query registry.PropertyQuery) ([]*api.BundleKey, error) {
+	return c.bundleIndex.FindBundles(ctx, c.packageIndex, c.backend.GetBundle, query)
+}
+
+func (c *cache) GetCatalogInfo(ctx context.Context) (*api.CatalogInfo, error) {
+	digest, err := c.backend.GetDigest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read cache digest: %v", err)
+	}
+	buildTime, err := c.backend.GetBuildTime(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read cache build time: %v", err)
+	}
+	var buildTimeStr string
+	if !buildTime.IsZero() {
+		buildTimeStr = buildTime.UTC().Format(time.RFC3339)
+	}
+	stats := c.Stats()
+	return &api.CatalogInfo{
+		Digest:    digest,
+		BuildTime: buildTimeStr,
+		Packages:  int32(stats.Packages),
+		Bundles:   int32(stats.Bundles),
+		Backend:   stats.Backend,
+	}, nil
+}
+
+func (c *cache) CheckIntegrity(ctx context.Context, fbc fs.FS) (err error) {
+	ctx, span := tracer.Start(ctx, "cache.CheckIntegrity")
+	defer func() { endSpan(span, err) }()
+
 	existingDigest, err := c.backend.GetDigest(ctx)
 	if err != nil {
 		return fmt.Errorf("read existing cache digest: %v", err)
@@ -253,17 +429,33 @@ func (c *cache) CheckIntegrity(ctx context.Context, fbc fs.FS) error {
 	return nil
 }
 
-func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
+// Build builds the cache from the FBC in fbcFsys. If the cache was
+// previously built, only the packages whose FBC metas have changed are
+// rebuilt, and the bundles of the other packages are kept.
+//
+// The cache is built in a staging directory, which replaces the cache once
+// the build is complete, so that an interrupted build leaves the previous
+// cache in place.
+func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) (err error) {
+	ctx, span := tracer.Start(ctx, "cache.Build")
+	defer func() { endSpan(span, err) }()
+
 	// ensure that generated cache is available to all future users
 	oldUmask := umask(000)
 	defer umask(oldUmask)
 
-	c.log.Info("building cache")
-
-	if err := c.backend.Init(); err != nil {
-		return fmt.Errorf("init cache: %v", err)
+	staging, err := c.startBuild()
+	if err != nil {
+		return err
+	}
+	if err := c.build(ctx, staging, fbcFsys); err != nil {
+		c.abortBuild(staging)
+		return err
 	}
+	return c.commitBuild(staging)
+}
 
+func (c *cache) build(ctx context.Context, staging backend, fbcFsys fs.FS) error {
 	tmpFile, err := os.CreateTemp("", "opm-cache-build-*.json")
 	if err != nil {
 		return err
@@ -274,10 +466,11 @@ func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
 	}()
 
 	var (
-		concurrency      = runtime.NumCPU()
-		byPackageReaders = map[string][]io.Reader{}
-		walkMu           sync.Mutex
-		offset           int64
+		concurrency       = runtime.NumCPU()
+		byPackageSections = map[string][]*io.SectionReader{}
+		byPackageHashes   = map[string][]uint64{}
+		walkMu            sync.Mutex
+		offset            int64
 	)
 	if err := declcfg.WalkMetasFS(ctx, fbcFsys, func(path string, meta *declcfg.Meta, err error) error {
 		if err != nil {
@@ -287,15 +480,26 @@ func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
 		if meta.Schema == declcfg.SchemaPackage {
 			packageName = meta.Name
 		}
+		h := fnv.New64a()
+		h.Write(meta.Blob)
+
+		// The metas are stored as JSON lines, which are also served from
+		// the cache, so each blob is compacted onto a single line.
+		var line bytes.Buffer
+		if err := json.Compact(&line, meta.Blob); err != nil {
+			return err
+		}
+		line.WriteByte('\n')
 
 		walkMu.Lock()
 		defer walkMu.Unlock()
-		if _, err := tmpFile.Write(meta.Blob); err != nil {
+		if _, err := tmpFile.Write(line.Bytes()); err != nil {
 			return err
 		}
-		sr := io.NewSectionReader(tmpFile, offset, int64(len(meta.Blob)))
-		byPackageReaders[packageName] = append(byPackageReaders[packageName], sr)
-		offset += int64(len(meta.Blob))
+		sr := io.NewSectionReader(tmpFile, offset, int64(line.Len()))
+		byPackageSections[packageName] = append(byPackageSections[packageName], sr)
+		byPackageHashes[packageName] = append(byPackageHashes[packageName], h.Sum64())
+		offset += int64(line.Len())
 		return nil
 	}, declcfg.WithConcurrency(concurrency)); err != nil {
 		return err
@@ -304,11 +508,28 @@ func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
 		return err
 	}
 
+	packageDigests := make(map[string]string, len(byPackageHashes))
+	for pkgName, hashes := range byPackageHashes {
+		packageDigests[pkgName] = packageDigest(hashes)
+	}
+
+	pkgs, plurals, infos, err := c.prepareBuild(ctx, staging, packageDigests)
+	if err != nil {
+		return err
+	}
+	rebuild := make([]string, 0, len(byPackageSections))
+	for pkgName := range byPackageSections {
+		if _, ok := pkgs[pkgName]; !ok {
+			rebuild = append(rebuild, pkgName)
+		}
+	}
+	c.log.WithField("packages", len(byPackageSections)).WithField("rebuiltPackages", len(rebuild)).Info("building cache")
+
 	eg, egCtx := errgroup.WithContext(ctx)
 	pkgNameChan := make(chan string, concurrency)
 	eg.Go(func() error {
 		defer close(pkgNameChan)
-		for pkgName := range byPackageReaders {
+		for _, pkgName := range rebuild {
 			select {
 			case <-egCtx.Done():
 				return egCtx.Err()
@@ -318,10 +539,7 @@ func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
 		return nil
 	})
 
-	var (
-		pkgs   = packageIndex{}
-		pkgsMu sync.Mutex
-	)
+	var pkgsMu sync.Mutex
 	for i := 0; i < concurrency; i++ {
 		eg.Go(func() error {
 			for {
@@ -332,13 +550,15 @@ func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
 					if !ok {
 						return nil
 					}
-					pkgIndex, err := c.processPackage(egCtx, io.MultiReader(byPackageReaders[pkgName]...))
+					pkgIndex, pkgPlurals, pkgInfos, err := processPackage(egCtx, staging, readSections(byPackageSections[pkgName]...))
 					if err != nil {
 						return fmt.Errorf("process package %q: %v", pkgName, err)
 					}
 
 					pkgsMu.Lock()
 					pkgs[pkgName] = pkgIndex[pkgName]
+					plurals[pkgName] = pkgPlurals[pkgName]
+					infos[pkgName] = pkgInfos[pkgName]
 					pkgsMu.Unlock()
 				}
 			}
@@ -349,58 +569,242 @@ func (c *cache) Build(ctx context.Context, fbcFsys fs.FS) error {
 		return fmt.Errorf("build package index: %v", err)
 	}
 
-	if err := c.backend.PutPackageIndex(ctx, pkgs); err != nil {
+	if err := staging.PutPackageIndex(ctx, pkgs); err != nil {
 		return fmt.Errorf("store package index: %v", err)
 	}
+	if err := staging.PutPackageDigests(ctx, packageDigests); err != nil {
+		return fmt.Errorf("store package digests: %v", err)
+	}
+	if err := staging.PutAPIPlurals(ctx, plurals); err != nil {
+		return fmt.Errorf("store api plurals: %v", err)
+	}
+	if err := staging.PutBundleInfos(ctx, infos); err != nil {
+		return fmt.Errorf("store bundle infos: %v", err)
+	}
+
+	pkgNames := make([]string, 0, len(byPackageSections))
+	for pkgName := range byPackageSections {
+		pkgNames = append(pkgNames, pkgName)
+	}
+	slices.Sort(pkgNames)
+	var metas []*io.SectionReader
+	for _, pkgName := range pkgNames {
+		metas = append(metas, byPackageSections[pkgName]...)
+	}
+	if err := staging.PutMetas(ctx, readSections(metas...)); err != nil {
+		return fmt.Errorf("store FBC metas: %v", err)
+	}
+
+	if err := staging.PutBuildTime(ctx, time.Now()); err != nil {
+		return fmt.Errorf("store build time: %v", err)
+	}
 
-	digest, err := c.backend.ComputeDigest(ctx, fbcFsys)
+	digest, err := staging.ComputeDigest(ctx, fbcFsys)
 	if err != nil {
 		return fmt.Errorf("compute digest: %v", err)
 	}
-	if err := c.backend.PutDigest(ctx, digest); err != nil {
+	if err := staging.PutDigest(ctx, digest); err != nil {
 		return fmt.Errorf("store digest: %v", err)
 	}
 	return nil
 }
 
-func (c *cache) processPackage(ctx context.Context, reader io.Reader) (packageIndex, error) {
+// prepareBuild starts the staging backend from a copy of the cache, in which
+// it keeps the bundles of the packages whose digests are unchanged since the
+// cache was previously built, and deletes the bundles of the other packages,
+// so that unchanged bundles are neither decoded nor stored again. It returns
+// the index entries, API plurals and bundle infos of the unchanged packages.
+// If the cache was not built with package digests, API plurals and bundle
+// infos, the staging backend is left empty, and every package is rebuilt.
+func (c *cache) prepareBuild(ctx context.Context, staging backend, packageDigests map[string]string) (packageIndex, apiPlurals, bundleInfos, error) {
+	previousDigests, err := c.backend.GetPackageDigests(ctx)
+	if err != nil {
+		c.log.WithError(err).Warn("unable to read package digests, rebuilding all packages")
+	}
+	pkgs, plurals, infos := packageIndex{}, apiPlurals{}, bundleInfos{}
+	if previousDigests == nil {
+		return pkgs, plurals, infos, nil
+	}
+	previousPlurals, err := c.backend.GetAPIPlurals(ctx)
+	if err != nil {
+		c.log.WithError(err).Warn("unable to read api plurals, rebuilding all packages")
+	}
+	if previousPlurals == nil {
+		return pkgs, plurals, infos, nil
+	}
+	previousInfos, err := c.backend.GetBundleInfos(ctx)
+	if err != nil {
+		c.log.WithError(err).Warn("unable to read bundle infos, rebuilding all packages")
+	}
+	if previousInfos == nil {
+		return pkgs, plurals, infos, nil
+	}
+	previous, err := c.backend.GetPackageIndex(ctx)
+	if err != nil {
+		c.log.WithError(err).Warn("unable to read package index, rebuilding all packages")
+		return pkgs, plurals, infos, nil
+	}
+
+	if err := c.copyInto(staging); err != nil {
+		return nil, nil, nil, fmt.Errorf("copy cache: %v", err)
+	}
+	// Reading the package index of the copy makes the staging backend track
+	// the bundles that it already stores.
+	if _, err := staging.GetPackageIndex(ctx); err != nil {
+		return nil, nil, nil, fmt.Errorf("get package index of copied cache: %v", err)
+	}
+	for pkgName, pkg := range previous {
+		if digest, ok := packageDigests[pkgName]; ok && digest == previousDigests[pkgName] {
+			pkgs[pkgName] = pkg
+			plurals[pkgName] = previousPlurals[pkgName]
+			infos[pkgName] = previousInfos[pkgName]
+			continue
+		}
+		for _, ch := range pkg.Channels {
+			for _, b := range ch.Bundles {
+				if err := staging.DeleteBundle(ctx, bundleKey{pkg.Name, ch.Name, b.Name}); err != nil {
+					return nil, nil, nil, fmt.Errorf("delete bundle %q: %v", b.Name, err)
+				}
+			}
+		}
+	}
+	return pkgs, plurals, infos, nil
+}
+
+// copyInto replaces the content of the staging backend with a copy of the
+// cache. Both backends are closed while the cache is copied, so that the
+// copy is consistent, and are reopened afterwards.
+func (c *cache) copyInto(staging backend) error {
+	if err := staging.Close(); err != nil {
+		return err
+	}
+	if err := c.backend.Close(); err != nil {
+		return errors.Join(err, staging.Open())
+	}
+	copyErr := func() error {
+		for _, entry := range staging.Entries() {
+			if err := os.RemoveAll(filepath.Join(staging.Dir(), entry)); err != nil {
+				return err
+			}
+		}
+		return copyEntries(staging.Dir(), c.backend.Dir(), c.backend.Entries())
+	}()
+	return errors.Join(copyErr, c.backend.Open(), staging.Open())
+}
+
+// readSections returns a reader of the concatenation of sections. Each
+// section is read from its start, even if it was read before.
+func readSections(sections ...*io.SectionReader) io.Reader {
+	readers := make([]io.Reader, 0, len(sections))
+	for _, s := range sections {
+		readers = append(readers, io.NewSectionReader(s.Outer()))
+	}
+	return io.MultiReader(readers...)
+}
+
+// packageDigest returns the digest of the FBC metas of a package from the
+// hashes of their blobs. The hashes are sorted, so that the digest does not
+// depend on the order in which the metas were read.
+func packageDigest(hashes []uint64) string {
+	sorted := slices.Clone(hashes)
+	slices.Sort(sorted)
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	for _, hash := range sorted {
+		binary.BigEndian.PutUint64(buf, hash)
+		h.Write(buf)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func processPackage(ctx context.Context, dst backend, reader io.Reader) (packageIndex, apiPlurals, bundleInfos, error) {
 	pkgFbc, err := declcfg.LoadReader(reader)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	pkgModel, err := declcfg.ConvertToModel(*pkgFbc)
+	pkgModel, err := declcfg.ConvertToModelContext(ctx, *pkgFbc)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	pkgIndex, err := packagesFromModel(pkgModel)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	plurals, infos := apiPlurals{}, bundleInfos{}
 	for _, p := range pkgModel {
+		plurals[p.Name] = pluralsFromModel(p)
+		infoKeys := sets.New(bundleInfoKeys(pkgIndex[p.Name])...)
+		pkgInfos := make([]bundleInfo, 0, infoKeys.Len())
 		for _, ch := range p.Channels {
 			for _, b := range ch.Bundles {
+				key := bundleKey{p.Name, ch.Name, b.Name}
 				apiBundle, err := api.ConvertModelBundleToAPIBundle(*b)
 				if err != nil {
-					return nil, err
+					return nil, nil, nil, err
+				}
+				if infoKeys.Has(key) {
+					pkgInfos = append(pkgInfos, newBundleInfo(key, apiBundle))
 				}
-				if err := c.backend.PutBundle(ctx, bundleKey{p.Name, ch.Name, b.Name}, apiBundle); err != nil {
-					return nil, fmt.Errorf("store bundle %q: %v", b.Name, err)
+				if err := dst.PutBundle(ctx, key, apiBundle); err != nil {
+					return nil, nil, nil, fmt.Errorf("store bundle %q: %v", b.Name, err)
 				}
 			}
 		}
+		slices.SortFunc(pkgInfos, func(a, b bundleInfo) int { return strings.Compare(a.Name, b.Name) })
+		infos[p.Name] = pkgInfos
 	}
-	return pkgIndex, nil
+	return pkgIndex, plurals, infos, nil
 }
 
-func (c *cache) Load(ctx context.Context) error {
+func (c *cache) Load(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "cache.Load")
+	defer func() { endSpan(span, err) }()
+
 	pi, err := c.backend.GetPackageIndex(ctx)
 	if err != nil {
 		return fmt.Errorf("get package index: %v", err)
 	}
+	plurals, err := c.backend.GetAPIPlurals(ctx)
+	if err != nil {
+		return fmt.Errorf("get api plurals: %v", err)
+	}
+	infos, err := c.backend.GetBundleInfos(ctx)
+	if err != nil {
+		return fmt.Errorf("get bundle infos: %v", err)
+	}
+	if plurals == nil || infos == nil {
+		c.log.WithField("hasAPIPlurals", plurals != nil).WithField("hasBundleInfos", infos != nil).Info("reading missing cache indexes from the bundles")
+		derivedPlurals, derivedInfos, err := indexesFromBundles(ctx, pi, c.backend.GetBundle)
+		if err != nil {
+			return fmt.Errorf("read cache indexes from bundles: %v", err)
+		}
+		if plurals == nil {
+			plurals = derivedPlurals
+		}
+		if infos == nil {
+			infos = derivedInfos
+		}
+	}
 	c.packageIndex = pi
+	c.pluralIndex = plurals.index()
+	c.bundleIndex = infos.index()
 	return nil
 }
 
+func (c *cache) Stats() Stats {
+	stats := Stats{Backend: c.backend.Name(), Packages: len(c.packageIndex)}
+	for _, pkg := range c.packageIndex {
+		bundles := sets.New[string]()
+		for _, ch := range pkg.Channels {
+			for name := range ch.Bundles {
+				bundles.Insert(name)
+			}
+		}
+		stats.Bundles += bundles.Len()
+	}
+	return stats
+}
+
 func (c *cache) Close() error {
 	return c.backend.Close()
 }
@@ -429,10 +833,60 @@ func readDigestFile(digestFile string) (string, error) {
 	return strings.TrimSpace(string(existingDigestBytes)), nil
 }
 
+// readBuildTimeFile reads the build time stored in file, or returns the zero
+// time if file does not exist.
+func readBuildTimeFile(file string) (time.Time, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+}
+
+func writeBuildTimeFile(file string, buildTime time.Time, mode os.FileMode) error {
+	return os.WriteFile(file, []byte(buildTime.UTC().Format(time.RFC3339Nano)), mode)
+}
+
 func writeDigestFile(file string, digest string, mode os.FileMode) error {
 	return os.WriteFile(file, []byte(digest), mode)
 }
 
+// readPackageDigestsFile reads the package digests stored in file, or returns
+// nil if file does not exist.
+func readPackageDigestsFile(file string) (map[string]string, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var digests map[string]string
+	if err := json.Unmarshal(data, &digests); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// writePackageDigestsFile stores digests in file, or removes file if digests
+// is nil.
+func writePackageDigestsFile(file string, digests map[string]string, mode os.FileMode) error {
+	if digests == nil {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(digests)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, mode)
+}
+
 func doesBundleProvide(ctx context.Context, getBundle getBundleFunc, pkgName, chName, bundleName, group, version, kind string) (bool, error) {
 	apiBundle, err := getBundle(ctx, bundleKey{pkgName, chName, bundleName})
 	if err != nil {
@@ -1,13 +1,24 @@
 package cache
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
+	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"testing/fstest"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/api"
 	"github.com/operator-framework/operator-registry/pkg/lib/log"
 	"github.com/operator-framework/operator-registry/pkg/registry"
 )
@@ -219,6 +230,373 @@ func TestCache_ListPackages(t *testing.T) {
 	}
 }
 
+func TestCache_Stats(t *testing.T) {
+	for name, testQuerier := range genTestCaches(t, validFS) {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, Stats{Backend: name, Packages: 2, Bundles: 11}, testQuerier.(StatsReporter).Stats())
+		})
+	}
+}
+
+func TestCache_SendMetas(t *testing.T) {
+	for name, testQuerier := range genTestCaches(t, validFS) {
+		t.Run(name, func(t *testing.T) {
+			sendMetas := func(filter MetaFilter) []string {
+				var names []string
+				require.NoError(t, testQuerier.(MetaSender).SendMetas(context.TODO(), filter, func(m *declcfg.Meta) error {
+					require.NotContains(t, string(m.Blob), "\n")
+					names = append(names, m.Name)
+					return nil
+				}))
+				return names
+			}
+			require.Equal(t, []string{"stable", "stable-3.x", "stable-5.x", "alpha", "singlenamespace-alpha", "clusterwide-alpha"}, sendMetas(MetaFilter{Schema: declcfg.SchemaChannel}))
+			require.Equal(t, []string{"etcd"}, sendMetas(MetaFilter{Schema: declcfg.SchemaPackage, Package: "etcd"}))
+			require.Equal(t, []string{"etcdoperator.v0.9.4"}, sendMetas(MetaFilter{Package: "etcd", Name: "etcdoperator.v0.9.4"}))
+			require.Empty(t, sendMetas(MetaFilter{Package: "widgets"}))
+		})
+	}
+}
+
+func TestCache_BuildTime(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			before := time.Now().Truncate(time.Second)
+			dir := buildTestCache(t, format, validFS)
+			after := time.Now()
+
+			c, err := newCache(dir)
+			require.NoError(t, err)
+			defer c.Close()
+			buildTime, err := c.backend.GetBuildTime(ctx)
+			require.NoError(t, err)
+			require.False(t, buildTime.Before(before))
+			require.False(t, buildTime.After(after))
+
+			// The build time does not depend on when the cache files were
+			// last modified.
+			for _, entry := range c.backend.Entries() {
+				require.NoError(t, os.Chtimes(filepath.Join(dir, entry), time.Time{}, time.Unix(0, 0)))
+			}
+			require.NoError(t, c.Load(ctx))
+			info, err := c.GetCatalogInfo(ctx)
+			require.NoError(t, err)
+			require.Equal(t, buildTime.UTC().Format(time.RFC3339), info.BuildTime)
+
+			require.NoError(t, os.Remove(filepath.Join(dir, map[string]string{FormatJSON: jsonBuildTimeFile, FormatPogrebV1: pogrebBuildTimeFile}[format])))
+			info, err = c.GetCatalogInfo(ctx)
+			require.NoError(t, err)
+			require.Empty(t, info.BuildTime)
+		})
+	}
+}
+
+func TestCache_CompressedFBC(t *testing.T) {
+	compressedFS := compressFS(t, validFS)
+	for name, testQuerier := range genTestCaches(t, compressedFS) {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, testQuerier.CheckIntegrity(context.TODO(), compressedFS))
+			packages, err := testQuerier.ListPackages(context.TODO())
+			require.NoError(t, err)
+			require.Equal(t, 2, len(packages))
+			b, err := testQuerier.GetBundle(context.TODO(), "etcd", "singlenamespace-alpha", "etcdoperator.v0.9.4")
+			require.NoError(t, err)
+			require.Equal(t, b.CsvName, "etcdoperator.v0.9.4")
+		})
+	}
+}
+
+func TestCache_IncrementalBuild(t *testing.T) {
+	withoutEtcd := fstest.MapFS{}
+	for name, f := range validFS {
+		if name != "etcd.json" {
+			withoutEtcd[name] = f
+		}
+	}
+	// The channel of the etcd bundles is renamed, so the bundles are stored
+	// under different keys.
+	renamedChannel := fstest.MapFS{}
+	for name, f := range validFS {
+		renamedChannel[name] = f
+	}
+	renamedChannel["etcd.json"] = &fstest.MapFile{Data: bytes.ReplaceAll(validFS["etcd.json"].Data, []byte("singlenamespace-alpha"), []byte("singlenamespace-beta"))}
+	cockroachdbKey := bundleKey{"cockroachdb", "stable", "cockroachdb.v2.0.9"}
+	etcdKey := bundleKey{"etcd", "singlenamespace-alpha", "etcdoperator.v0.9.4"}
+
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			fullDigest := func(fbc fs.FS) string {
+				c, err := newCache(t.TempDir(), WithFormat(format))
+				require.NoError(t, err)
+				defer c.Close()
+				require.NoError(t, c.Build(ctx, fbc))
+				digest, err := c.backend.GetDigest(ctx)
+				require.NoError(t, err)
+				return digest
+			}
+
+			// A bundle is tampered with in the cache, so that it is known to
+			// have been kept by a build if it is still tampered with, and to
+			// have been rebuilt from the FBC otherwise.
+			tamper := func(c *cache, key bundleKey) {
+				b, err := c.backend.GetBundle(ctx, key)
+				require.NoError(t, err)
+				b.Version = "tampered"
+				require.NoError(t, c.backend.PutBundle(ctx, key, b))
+			}
+			isTampered := func(c *cache, key bundleKey) bool {
+				b, err := c.backend.GetBundle(ctx, key)
+				require.NoError(t, err)
+				return b.Version == "tampered"
+			}
+
+			c, err := newCache(t.TempDir(), WithFormat(format))
+			require.NoError(t, err)
+			defer c.Close()
+			require.NoError(t, c.Build(ctx, validFS))
+
+			// Removing a package keeps the bundles of the other packages.
+			tamper(c, cockroachdbKey)
+			require.NoError(t, c.Build(ctx, withoutEtcd))
+			require.True(t, isTampered(c, cockroachdbKey))
+			require.NoError(t, c.Load(ctx))
+			packages, err := c.ListPackages(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []string{"cockroachdb"}, packages)
+			bundles, err := c.ListBundles(ctx)
+			require.NoError(t, err)
+			require.Len(t, bundles, 5)
+
+			// Adding a package back only builds that package.
+			require.NoError(t, c.Build(ctx, validFS))
+			require.True(t, isTampered(c, cockroachdbKey))
+			require.False(t, isTampered(c, etcdKey))
+			require.NoError(t, c.Load(ctx))
+			require.Equal(t, Stats{Backend: format, Packages: 2, Bundles: 11}, c.Stats())
+			inRange, err := c.GetBundlesInRange(ctx, "cockroachdb", ">=5.0.0")
+			require.NoError(t, err)
+			require.Len(t, inRange, 1)
+			require.Equal(t, "cockroachdb.v5.0.3", inRange[0].CsvName)
+
+			// Without package digests, every package is rebuilt.
+			require.NoError(t, c.backend.PutPackageDigests(ctx, nil))
+			require.NoError(t, c.Build(ctx, validFS))
+			require.False(t, isTampered(c, cockroachdbKey))
+
+			// A cache that is built incrementally is the same as a cache that
+			// is built from scratch.
+			require.NoError(t, c.Build(ctx, withoutEtcd))
+			require.NoError(t, c.CheckIntegrity(ctx, withoutEtcd))
+			digest, err := c.backend.GetDigest(ctx)
+			require.NoError(t, err)
+			require.Equal(t, fullDigest(withoutEtcd), digest)
+			require.NoError(t, c.Build(ctx, validFS))
+			require.NoError(t, c.CheckIntegrity(ctx, validFS))
+			digest, err = c.backend.GetDigest(ctx)
+			require.NoError(t, err)
+			require.Equal(t, fullDigest(validFS), digest)
+
+			// The bundles of a changed package are replaced, rather than
+			// added to.
+			require.NoError(t, c.Build(ctx, renamedChannel))
+			digest, err = c.backend.GetDigest(ctx)
+			require.NoError(t, err)
+			require.Equal(t, fullDigest(renamedChannel), digest)
+			require.NoError(t, c.Build(ctx, validFS))
+			digest, err = c.backend.GetDigest(ctx)
+			require.NoError(t, err)
+			require.Equal(t, fullDigest(validFS), digest)
+
+			// Without bundle infos, every package is rebuilt.
+			require.NoError(t, c.backend.PutBundleInfos(ctx, nil))
+			tamper(c, cockroachdbKey)
+			require.NoError(t, c.Build(ctx, validFS))
+			require.False(t, isTampered(c, cockroachdbKey))
+			infos, err := c.backend.GetBundleInfos(ctx)
+			require.NoError(t, err)
+			require.Len(t, infos, 2)
+
+			// Without api plurals, the cache passes its integrity check, and
+			// every package is rebuilt.
+			require.NoError(t, c.backend.PutAPIPlurals(ctx, nil))
+			require.NoError(t, c.CheckIntegrity(ctx, validFS))
+			tamper(c, cockroachdbKey)
+			require.NoError(t, c.Build(ctx, validFS))
+			require.False(t, isTampered(c, cockroachdbKey))
+			require.NoError(t, c.CheckIntegrity(ctx, validFS))
+		})
+	}
+}
+
+func TestCache_GetKindForPlural(t *testing.T) {
+	crd := `{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition","metadata":{"name":"gadgets.example.com"},"spec":{"group":"example.com","names":{"kind":"Gadget","plural":"gadgets"},"versions":[{"name":"v1"},{"name":"v2"}]}}`
+	pluralsFS := fstest.MapFS{
+		"widgets.json": &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"schema": "olm.package", "name": "widgets", "defaultChannel": "stable"}
+{"schema": "olm.channel", "package": "widgets", "name": "stable", "entries": [{"name": "widgets.v1.0.0"}]}
+{
+	"schema": "olm.bundle",
+	"name": "widgets.v1.0.0",
+	"package": "widgets",
+	"image": "quay.io/example/widgets-bundle:v1.0.0",
+	"properties": [
+		{"type": "olm.package", "value": {"packageName": "widgets", "version": "1.0.0"}},
+		{"type": "olm.gvk", "value": {"group": "example.com", "kind": "Widget", "version": "v1"}},
+		{"type": "olm.csv.metadata", "value": {
+			"crdDescriptions": {"owned": [{"name": "widgets.example.com", "version": "v1", "kind": "Widget"}]},
+			"apiServiceDefinitions": {"owned": [{"group": "metrics.example.com", "version": "v1alpha1", "kind": "Sprocket", "name": "sprockets"}]}
+		}},
+		{"type": "olm.bundle.object", "value": {"data": %q}}
+	]
+}`, base64.StdEncoding.EncodeToString([]byte(crd))))},
+	}
+
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			c, err := newCache(buildTestCache(t, format, pluralsFS))
+			require.NoError(t, err)
+			defer c.Close()
+
+			check := func(t *testing.T) {
+				require.NoError(t, c.Load(ctx))
+				for _, tt := range []struct {
+					group, version, plural string
+					kind                   string
+				}{
+					{group: "example.com", version: "v1", plural: "widgets", kind: "Widget"},
+					{group: "example.com", version: "v1", plural: "gadgets", kind: "Gadget"},
+					{group: "example.com", version: "v2", plural: "gadgets", kind: "Gadget"},
+					{group: "metrics.example.com", version: "v1alpha1", plural: "sprockets", kind: "Sprocket"},
+				} {
+					kind, err := c.GetKindForPlural(ctx, tt.group, tt.version, tt.plural)
+					require.NoError(t, err)
+					require.Equal(t, tt.kind, kind)
+				}
+
+				_, err := c.GetKindForPlural(ctx, "example.com", "v2", "widgets")
+				require.ErrorIs(t, err, ErrNotFound)
+			}
+			t.Run("Stored", check)
+
+			// Caches that were built without api plurals read them from the
+			// bundles when they are loaded.
+			require.NoError(t, c.backend.PutAPIPlurals(ctx, nil))
+			require.NoError(t, c.CheckIntegrity(ctx, pluralsFS))
+			t.Run("NotStored", check)
+		})
+	}
+}
+
+func TestCache_BundleIndex(t *testing.T) {
+	bundle := func(pkg, version, image string) string {
+		return fmt.Sprintf(`{"schema": "olm.package", "name": %[1]q, "defaultChannel": "stable"}
+{"schema": "olm.channel", "package": %[1]q, "name": "stable", "entries": [{"name": "%[1]s.v%[2]s"}]}
+{"schema": "olm.bundle", "name": "%[1]s.v%[2]s", "package": %[1]q, "image": %[3]q, "properties": [{"type": "olm.package", "value": {"packageName": %[1]q, "version": %[2]q}}]}`, pkg, version, image)
+	}
+	const sharedImage = "quay.io/example/shared@sha256:1234"
+	sharedFS := fstest.MapFS{
+		"b.json": &fstest.MapFile{Data: []byte(bundle("b", "1.0.0", sharedImage))},
+		"a.json": &fstest.MapFile{Data: []byte(bundle("a", "2.0.0", sharedImage))},
+	}
+
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			c, err := newCache(buildTestCache(t, format, sharedFS))
+			require.NoError(t, err)
+			defer c.Close()
+
+			// Bundles are not looked up before the cache is loaded.
+			_, err = c.GetBundleByImage(ctx, sharedImage)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			infos, err := c.backend.GetBundleInfos(ctx)
+			require.NoError(t, err)
+			require.Equal(t, bundleInfos{
+				"a": {{Name: "a.v2.0.0", Channel: "stable", Version: "2.0.0", Image: sharedImage, PropertyTypes: []string{"olm.package"}}},
+				"b": {{Name: "b.v1.0.0", Channel: "stable", Version: "1.0.0", Image: sharedImage, PropertyTypes: []string{"olm.package"}}},
+			}, infos)
+
+			check := func(t *testing.T) {
+				require.NoError(t, c.Load(ctx))
+				// A shared image is looked up in the first package by name.
+				for _, image := range []string{sharedImage, "sha256:1234"} {
+					b, err := c.GetBundleByImage(ctx, image)
+					require.NoError(t, err)
+					require.Equal(t, "a.v2.0.0", b.CsvName)
+				}
+				bundles, err := c.GetBundlesInRange(ctx, "b", ">=1.0.0")
+				require.NoError(t, err)
+				require.Len(t, bundles, 1)
+				require.Equal(t, "b.v1.0.0", bundles[0].CsvName)
+
+				// Only the bundles with a property of the queried type are
+				// read, and only if their property values are queried.
+				reads := 0
+				getBundle := func(ctx context.Context, key bundleKey) (*api.Bundle, error) {
+					reads++
+					return c.backend.GetBundle(ctx, key)
+				}
+				for _, tt := range []struct {
+					query    registry.PropertyQuery
+					expected []string
+					reads    int
+				}{
+					{query: registry.PropertyQuery{Type: "olm.package"}, expected: []string{"a.v2.0.0", "b.v1.0.0"}},
+					{query: registry.PropertyQuery{Type: "olm.gvk", Path: "kind", Value: "Widget"}},
+					{query: registry.PropertyQuery{Type: "olm.package", Path: "version", Value: "1.0.0"}, expected: []string{"b.v1.0.0"}, reads: 2},
+				} {
+					reads = 0
+					keys, err := c.bundleIndex.FindBundles(ctx, c.packageIndex, getBundle, tt.query)
+					require.NoError(t, err)
+					var names []string
+					for _, key := range keys {
+						names = append(names, key.CsvName)
+					}
+					require.Equal(t, tt.expected, names)
+					require.Equal(t, tt.reads, reads)
+				}
+			}
+			t.Run("Stored", check)
+
+			// Caches that were built without bundle infos read them from
+			// the bundles when they are loaded.
+			require.NoError(t, c.backend.PutBundleInfos(ctx, nil))
+			t.Run("NotStored", check)
+		})
+	}
+}
+
+// compressFS returns a copy of fbcFS in which JSON files are gzip-compressed
+// and all other files are zstd-compressed.
+func compressFS(t *testing.T, fbcFS fstest.MapFS) fstest.MapFS {
+	t.Helper()
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer enc.Close()
+
+	out := fstest.MapFS{}
+	for name, f := range fbcFS {
+		if f.Mode.IsDir() {
+			out[name] = f
+			continue
+		}
+		if strings.HasSuffix(name, ".json") {
+			var buf bytes.Buffer
+			w := gzip.NewWriter(&buf)
+			_, err := w.Write(f.Data)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+			out[name+".gz"] = &fstest.MapFile{Data: buf.Bytes(), Mode: f.Mode}
+			continue
+		}
+		out[name+".zst"] = &fstest.MapFile{Data: enc.EncodeAll(f.Data, nil), Mode: f.Mode}
+	}
+	return out
+}
+
 func genTestCaches(t *testing.T, fbcFS fs.FS) map[string]Cache {
 	t.Helper()
 
@@ -0,0 +1,387 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Info describes the content of a cache directory.
+type Info struct {
+	// Backend is the name of the backend that stores the cache.
+	Backend string `json:"backend"`
+	// Digest is the digest stored in the cache, or empty if the cache has no
+	// digest.
+	Digest string `json:"digest"`
+	// Bundles is the number of distinct bundles in the cache.
+	Bundles int `json:"bundles"`
+	// Packages describes the package index of the cache.
+	Packages []PackageInfo `json:"packages"`
+}
+
+// PackageInfo describes a package in the package index of a cache.
+type PackageInfo struct {
+	Name           string        `json:"name"`
+	DefaultChannel string        `json:"defaultChannel"`
+	Deprecated     bool          `json:"deprecated,omitempty"`
+	Channels       []ChannelInfo `json:"channels"`
+}
+
+// ChannelInfo describes a channel in the package index of a cache.
+type ChannelInfo struct {
+	Name       string `json:"name"`
+	Head       string `json:"head"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+	Bundles    int    `json:"bundles"`
+}
+
+// openExisting opens a copy of the cache in cacheDir with the backend that
+// stored it. Unlike New, it fails if cacheDir does not contain a cache, rather
+// than creating one. Opening a pogreb database creates a lock file in it, and
+// may recover it in place, so the cache is copied to a temporary directory,
+// which is removed when the cache is closed, so that inspecting a cache does
+// not modify it.
+func openExisting(cacheDir string) (*cache, error) {
+	for _, b := range newBackends(cacheDir) {
+		if !b.IsCachePresent() {
+			continue
+		}
+		tmpDir, err := os.MkdirTemp("", "opm-cache-inspect-")
+		if err != nil {
+			return nil, err
+		}
+		if err := copyEntries(tmpDir, cacheDir, b.Entries()); err != nil {
+			os.RemoveAll(tmpDir)
+			return nil, fmt.Errorf("copy cache: %v", err)
+		}
+		c, err := openCache(tmpDir, newCacheOptions(WithFormat(b.Name())))
+		if err != nil {
+			os.RemoveAll(tmpDir)
+			return nil, err
+		}
+		c.backend = &removeOnCloseBackend{backend: c.backend}
+		return c, nil
+	}
+	return nil, fmt.Errorf("no cache found in %q", cacheDir)
+}
+
+// removeOnCloseBackend removes the directory of a backend when it is closed.
+type removeOnCloseBackend struct {
+	backend
+}
+
+func (b *removeOnCloseBackend) Close() error {
+	return errors.Join(b.backend.Close(), os.RemoveAll(b.Dir()))
+}
+
+// Inspect describes the cache in cacheDir. It does not check the integrity of
+// the cache, so that caches that fail their integrity check can be inspected.
+func Inspect(ctx context.Context, cacheDir string) (*Info, error) {
+	c, err := openExisting(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	digest, err := c.backend.GetDigest(ctx)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read cache digest: %v", err)
+	}
+	if err := c.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	info := &Info{
+		Backend:  c.backend.Name(),
+		Digest:   digest,
+		Bundles:  c.Stats().Bundles,
+		Packages: make([]PackageInfo, 0, len(c.packageIndex)),
+	}
+	for _, pkg := range c.packageIndex {
+		pkgInfo := PackageInfo{
+			Name:           pkg.Name,
+			DefaultChannel: pkg.DefaultChannel,
+			Deprecated:     pkg.Deprecation != nil,
+			Channels:       make([]ChannelInfo, 0, len(pkg.Channels)),
+		}
+		for _, ch := range pkg.Channels {
+			pkgInfo.Channels = append(pkgInfo.Channels, ChannelInfo{
+				Name:       ch.Name,
+				Head:       ch.Head,
+				Deprecated: ch.Deprecation != nil,
+				Bundles:    len(ch.Bundles),
+			})
+		}
+		sort.Slice(pkgInfo.Channels, func(i, j int) bool { return pkgInfo.Channels[i].Name < pkgInfo.Channels[j].Name })
+		info.Packages = append(info.Packages, pkgInfo)
+	}
+	sort.Slice(info.Packages, func(i, j int) bool { return info.Packages[i].Name < info.Packages[j].Name })
+	return info, nil
+}
+
+// Verify checks the cache in cacheDir against the FBC in fbc. It returns the
+// differences that it finds, which are empty if the digest of the cache
+// matches and its content is equivalent to a cache built from fbc. The
+// content is compared even if the digest does not match, to show why.
+func Verify(ctx context.Context, cacheDir string, fbc fs.FS) ([]string, error) {
+	c, err := openExisting(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	var diffs []string
+	existingDigest, err := c.backend.GetDigest(ctx)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read cache digest: %v", err)
+	}
+	computedDigest, err := c.backend.ComputeDigest(ctx, fbc)
+	if err != nil {
+		return nil, fmt.Errorf("compute digest: %v", err)
+	}
+	if existingDigest != computedDigest {
+		diffs = append(diffs, fmt.Sprintf("cache digest %q does not match computed digest %q", existingDigest, computedDigest))
+	}
+	if err := c.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "opm-cache-verify-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	expected, err := newCache(tmpDir, WithFormat(c.backend.Name()))
+	if err != nil {
+		return nil, err
+	}
+	defer expected.Close()
+	if err := expected.Build(ctx, fbc); err != nil {
+		return nil, fmt.Errorf("build cache from FBC: %v", err)
+	}
+	if err := expected.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	contentDiffs, err := diffContent(ctx, expected, c)
+	if err != nil {
+		return nil, err
+	}
+	return append(diffs, contentDiffs...), nil
+}
+
+// diffContent returns the differences between the package indexes and the
+// bundles of the expected and actual caches.
+func diffContent(ctx context.Context, expected, actual *cache) ([]string, error) {
+	var diffs []string
+	for name := range actual.packageIndex {
+		if _, ok := expected.packageIndex[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("package %q is in the cache, but not in the FBC", name))
+		}
+	}
+	for name, expectedPkg := range expected.packageIndex {
+		actualPkg, ok := actual.packageIndex[name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("package %q is in the FBC, but not in the cache", name))
+			continue
+		}
+		expectedChannels, actualChannels := expectedPkg.Channels, actualPkg.Channels
+		expectedPkg.Channels, actualPkg.Channels = nil, nil
+		if !reflect.DeepEqual(expectedPkg, actualPkg) {
+			diffs = append(diffs, fmt.Sprintf("package %q differs", name))
+		}
+
+		for chName := range actualChannels {
+			if _, ok := expectedChannels[chName]; !ok {
+				diffs = append(diffs, fmt.Sprintf("package %q, channel %q is in the cache, but not in the FBC", name, chName))
+			}
+		}
+		for chName, expectedCh := range expectedChannels {
+			actualCh, ok := actualChannels[chName]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("package %q, channel %q is in the FBC, but not in the cache", name, chName))
+				continue
+			}
+			chDiffs, err := diffChannel(ctx, expected, actual, name, expectedCh, actualCh)
+			if err != nil {
+				return nil, err
+			}
+			diffs = append(diffs, chDiffs...)
+		}
+	}
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+func diffChannel(ctx context.Context, expected, actual *cache, pkgName string, expectedCh, actualCh cChannel) ([]string, error) {
+	var diffs []string
+	expectedBundles, actualBundles := expectedCh.Bundles, actualCh.Bundles
+	expectedCh.Bundles, actualCh.Bundles = nil, nil
+	if !reflect.DeepEqual(expectedCh, actualCh) {
+		diffs = append(diffs, fmt.Sprintf("package %q, channel %q differs", pkgName, expectedCh.Name))
+	}
+
+	for name := range actualBundles {
+		if _, ok := expectedBundles[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("package %q, channel %q, bundle %q is in the cache, but not in the FBC", pkgName, expectedCh.Name, name))
+		}
+	}
+	for name, expectedEntry := range expectedBundles {
+		actualEntry, ok := actualBundles[name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("package %q, channel %q, bundle %q is in the FBC, but not in the cache", pkgName, expectedCh.Name, name))
+			continue
+		}
+		key := bundleKey{pkgName, expectedCh.Name, name}
+		expectedBundle, err := expected.backend.GetBundle(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("get bundle %q from FBC: %v", name, err)
+		}
+		actualBundle, err := actual.backend.GetBundle(ctx, key)
+		if err != nil {
+			diffs = append(diffs, fmt.Sprintf("package %q, channel %q, bundle %q cannot be read from the cache: %v", pkgName, expectedCh.Name, name, err))
+			continue
+		}
+		if !reflect.DeepEqual(expectedEntry, actualEntry) || !proto.Equal(expectedBundle, actualBundle) {
+			diffs = append(diffs, fmt.Sprintf("package %q, channel %q, bundle %q differs", pkgName, expectedCh.Name, name))
+		}
+	}
+	return diffs, nil
+}
+
+// Convert converts the cache in srcDir to the given format, and stores the
+// result in dstDir, which must be empty or not exist. The source cache must
+// be valid for the FBC in fbc, from which the digest of the converted cache
+// is computed.
+func Convert(ctx context.Context, srcDir string, fbc fs.FS, dstDir string, format string) error {
+	src, err := openExisting(srcDir)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if src.backend.Name() == format {
+		return fmt.Errorf("cache in %q is already in %q format", srcDir, format)
+	}
+	if err := src.CheckIntegrity(ctx, fbc); err != nil {
+		return fmt.Errorf("source cache: %v", err)
+	}
+
+	entries, err := os.ReadDir(dstDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("output directory %q is not empty", dstDir)
+	}
+
+	// ensure that generated cache is available to all future users
+	oldUmask := umask(000)
+	defer umask(oldUmask)
+
+	dst, err := newCache(dstDir, WithFormat(format))
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	staging, err := dst.startBuild()
+	if err != nil {
+		return err
+	}
+	if err := convertInto(ctx, src, staging, fbc); err != nil {
+		dst.abortBuild(staging)
+		return err
+	}
+	return dst.commitBuild(staging)
+}
+
+// convertInto copies the content of the src cache into dst, and stores the
+// digest of dst for the FBC in fbc.
+func convertInto(ctx context.Context, src *cache, dst backend, fbc fs.FS) error {
+	pi, err := src.backend.GetPackageIndex(ctx)
+	if err != nil {
+		return fmt.Errorf("get package index: %v", err)
+	}
+	for _, pkg := range pi {
+		for _, ch := range pkg.Channels {
+			for _, b := range ch.Bundles {
+				key := bundleKey{pkg.Name, ch.Name, b.Name}
+				bundle, err := src.backend.GetBundle(ctx, key)
+				if err != nil {
+					return fmt.Errorf("get bundle %q: %v", b.Name, err)
+				}
+				if err := dst.PutBundle(ctx, key, bundle); err != nil {
+					return fmt.Errorf("store bundle %q: %v", b.Name, err)
+				}
+			}
+		}
+	}
+	if err := dst.PutPackageIndex(ctx, pi); err != nil {
+		return fmt.Errorf("store package index: %v", err)
+	}
+
+	packageDigests, err := src.backend.GetPackageDigests(ctx)
+	if err != nil {
+		return fmt.Errorf("get package digests: %v", err)
+	}
+	if packageDigests != nil {
+		if err := dst.PutPackageDigests(ctx, packageDigests); err != nil {
+			return fmt.Errorf("store package digests: %v", err)
+		}
+	}
+
+	plurals, err := src.backend.GetAPIPlurals(ctx)
+	if err != nil {
+		return fmt.Errorf("get api plurals: %v", err)
+	}
+	if plurals != nil {
+		if err := dst.PutAPIPlurals(ctx, plurals); err != nil {
+			return fmt.Errorf("store api plurals: %v", err)
+		}
+	}
+
+	infos, err := src.backend.GetBundleInfos(ctx)
+	if err != nil {
+		return fmt.Errorf("get bundle infos: %v", err)
+	}
+	if infos != nil {
+		if err := dst.PutBundleInfos(ctx, infos); err != nil {
+			return fmt.Errorf("store bundle infos: %v", err)
+		}
+	}
+
+	metas, err := src.backend.OpenMetas(ctx)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("open FBC metas: %v", err)
+	}
+	if err == nil {
+		defer metas.Close()
+		if err := dst.PutMetas(ctx, metas); err != nil {
+			return fmt.Errorf("store FBC metas: %v", err)
+		}
+	}
+
+	buildTime, err := src.backend.GetBuildTime(ctx)
+	if err != nil {
+		return fmt.Errorf("get build time: %v", err)
+	}
+	if !buildTime.IsZero() {
+		if err := dst.PutBuildTime(ctx, buildTime); err != nil {
+			return fmt.Errorf("store build time: %v", err)
+		}
+	}
+
+	digest, err := dst.ComputeDigest(ctx, fbc)
+	if err != nil {
+		return fmt.Errorf("compute digest: %v", err)
+	}
+	if err := dst.PutDigest(ctx, digest); err != nil {
+		return fmt.Errorf("store digest: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+func buildTestCache(t *testing.T, format string, fbc fstest.MapFS) string {
+	t.Helper()
+	dir := t.TempDir()
+	c, err := New(dir, WithFormat(format))
+	require.NoError(t, err)
+	defer c.Close()
+	require.NoError(t, c.Build(context.Background(), fbc))
+	return dir
+}
+
+func TestInspect(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			dir := buildTestCache(t, format, validFS)
+
+			info, err := Inspect(context.Background(), dir)
+			require.NoError(t, err)
+			require.Equal(t, format, info.Backend)
+			require.NotEmpty(t, info.Digest)
+			require.Equal(t, 11, info.Bundles)
+			require.Len(t, info.Packages, 2)
+			require.Equal(t, PackageInfo{
+				Name:           "cockroachdb",
+				DefaultChannel: "stable-5.x",
+				Channels: []ChannelInfo{
+					{Name: "stable", Head: "cockroachdb.v2.1.11", Bundles: 3},
+					{Name: "stable-3.x", Head: "cockroachdb.v3.0.7", Bundles: 1},
+					{Name: "stable-5.x", Head: "cockroachdb.v5.0.3", Bundles: 1},
+				},
+			}, info.Packages[0])
+			require.Equal(t, "etcd", info.Packages[1].Name)
+		})
+	}
+
+	t.Run("NoCache", func(t *testing.T) {
+		_, err := Inspect(context.Background(), t.TempDir())
+		require.ErrorContains(t, err, "no cache found")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	withoutEtcd := fstest.MapFS{}
+	for name, f := range validFS {
+		if name != "etcd.json" {
+			withoutEtcd[name] = f
+		}
+	}
+
+	type spec struct {
+		name          string
+		fbc           fstest.MapFS
+		modify        func(t *testing.T, dir string)
+		expectedDiffs []string
+	}
+	specs := []spec{
+		{
+			name: "Valid",
+			fbc:  validFS,
+		},
+		{
+			name: "PackageNotInFBC",
+			fbc:  withoutEtcd,
+			expectedDiffs: []string{
+				`cache digest`,
+				`package "etcd" is in the cache, but not in the FBC`,
+			},
+		},
+		{
+			name: "ModifiedBundle",
+			fbc:  validFS,
+			modify: func(t *testing.T, dir string) {
+				c, err := newCache(dir)
+				require.NoError(t, err)
+				defer c.Close()
+				key := bundleKey{"cockroachdb", "stable-5.x", "cockroachdb.v5.0.3"}
+				b, err := c.backend.GetBundle(context.Background(), key)
+				require.NoError(t, err)
+				b.BundlePath = "quay.io/tampered"
+				require.NoError(t, c.backend.PutBundle(context.Background(), key, b))
+			},
+			expectedDiffs: []string{
+				`cache digest`,
+				`package "cockroachdb", channel "stable-5.x", bundle "cockroachdb.v5.0.3" differs`,
+			},
+		},
+		{
+			name: "ExtraBundle",
+			fbc:  validFS,
+			modify: func(t *testing.T, dir string) {
+				c, err := newCache(dir)
+				require.NoError(t, err)
+				defer c.Close()
+				require.NoError(t, c.backend.PutBundle(context.Background(), bundleKey{"foo", "bar", "baz"}, &api.Bundle{CsvName: "baz"}))
+			},
+			expectedDiffs: []string{
+				`cache digest`,
+			},
+		},
+	}
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		for _, s := range specs {
+			t.Run(format+"/"+s.name, func(t *testing.T) {
+				dir := buildTestCache(t, format, validFS)
+				if s.modify != nil {
+					s.modify(t, dir)
+				}
+				diffs, err := Verify(context.Background(), dir, s.fbc)
+				require.NoError(t, err)
+				require.Len(t, diffs, len(s.expectedDiffs), diffs)
+				for i, expected := range s.expectedDiffs {
+					require.Contains(t, diffs[i], expected)
+				}
+			})
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	type spec struct {
+		from, to string
+	}
+	specs := []spec{
+		{from: FormatJSON, to: FormatPogrebV1},
+		{from: FormatPogrebV1, to: FormatJSON},
+	}
+	for _, s := range specs {
+		t.Run(s.from+"->"+s.to, func(t *testing.T) {
+			ctx := context.Background()
+			src := buildTestCache(t, s.from, validFS)
+			dst := filepath.Join(t.TempDir(), "converted")
+			require.NoError(t, Convert(ctx, src, validFS, dst, s.to))
+
+			// The converted cache is identical to a cache built from the FBC
+			// in the target format.
+			converted, err := Inspect(ctx, dst)
+			require.NoError(t, err)
+			built, err := Inspect(ctx, buildTestCache(t, s.to, validFS))
+			require.NoError(t, err)
+			require.Equal(t, built, converted)
+
+			diffs, err := Verify(ctx, dst, validFS)
+			require.NoError(t, err)
+			require.Empty(t, diffs)
+
+			c, err := New(dst)
+			require.NoError(t, err)
+			defer c.Close()
+			require.NoError(t, c.CheckIntegrity(ctx, validFS))
+		})
+	}
+
+	t.Run("SameFormat", func(t *testing.T) {
+		src := buildTestCache(t, FormatJSON, validFS)
+		err := Convert(context.Background(), src, validFS, t.TempDir(), FormatJSON)
+		require.ErrorContains(t, err, "already in")
+	})
+	t.Run("InvalidSource", func(t *testing.T) {
+		src := buildTestCache(t, FormatJSON, validFS)
+		err := Convert(context.Background(), src, badBundleFS, t.TempDir(), FormatPogrebV1)
+		require.ErrorContains(t, err, "cache requires rebuild")
+	})
+	t.Run("NonEmptyOutput", func(t *testing.T) {
+		src := buildTestCache(t, FormatJSON, validFS)
+		dst := buildTestCache(t, FormatJSON, validFS)
+		err := Convert(context.Background(), src, validFS, dst, FormatPogrebV1)
+		require.ErrorContains(t, err, "is not empty")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -30,9 +31,14 @@ const (
 	jsonCacheModeDir  = 0750
 	jsonCacheModeFile = 0640
 
-	jsonDigestFile   = "digest"
-	jsonDir          = "cache"
-	jsonPackagesFile = jsonDir + string(filepath.Separator) + "packages.json"
+	jsonDigestFile         = "digest"
+	jsonPackageDigestsFile = "package-digests.json"
+	jsonAPIPluralsFile     = "api-plurals.json"
+	jsonMetasFile          = "metas.jsonl"
+	jsonBundleInfosFile    = "bundle-infos.json"
+	jsonBuildTimeFile      = "build-time"
+	jsonDir                = "cache"
+	jsonPackagesFile       = jsonDir + string(filepath.Separator) + "packages.json"
 )
 
 type jsonBackend struct {
@@ -46,6 +52,14 @@ func (q *jsonBackend) Name() string {
 	return FormatJSON
 }
 
+func (q *jsonBackend) Dir() string {
+	return q.baseDir
+}
+
+func (q *jsonBackend) Entries() []string {
+	return []string{jsonDir, jsonPackageDigestsFile, jsonAPIPluralsFile, jsonMetasFile, jsonBundleInfosFile, jsonBuildTimeFile, jsonDigestFile}
+}
+
 func (q *jsonBackend) IsCachePresent() bool {
 	entries, err := os.ReadDir(q.baseDir)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -70,6 +84,9 @@ func (q *jsonBackend) Init() error {
 	if err := os.RemoveAll(filepath.Join(q.baseDir, jsonDigestFile)); err != nil {
 		return fmt.Errorf("failed to remove existing JSON digest file: %v", err)
 	}
+	if err := os.RemoveAll(filepath.Join(q.baseDir, jsonPackageDigestsFile)); err != nil {
+		return fmt.Errorf("failed to remove existing JSON package digests file: %v", err)
+	}
 	q.bundles = newBundleKeys()
 	return nil
 }
@@ -140,10 +157,26 @@ func (q *jsonBackend) PutBundle(_ context.Context, key bundleKey, bundle *api.Bu
 	return nil
 }
 
+func (q *jsonBackend) DeleteBundle(_ context.Context, key bundleKey) error {
+	if err := os.Remove(q.bundleFile(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	q.bundles.Delete(key)
+	return nil
+}
+
 func (q *jsonBackend) GetDigest(_ context.Context) (string, error) {
 	return readDigestFile(filepath.Join(q.baseDir, jsonDigestFile))
 }
 
+func (q *jsonBackend) GetBuildTime(_ context.Context) (time.Time, error) {
+	return readBuildTimeFile(filepath.Join(q.baseDir, jsonBuildTimeFile))
+}
+
+func (q *jsonBackend) PutBuildTime(_ context.Context, buildTime time.Time) error {
+	return writeBuildTimeFile(filepath.Join(q.baseDir, jsonBuildTimeFile), buildTime, jsonCacheModeFile)
+}
+
 func (q *jsonBackend) ComputeDigest(_ context.Context, fbcFsys fs.FS) (string, error) {
 	// We are not sensitive to the size of this buffer, we just need it to be shared.
 	// For simplicity, do the same as io.Copy() would.
@@ -165,11 +198,46 @@ func (q *jsonBackend) PutDigest(_ context.Context, digest string) error {
 	return writeDigestFile(filepath.Join(q.baseDir, jsonDigestFile), digest, jsonCacheModeFile)
 }
 
-func (q *jsonBackend) SendBundles(_ context.Context, s registry.BundleSender) error {
+func (q *jsonBackend) GetPackageDigests(_ context.Context) (map[string]string, error) {
+	return readPackageDigestsFile(filepath.Join(q.baseDir, jsonPackageDigestsFile))
+}
+
+func (q *jsonBackend) PutPackageDigests(_ context.Context, digests map[string]string) error {
+	return writePackageDigestsFile(filepath.Join(q.baseDir, jsonPackageDigestsFile), digests, jsonCacheModeFile)
+}
+
+func (q *jsonBackend) GetAPIPlurals(_ context.Context) (apiPlurals, error) {
+	return readAPIPluralsFile(filepath.Join(q.baseDir, jsonAPIPluralsFile))
+}
+
+func (q *jsonBackend) PutAPIPlurals(_ context.Context, plurals apiPlurals) error {
+	return writeAPIPluralsFile(filepath.Join(q.baseDir, jsonAPIPluralsFile), plurals, jsonCacheModeFile)
+}
+
+func (q *jsonBackend) GetBundleInfos(_ context.Context) (bundleInfos, error) {
+	return readBundleInfosFile(filepath.Join(q.baseDir, jsonBundleInfosFile))
+}
+
+func (q *jsonBackend) PutBundleInfos(_ context.Context, infos bundleInfos) error {
+	return writeBundleInfosFile(filepath.Join(q.baseDir, jsonBundleInfosFile), infos, jsonCacheModeFile)
+}
+
+func (q *jsonBackend) OpenMetas(_ context.Context) (io.ReadCloser, error) {
+	return openMetasFile(filepath.Join(q.baseDir, jsonMetasFile))
+}
+
+func (q *jsonBackend) PutMetas(_ context.Context, metas io.Reader) error {
+	return writeMetasFile(filepath.Join(q.baseDir, jsonMetasFile), metas, jsonCacheModeFile)
+}
+
+func (q *jsonBackend) SendBundles(_ context.Context, s registry.BundleSender, selectKey func(bundleKey) bool) error {
 	keys := make([]bundleKey, 0, q.bundles.Len())
 	files := make([]*os.File, 0, q.bundles.Len())
 	readers := make([]io.Reader, 0, q.bundles.Len())
 	if err := q.bundles.Walk(func(key bundleKey) error {
+		if !selectKey(key) {
+			return nil
+		}
 		file, err := os.Open(q.bundleFile(key))
 		if err != nil {
 			return fmt.Errorf("failed to open file for package %q, channel %q, key %q: %w", key.PackageName, key.ChannelName, key.Name, err)
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+)
+
+// MetaFilter selects FBC metas by schema, package and name. Empty fields
+// match any value. The package of an olm.package meta is its name.
+type MetaFilter struct {
+	Schema  string
+	Package string
+	Name    string
+}
+
+func (f MetaFilter) matches(m *declcfg.Meta) bool {
+	pkg := m.Package
+	if m.Schema == declcfg.SchemaPackage {
+		pkg = m.Name
+	}
+	return (f.Schema == "" || f.Schema == m.Schema) &&
+		(f.Package == "" || f.Package == pkg) &&
+		(f.Name == "" || f.Name == m.Name)
+}
+
+// MetaSender is implemented by the caches returned by New, which store the
+// FBC metas that they were built from, so that the metas can be served
+// without the FBC.
+type MetaSender interface {
+	// SendMetas calls send with each meta that matches filter. The metas
+	// are grouped by package, in order of package name, and the metas of a
+	// package are in the order in which they were read. The blob of each
+	// meta is compact JSON.
+	SendMetas(ctx context.Context, filter MetaFilter, send func(*declcfg.Meta) error) error
+}
+
+// SendMetas calls c.SendMetas if c is a MetaSender, and fails otherwise.
+// It is intended for wrappers of caches, which implement MetaSender by
+// forwarding to the cache they wrap.
+func SendMetas(ctx context.Context, c Cache, filter MetaFilter, send func(*declcfg.Meta) error) error {
+	s, ok := c.(MetaSender)
+	if !ok {
+		return fmt.Errorf("cache %T does not store FBC metas", c)
+	}
+	return s.SendMetas(ctx, filter, send)
+}
+
+func (c *cache) SendMetas(ctx context.Context, filter MetaFilter, send func(*declcfg.Meta) error) error {
+	metas, err := c.backend.OpenMetas(ctx)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("FBC metas %w in cache, rebuild the cache to serve them", ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("open FBC metas: %v", err)
+	}
+	defer metas.Close()
+
+	r := bufio.NewReader(metas)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(line) == 0 {
+			return nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("read FBC metas: %v", err)
+		}
+		var header struct {
+			Schema  string `json:"schema"`
+			Package string `json:"package"`
+			Name    string `json:"name"`
+		}
+		if err := json.Unmarshal(line, &header); err != nil {
+			return fmt.Errorf("decode FBC meta: %v", err)
+		}
+		blob := bytes.TrimSuffix(line, []byte("\n"))
+		meta := &declcfg.Meta{Schema: header.Schema, Package: header.Package, Name: header.Name, Blob: blob}
+		if !filter.matches(meta) {
+			continue
+		}
+		if err := send(meta); err != nil {
+			return err
+		}
+	}
+}
+
+// openMetasFile opens the metas stored in file.
+func openMetasFile(file string) (io.ReadCloser, error) {
+	return os.Open(file)
+}
+
+// writeMetasFile stores the metas read from metas in file.
+func writeMetasFile(file string, metas io.Reader, mode os.FileMode) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, metas); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
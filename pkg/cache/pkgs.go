@@ -24,7 +24,7 @@ func (pkgs packageIndex) ListPackages(_ context.Context) ([]string, error) {
 func (pkgs packageIndex) GetPackage(_ context.Context, name string) (*registry.PackageManifest, error) {
 	pkg, ok := pkgs[name]
 	if !ok {
-		return nil, fmt.Errorf("package %q not found", name)
+		return nil, fmt.Errorf("package %q %w", name, ErrNotFound)
 	}
 
 	var channels []registry.PackageChannel
@@ -72,11 +72,11 @@ type getBundleFunc func(context.Context, bundleKey) (*api.Bundle, error)
 func (pkgs packageIndex) GetBundleForChannel(ctx context.Context, getBundle getBundleFunc, pkgName string, channelName string) (*api.Bundle, error) {
 	pkg, ok := pkgs[pkgName]
 	if !ok {
-		return nil, fmt.Errorf("package %q not found", pkgName)
+		return nil, fmt.Errorf("package %q %w", pkgName, ErrNotFound)
 	}
 	ch, ok := pkg.Channels[channelName]
 	if !ok {
-		return nil, fmt.Errorf("package %q, channel %q not found", pkgName, channelName)
+		return nil, fmt.Errorf("package %q, channel %q %w", pkgName, channelName, ErrNotFound)
 	}
 	return getBundle(ctx, bundleKey{pkg.Name, ch.Name, ch.Head})
 }
@@ -84,11 +84,11 @@ func (pkgs packageIndex) GetBundleForChannel(ctx context.Context, getBundle getB
 func (pkgs packageIndex) GetBundleThatReplaces(ctx context.Context, getBundle getBundleFunc, name, pkgName, channelName string) (*api.Bundle, error) {
 	pkg, ok := pkgs[pkgName]
 	if !ok {
-		return nil, fmt.Errorf("package %s not found", pkgName)
+		return nil, fmt.Errorf("package %s %w", pkgName, ErrNotFound)
 	}
 	ch, ok := pkg.Channels[channelName]
 	if !ok {
-		return nil, fmt.Errorf("package %q, channel %q not found", pkgName, channelName)
+		return nil, fmt.Errorf("package %q, channel %q %w", pkgName, channelName, ErrNotFound)
 	}
 
 	// NOTE: iterating over a map is non-deterministic in Go, so if multiple bundles replace this one,
@@ -187,6 +187,75 @@ func (pkgs packageIndex) GetBundleThatProvides(ctx context.Context, c Cache, gro
 	return nil, fmt.Errorf("no entry found that provides group:%q version:%q kind:%q", group, version, kind)
 }
 
+func (pkgs packageIndex) ListChannelEntries(_ context.Context, pkgName, channelName string) ([]*registry.ChannelEntry, error) {
+	pkg, ok := pkgs[pkgName]
+	if !ok {
+		return nil, fmt.Errorf("package %q %w", pkgName, ErrNotFound)
+	}
+	ch, ok := pkg.Channels[channelName]
+	if !ok {
+		return nil, fmt.Errorf("package %q, channel %q %w", pkgName, channelName, ErrNotFound)
+	}
+
+	var entries []*registry.ChannelEntry
+	for _, b := range ch.Bundles {
+		entries = append(entries, pkgs.channelEntriesForBundle(b, false)...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].BundleName != entries[j].BundleName {
+			return entries[i].BundleName < entries[j].BundleName
+		}
+		return entries[i].Replaces < entries[j].Replaces
+	})
+	return entries, nil
+}
+
+func (pkgs packageIndex) GetDeprecations(ctx context.Context, getBundle getBundleFunc, pkgName string) (*api.Deprecations, error) {
+	pkg, ok := pkgs[pkgName]
+	if !ok {
+		return nil, fmt.Errorf("package %q %w", pkgName, ErrNotFound)
+	}
+
+	deprecations := &api.Deprecations{PackageName: pkg.Name}
+	if pkg.Deprecation != nil {
+		deprecations.Deprecation = &api.Deprecation{Message: pkg.Deprecation.Message}
+	}
+
+	// Bundle deprecations are stored with the bundles, which are read once
+	// even if they are in more than one channel.
+	bundleKeys := map[string]bundleKey{}
+	for _, ch := range pkg.Channels {
+		if ch.Deprecation != nil {
+			deprecations.Channels = append(deprecations.Channels, &api.ChannelDeprecation{
+				ChannelName: ch.Name,
+				Deprecation: &api.Deprecation{Message: ch.Deprecation.Message},
+			})
+		}
+		for _, b := range ch.Bundles {
+			bundleKeys[b.Name] = bundleKey{pkg.Name, ch.Name, b.Name}
+		}
+	}
+	for _, key := range bundleKeys {
+		b, err := getBundle(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("get bundle %q: %v", key.Name, err)
+		}
+		if b.Deprecation != nil {
+			deprecations.Bundles = append(deprecations.Bundles, &api.BundleDeprecation{
+				CsvName:     key.Name,
+				Deprecation: b.Deprecation,
+			})
+		}
+	}
+	sort.Slice(deprecations.Channels, func(i, j int) bool {
+		return deprecations.Channels[i].ChannelName < deprecations.Channels[j].ChannelName
+	})
+	sort.Slice(deprecations.Bundles, func(i, j int) bool {
+		return deprecations.Bundles[i].CsvName < deprecations.Bundles[j].CsvName
+	})
+	return deprecations, nil
+}
+
 type cPkg struct {
 	Name           string      `json:"name"`
 	Description    string      `json:"description"`
@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/operator-registry/alpha/model"
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+// apiPlurals holds, for each package, the resource plurals of the APIs that
+// its bundles provide. It is built from the CRD objects and olm.csv.metadata
+// properties of the bundles, and is stored next to the cache rather than in
+// it, so that it does not change the cache digest.
+type apiPlurals map[string][]apiPlural
+
+type apiPlural struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Plural  string `json:"plural"`
+}
+
+// groupVersionPlural identifies an API by its resource plural.
+type groupVersionPlural struct {
+	group, version, plural string
+}
+
+// pluralIndex maps the resource plurals of the APIs in a cache to their
+// kinds.
+type pluralIndex map[groupVersionPlural]string
+
+func (p apiPlurals) index() pluralIndex {
+	idx := pluralIndex{}
+	for _, plurals := range p {
+		for _, pl := range plurals {
+			idx[groupVersionPlural{pl.Group, pl.Version, pl.Plural}] = pl.Kind
+		}
+	}
+	return idx
+}
+
+func (idx pluralIndex) GetKindForPlural(group, version, plural string) (string, error) {
+	kind, ok := idx[groupVersionPlural{group, version, plural}]
+	if !ok {
+		return "", fmt.Errorf("api with group %q, version %q, plural %q %w", group, version, plural, ErrNotFound)
+	}
+	return kind, nil
+}
+
+// crdObject holds the fields of a v1 or v1beta1 CustomResourceDefinition
+// that name the APIs it defines.
+type crdObject struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Names   struct {
+			Plural string `json:"plural"`
+			Kind   string `json:"kind"`
+		} `json:"names"`
+		Versions []struct {
+			Name string `json:"name"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// csvObject holds the fields of a ClusterServiceVersion that name the APIs
+// that it owns.
+type csvObject struct {
+	Spec struct {
+		CustomResourceDefinitions struct {
+			Owned []struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+				Kind    string `json:"kind"`
+			} `json:"owned"`
+		} `json:"customresourcedefinitions"`
+		APIServiceDefinitions struct {
+			Owned []struct {
+				Group   string `json:"group"`
+				Version string `json:"version"`
+				Kind    string `json:"kind"`
+				Name    string `json:"name"`
+			} `json:"owned"`
+		} `json:"apiservicedefinitions"`
+	} `json:"spec"`
+}
+
+// pluralSet collects the API plurals of the bundles of a package.
+type pluralSet map[apiPlural]struct{}
+
+func (s pluralSet) add(group, version, kind, plural string) {
+	if group == "" || version == "" || kind == "" || plural == "" {
+		return
+	}
+	s[apiPlural{Group: group, Version: version, Kind: kind, Plural: plural}] = struct{}{}
+}
+
+// addObjects adds the APIs defined by the CRDs among objs.
+func (s pluralSet) addObjects(objs []string) {
+	for _, obj := range objs {
+		// Objects that cannot be decoded are not CRDs that OLM could
+		// install, so they are skipped.
+		var crd crdObject
+		if err := yaml.Unmarshal([]byte(obj), &crd); err != nil || crd.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		for _, v := range crd.Spec.Versions {
+			s.add(crd.Spec.Group, v.Name, crd.Spec.Names.Kind, crd.Spec.Names.Plural)
+		}
+		s.add(crd.Spec.Group, crd.Spec.Version, crd.Spec.Names.Kind, crd.Spec.Names.Plural)
+	}
+}
+
+// addOwnedCRD adds an owned CRD, which is named <plural>.<group>.
+func (s pluralSet) addOwnedCRD(name, version, kind string) {
+	plural, group, _ := strings.Cut(name, ".")
+	s.add(group, version, kind, plural)
+}
+
+// sorted returns the plurals of s, sorted.
+func (s pluralSet) sorted() []apiPlural {
+	plurals := make([]apiPlural, 0, len(s))
+	for p := range s {
+		plurals = append(plurals, p)
+	}
+	sort.Slice(plurals, func(i, j int) bool {
+		a, b := plurals[i], plurals[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		if a.Plural != b.Plural {
+			return a.Plural < b.Plural
+		}
+		return a.Kind < b.Kind
+	})
+	return plurals
+}
+
+// pluralsFromModel returns the sorted, unique API plurals of the bundles of
+// a package.
+func pluralsFromModel(pkg *model.Package) []apiPlural {
+	s := pluralSet{}
+	for _, ch := range pkg.Channels {
+		for _, b := range ch.Bundles {
+			s.addObjects(b.Objects)
+			if b.PropertiesP == nil {
+				continue
+			}
+			for _, md := range b.PropertiesP.CSVMetadatas {
+				for _, owned := range md.CustomResourceDefinitions.Owned {
+					s.addOwnedCRD(owned.Name, owned.Version, owned.Kind)
+				}
+				for _, owned := range md.APIServiceDefinitions.Owned {
+					s.add(owned.Group, owned.Version, owned.Kind, owned.Name)
+				}
+			}
+		}
+	}
+	return s.sorted()
+}
+
+// addBundle adds the APIs defined by the CRD objects of b, and the APIs owned
+// by its CSV. It is used for caches that were built without API plurals, in
+// which the olm.csv.metadata properties of bundles are only stored as CSVs.
+func (s pluralSet) addBundle(b *api.Bundle) {
+	s.addObjects(b.GetObject())
+	if b.GetCsvJson() == "" {
+		return
+	}
+	var csv csvObject
+	if err := json.Unmarshal([]byte(b.GetCsvJson()), &csv); err != nil {
+		return
+	}
+	for _, owned := range csv.Spec.CustomResourceDefinitions.Owned {
+		s.addOwnedCRD(owned.Name, owned.Version, owned.Kind)
+	}
+	for _, owned := range csv.Spec.APIServiceDefinitions.Owned {
+		s.add(owned.Group, owned.Version, owned.Kind, owned.Name)
+	}
+}
+
+// readAPIPluralsFile returns the API plurals stored in file, or nil if file
+// does not exist.
+func readAPIPluralsFile(file string) (apiPlurals, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var plurals apiPlurals
+	if err := json.Unmarshal(data, &plurals); err != nil {
+		return nil, err
+	}
+	return plurals, nil
+}
+
+// writeAPIPluralsFile stores plurals in file, or removes file if plurals is
+// nil.
+func writeAPIPluralsFile(file string, plurals apiPlurals, mode os.FileMode) error {
+	if plurals == nil {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(plurals)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, mode)
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/akrylysov/pogreb"
 	pogrebfs "github.com/akrylysov/pogreb/fs"
@@ -36,21 +37,37 @@ const (
 	pogrebV1CacheModeDir  = 0770
 	pogrebV1CacheModeFile = 0660
 
-	pograbV1CacheDir = FormatPogrebV1
-	pogrebDigestFile = pograbV1CacheDir + "/digest"
-	pogrebDbDir      = pograbV1CacheDir + "/db"
+	pograbV1CacheDir         = FormatPogrebV1
+	pogrebDigestFile         = pograbV1CacheDir + "/digest"
+	pogrebPackageDigestsFile = pograbV1CacheDir + "/package-digests.json"
+	pogrebAPIPluralsFile     = pograbV1CacheDir + "/api-plurals.json"
+	pogrebMetasFile          = pograbV1CacheDir + "/metas.jsonl"
+	pogrebBundleInfosFile    = pograbV1CacheDir + "/bundle-infos.json"
+	pogrebBuildTimeFile      = pograbV1CacheDir + "/build-time"
+	pogrebDbDir              = pograbV1CacheDir + "/db"
 )
 
 type pogrebV1Backend struct {
 	baseDir string
 	db      *pogreb.DB
 	bundles bundleKeys
+	// compact is set when bundles are deleted, so that the space that they
+	// take up is reclaimed when the DB is closed.
+	compact bool
 }
 
 func (q *pogrebV1Backend) Name() string {
 	return FormatPogrebV1
 }
 
+func (q *pogrebV1Backend) Dir() string {
+	return q.baseDir
+}
+
+func (q *pogrebV1Backend) Entries() []string {
+	return []string{pograbV1CacheDir}
+}
+
 func (q *pogrebV1Backend) IsCachePresent() bool {
 	entries, err := os.ReadDir(q.baseDir)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -88,6 +105,12 @@ func (q *pogrebV1Backend) Close() error {
 	if q.db == nil {
 		return nil
 	}
+	if q.compact {
+		if _, err := q.db.Compact(); err != nil {
+			return fmt.Errorf("compact DB: %v", err)
+		}
+		q.compact = false
+	}
 	if err := q.db.Close(); err != nil {
 		return err
 	}
@@ -163,6 +186,15 @@ func (q *pogrebV1Backend) PutBundle(_ context.Context, key bundleKey, bundle *ap
 	return nil
 }
 
+func (q *pogrebV1Backend) DeleteBundle(_ context.Context, key bundleKey) error {
+	if err := q.db.Delete(q.dbKey(key)); err != nil {
+		return err
+	}
+	q.bundles.Delete(key)
+	q.compact = true
+	return nil
+}
+
 func (q *pogrebV1Backend) GetDigest(_ context.Context) (string, error) {
 	return readDigestFile(filepath.Join(q.baseDir, pogrebDigestFile))
 }
@@ -198,6 +230,14 @@ func (q *pogrebV1Backend) writeKeyValue(w io.Writer, k []byte) error {
 	return nil
 }
 
+func (q *pogrebV1Backend) GetBuildTime(_ context.Context) (time.Time, error) {
+	return readBuildTimeFile(filepath.Join(q.baseDir, pogrebBuildTimeFile))
+}
+
+func (q *pogrebV1Backend) PutBuildTime(_ context.Context, buildTime time.Time) error {
+	return writeBuildTimeFile(filepath.Join(q.baseDir, pogrebBuildTimeFile), buildTime, pogrebV1CacheModeFile)
+}
+
 func (q *pogrebV1Backend) ComputeDigest(ctx context.Context, fbcFsys fs.FS) (string, error) {
 	computedHasher := fnv.New64a()
 
@@ -231,8 +271,43 @@ func (q *pogrebV1Backend) PutDigest(_ context.Context, digest string) error {
 	return writeDigestFile(filepath.Join(q.baseDir, pogrebDigestFile), digest, pogrebV1CacheModeFile)
 }
 
-func (q *pogrebV1Backend) SendBundles(_ context.Context, s registry.BundleSender) error {
+func (q *pogrebV1Backend) GetPackageDigests(_ context.Context) (map[string]string, error) {
+	return readPackageDigestsFile(filepath.Join(q.baseDir, pogrebPackageDigestsFile))
+}
+
+func (q *pogrebV1Backend) PutPackageDigests(_ context.Context, digests map[string]string) error {
+	return writePackageDigestsFile(filepath.Join(q.baseDir, pogrebPackageDigestsFile), digests, pogrebV1CacheModeFile)
+}
+
+func (q *pogrebV1Backend) GetAPIPlurals(_ context.Context) (apiPlurals, error) {
+	return readAPIPluralsFile(filepath.Join(q.baseDir, pogrebAPIPluralsFile))
+}
+
+func (q *pogrebV1Backend) PutAPIPlurals(_ context.Context, plurals apiPlurals) error {
+	return writeAPIPluralsFile(filepath.Join(q.baseDir, pogrebAPIPluralsFile), plurals, pogrebV1CacheModeFile)
+}
+
+func (q *pogrebV1Backend) GetBundleInfos(_ context.Context) (bundleInfos, error) {
+	return readBundleInfosFile(filepath.Join(q.baseDir, pogrebBundleInfosFile))
+}
+
+func (q *pogrebV1Backend) PutBundleInfos(_ context.Context, infos bundleInfos) error {
+	return writeBundleInfosFile(filepath.Join(q.baseDir, pogrebBundleInfosFile), infos, pogrebV1CacheModeFile)
+}
+
+func (q *pogrebV1Backend) OpenMetas(_ context.Context) (io.ReadCloser, error) {
+	return openMetasFile(filepath.Join(q.baseDir, pogrebMetasFile))
+}
+
+func (q *pogrebV1Backend) PutMetas(_ context.Context, metas io.Reader) error {
+	return writeMetasFile(filepath.Join(q.baseDir, pogrebMetasFile), metas, pogrebV1CacheModeFile)
+}
+
+func (q *pogrebV1Backend) SendBundles(_ context.Context, s registry.BundleSender, selectKey func(bundleKey) bool) error {
 	return q.bundles.Walk(func(key bundleKey) error {
+		if !selectKey(key) {
+			return nil
+		}
 		bundleData, err := q.db.Get(q.dbKey(key))
 		if err != nil {
 			return fmt.Errorf("failed to get data for package %q, channel %q, key %q: %w", key.PackageName, key.ChannelName, key.Name, err)
@@ -31,6 +31,18 @@ func TestPogrebV1_StableDigest(t *testing.T) {
 	require.Equal(t, "485a767449dd66d4", actualDigest)
 }
 
+func TestPogrebV1_CompressedDigest(t *testing.T) {
+	// The digest is computed from the decoded FBC blobs, so compressing
+	// the FBC files must not change it.
+	cacheDir := t.TempDir()
+	c := &cache{backend: newPogrebV1Backend(cacheDir), log: log.Null()}
+	require.NoError(t, c.Build(context.Background(), compressFS(t, validFS)))
+
+	actualDigest, err := c.backend.GetDigest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "485a767449dd66d4", actualDigest)
+}
+
 func TestPogrebV1_CheckIntegrity(t *testing.T) {
 	type testCase struct {
 		name   string
@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// buildDirPrefix is the prefix of the staging directories in which
+	// caches are built, before they are moved into the cache directory.
+	buildDirPrefix = ".build-"
+	// oldCacheDir is the directory in a staging directory to which the
+	// previous cache is moved while a build is committed.
+	oldCacheDir = ".old"
+
+	cacheModeDir = 0755
+)
+
+// newBackend returns the backend with the given name for cacheDir.
+func newBackend(name string, cacheDir string) (backend, error) {
+	for _, b := range newBackends(cacheDir) {
+		if b.Name() == name {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown backend %q", name)
+}
+
+// startBuild creates a staging directory in the cache directory, and returns
+// an initialized backend in it with the same format as the cache. The
+// staging directory is in the cache directory so that the built cache can be
+// renamed into place, even if the cache directory is a mount point.
+func (c *cache) startBuild() (backend, error) {
+	cacheDir := c.backend.Dir()
+	if err := os.MkdirAll(cacheDir, cacheModeDir); err != nil {
+		return nil, fmt.Errorf("create cache directory: %v", err)
+	}
+	buildDir, err := os.MkdirTemp(cacheDir, buildDirPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("create build directory: %v", err)
+	}
+	b, err := newBackend(c.backend.Name(), buildDir)
+	if err != nil {
+		os.RemoveAll(buildDir)
+		return nil, err
+	}
+	staging := &tracingBackend{backend: b}
+	if err := staging.Init(); err != nil {
+		os.RemoveAll(buildDir)
+		return nil, fmt.Errorf("init cache: %v", err)
+	}
+	return staging, nil
+}
+
+// abortBuild discards a build that was started with startBuild.
+func (c *cache) abortBuild(staging backend) {
+	if err := staging.Close(); err != nil {
+		c.log.WithError(err).Warn("unable to close aborted cache build")
+	}
+	if err := os.RemoveAll(staging.Dir()); err != nil {
+		c.log.WithError(err).Warn("unable to remove aborted cache build")
+	}
+}
+
+// commitBuild replaces the cache with a build that was started with
+// startBuild, and reopens the cache. If the build cannot be committed, the
+// previous cache is restored.
+func (c *cache) commitBuild(staging backend) error {
+	cacheDir, buildDir := c.backend.Dir(), staging.Dir()
+	if err := staging.Close(); err != nil {
+		c.abortBuild(staging)
+		return fmt.Errorf("close built cache: %v", err)
+	}
+	if err := c.backend.Close(); err != nil {
+		c.abortBuild(staging)
+		return fmt.Errorf("close cache: %v", err)
+	}
+
+	swapErr := swapEntries(cacheDir, buildDir, c.backend.Entries())
+	if swapErr != nil {
+		swapErr = fmt.Errorf("replace cache: %v", swapErr)
+		if err := recoverBuild(cacheDir, buildDir); err != nil {
+			return fmt.Errorf("%v, and restoring the previous cache failed: %v", swapErr, err)
+		}
+	} else if err := os.RemoveAll(buildDir); err != nil {
+		c.log.WithError(err).Warn("unable to remove cache build directory")
+	}
+
+	b, err := newBackend(c.backend.Name(), cacheDir)
+	if err != nil {
+		return err
+	}
+	c.backend = &tracingBackend{backend: b}
+	if err := c.backend.Open(); err != nil {
+		return fmt.Errorf("open cache: %v", err)
+	}
+	return swapErr
+}
+
+// rename is os.Rename. Tests replace it to make swaps fail.
+var rename = os.Rename
+
+// swapEntries moves the given entries of cacheDir aside into the build
+// directory, and then moves the entries of the build directory into
+// cacheDir.
+//
+// The entries of a cache cannot all be renamed into place at once, but the
+// last entry marks a complete cache: it is moved aside first and moved into
+// place last, so that cacheDir only contains it while all the other entries
+// of the same cache are in place. If an entry cannot be moved, the swap
+// stops, and if the swap is interrupted, recoverBuild moves the entries of
+// the previous cache back into place, so that cacheDir always ends up with
+// either the previous cache or the new one, as if it had been renamed into
+// place.
+//
+// Entries that are in the lower layers of an overlay filesystem, e.g. caches
+// that are built into a catalog image, cannot be renamed, so they are
+// removed instead. The previous cache can then no longer be restored, so its
+// last entry is removed too, so that a partially restored cache is never
+// mistaken for a complete one.
+func swapEntries(cacheDir, buildDir string, entries []string) error {
+	oldDir := filepath.Join(buildDir, oldCacheDir)
+	if err := os.Mkdir(oldDir, cacheModeDir); err != nil {
+		return err
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := filepath.Join(cacheDir, entries[i])
+		err := rename(entry, filepath.Join(oldDir, entries[i]))
+		if errors.Is(err, syscall.EXDEV) || errors.Is(err, syscall.ENOTEMPTY) {
+			err = os.RemoveAll(entry)
+			if err == nil {
+				err = os.RemoveAll(filepath.Join(oldDir, entries[len(entries)-1]))
+			}
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	for _, entry := range entries {
+		if err := rename(filepath.Join(buildDir, entry), filepath.Join(cacheDir, entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverBuilds removes the staging directories that were left in cacheDir
+// by interrupted builds. If a build was interrupted while it was committed,
+// the previous cache is restored.
+func recoverBuilds(cacheDir string, log *logrus.Entry) error {
+	buildDirs, err := filepath.Glob(filepath.Join(cacheDir, buildDirPrefix+"*"))
+	if err != nil {
+		return err
+	}
+	for _, buildDir := range buildDirs {
+		if err := recoverBuild(cacheDir, buildDir); err != nil {
+			return fmt.Errorf("recover interrupted cache build %q: %v", buildDir, err)
+		}
+		log.WithField("buildDir", buildDir).Warn("removed interrupted cache build")
+	}
+	return nil
+}
+
+// recoverBuild restores the cache that was moved aside into buildDir if the
+// cache in cacheDir is incomplete, and removes buildDir.
+//
+// The first entry of a build is the first one that is moved into cacheDir.
+// While it is still in buildDir, the entries in cacheDir belong to the
+// previous cache, and only the entries that were moved aside are moved back.
+// Otherwise, the entries in cacheDir that were moved into place are removed
+// before the previous cache is restored.
+func recoverBuild(cacheDir, buildDir string) error {
+	oldDir := filepath.Join(buildDir, oldCacheDir)
+	for _, b := range newBackends(cacheDir) {
+		entries := b.Entries()
+		if !anyExists(buildDir, entries) && !anyExists(oldDir, entries) {
+			continue
+		}
+		if anyExists(cacheDir, entries[len(entries)-1:]) {
+			continue
+		}
+		movedIn := !anyExists(buildDir, entries[:1])
+		for _, entry := range entries {
+			if !movedIn && !anyExists(oldDir, []string{entry}) {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(cacheDir, entry)); err != nil {
+				return err
+			}
+			if err := os.Rename(filepath.Join(oldDir, entry), filepath.Join(cacheDir, entry)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return os.RemoveAll(buildDir)
+}
+
+func anyExists(dir string, entries []string) bool {
+	for _, entry := range entries {
+		if _, err := os.Lstat(filepath.Join(dir, entry)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// copyEntries copies the given entries of srcDir, and their content, to
+// dstDir. Entries that do not exist in srcDir are skipped.
+func copyEntries(dstDir, srcDir string, entries []string) error {
+	for _, entry := range entries {
+		if !anyExists(srcDir, []string{entry}) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dstDir, entry)), cacheModeDir); err != nil {
+			return err
+		}
+		err := filepath.WalkDir(filepath.Join(srcDir, entry), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(srcDir, path)
+			if err != nil {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return os.Mkdir(filepath.Join(dstDir, rel), info.Mode().Perm())
+			}
+			return copyFile(filepath.Join(dstDir, rel), path, info.Mode().Perm())
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(dst, src string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
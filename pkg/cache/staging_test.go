@@ -0,0 +1,319 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+func TestRecoverBuilds(t *testing.T) {
+	type spec struct {
+		name  string
+		setup func(t *testing.T, format, cacheDir string)
+	}
+	newBuildDir := func(t *testing.T, format, cacheDir string) (backend, string) {
+		buildDir, err := os.MkdirTemp(cacheDir, buildDirPrefix+"*")
+		require.NoError(t, err)
+		b, err := newBackend(format, buildDir)
+		require.NoError(t, err)
+		return b, buildDir
+	}
+	specs := []spec{
+		{
+			name: "InterruptedBuild",
+			setup: func(t *testing.T, format, cacheDir string) {
+				b, _ := newBuildDir(t, format, cacheDir)
+				require.NoError(t, b.Init())
+				require.NoError(t, b.PutBundle(context.Background(), bundleKey{"foo", "bar", "baz"}, &api.Bundle{CsvName: "baz"}))
+				require.NoError(t, b.Close())
+			},
+		},
+		{
+			name: "InterruptedMoveAside",
+			setup: func(t *testing.T, format, cacheDir string) {
+				b, buildDir := newBuildDir(t, format, cacheDir)
+				require.NoError(t, b.Init())
+				require.NoError(t, b.Close())
+				oldDir := filepath.Join(buildDir, oldCacheDir)
+				require.NoError(t, os.Mkdir(oldDir, cacheModeDir))
+				// The entries of the previous cache that follow the first one
+				// were moved aside.
+				for _, entry := range b.Entries()[1:] {
+					require.NoError(t, os.Rename(filepath.Join(cacheDir, entry), filepath.Join(oldDir, entry)))
+				}
+			},
+		},
+		{
+			name: "InterruptedCommit",
+			setup: func(t *testing.T, format, cacheDir string) {
+				b, buildDir := newBuildDir(t, format, cacheDir)
+				oldDir := filepath.Join(buildDir, oldCacheDir)
+				require.NoError(t, os.Mkdir(oldDir, cacheModeDir))
+				for _, entry := range b.Entries() {
+					require.NoError(t, os.Rename(filepath.Join(cacheDir, entry), filepath.Join(oldDir, entry)))
+				}
+				// The entries of the new cache that precede the last one were
+				// moved into place.
+				for _, entry := range b.Entries()[:len(b.Entries())-1] {
+					require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, entry, "partial"), cacheModeDir))
+				}
+			},
+		},
+		{
+			name: "InterruptedCleanup",
+			setup: func(t *testing.T, format, cacheDir string) {
+				_, buildDir := newBuildDir(t, format, cacheDir)
+				require.NoError(t, os.MkdirAll(filepath.Join(buildDir, oldCacheDir, "partial"), cacheModeDir))
+			},
+		},
+	}
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		for _, s := range specs {
+			t.Run(format+"/"+s.name, func(t *testing.T) {
+				ctx := context.Background()
+				dir := buildTestCache(t, format, validFS)
+				s.setup(t, format, dir)
+
+				c, err := New(dir)
+				require.NoError(t, err)
+				defer c.Close()
+				require.NoError(t, c.CheckIntegrity(ctx, validFS))
+				require.NoError(t, c.Load(ctx))
+				require.Equal(t, Stats{Backend: format, Packages: 2, Bundles: 11}, c.(StatsReporter).Stats())
+
+				buildDirs, err := filepath.Glob(filepath.Join(dir, buildDirPrefix+"*"))
+				require.NoError(t, err)
+				require.Empty(t, buildDirs)
+			})
+		}
+	}
+}
+
+func TestCache_FailedBuildKeepsCache(t *testing.T) {
+	invalidFS := fstest.MapFS{
+		"foo.json": &fstest.MapFile{Data: []byte(`{"schema": "olm.package", "name": "foo", "defaultChannel": "missing"}`)},
+	}
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			ctx := context.Background()
+			dir := buildTestCache(t, format, validFS)
+			c, err := New(dir)
+			require.NoError(t, err)
+			defer c.Close()
+
+			require.Error(t, c.Build(ctx, invalidFS))
+			require.NoError(t, c.CheckIntegrity(ctx, validFS))
+			require.NoError(t, c.Load(ctx))
+			b, err := c.GetBundle(ctx, "etcd", "singlenamespace-alpha", "etcdoperator.v0.9.4")
+			require.NoError(t, err)
+			require.Equal(t, "etcdoperator.v0.9.4", b.CsvName)
+
+			buildDirs, err := filepath.Glob(filepath.Join(dir, buildDirPrefix+"*"))
+			require.NoError(t, err)
+			require.Empty(t, buildDirs)
+		})
+	}
+}
+
+func TestSwapEntries_RenameFails(t *testing.T) {
+	cacheDir := t.TempDir()
+	buildDir := filepath.Join(cacheDir, buildDirPrefix+"test")
+	require.NoError(t, os.Mkdir(buildDir, cacheModeDir))
+	for _, dir := range []string{cacheDir, buildDir} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte(dir), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "c"), []byte(dir), 0644))
+	}
+	// "b/x" cannot be moved aside, because "b" is a file.
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "b"), nil, 0644))
+
+	require.Error(t, swapEntries(cacheDir, buildDir, []string{"a", "b/x", "c"}))
+
+	// The entries that were not moved aside are still in place.
+	data, err := os.ReadFile(filepath.Join(cacheDir, "a"))
+	require.NoError(t, err)
+	require.Equal(t, cacheDir, string(data))
+	_, err = os.Stat(filepath.Join(cacheDir, "b"))
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(buildDir, oldCacheDir, "c"))
+	require.NoError(t, err)
+	require.Equal(t, cacheDir, string(data))
+}
+
+// setupSwap fills cacheDir and a build directory in it with the entries of
+// a JSON cache, whose contents are "old" and "new", and returns the build
+// directory and the entries.
+func setupSwap(t *testing.T, cacheDir string) (string, []string) {
+	buildDir := filepath.Join(cacheDir, buildDirPrefix+"test")
+	require.NoError(t, os.Mkdir(buildDir, cacheModeDir))
+	entries := newJSONBackend(cacheDir).Entries()
+	for _, entry := range entries {
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, entry), []byte("old"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(buildDir, entry), []byte("new"), 0644))
+	}
+	return buildDir, entries
+}
+
+// readEntries returns the contents of the entries in cacheDir, with an
+// empty content for the entries that do not exist.
+func readEntries(t *testing.T, cacheDir string, entries []string) []string {
+	contents := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(cacheDir, entry))
+		if !errors.Is(err, os.ErrNotExist) {
+			require.NoError(t, err)
+		}
+		contents = append(contents, string(data))
+	}
+	return contents
+}
+
+func repeat(s string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = s
+	}
+	return out
+}
+
+func TestSwapEntries_Interrupted(t *testing.T) {
+	defer func(r func(string, string) error) { rename = r }(rename)
+
+	errInterrupted := errors.New("interrupted")
+	n := len(newJSONBackend("").Entries())
+	// Each entry is renamed twice, once to move it aside and once to move
+	// it into place.
+	for renames := 0; renames <= 2*n; renames++ {
+		t.Run(fmt.Sprintf("AfterRename%d", renames), func(t *testing.T) {
+			cacheDir := t.TempDir()
+			buildDir, entries := setupSwap(t, cacheDir)
+			left := renames
+			rename = func(oldpath, newpath string) error {
+				if left == 0 {
+					return errInterrupted
+				}
+				left--
+				return os.Rename(oldpath, newpath)
+			}
+
+			err := swapEntries(cacheDir, buildDir, entries)
+			rename = os.Rename
+			if renames < 2*n {
+				require.ErrorIs(t, err, errInterrupted)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, recoverBuild(cacheDir, buildDir))
+
+			// The cache is either the previous one or the new one, and
+			// never a mix of both.
+			expected := repeat("old", n)
+			if renames == 2*n {
+				expected = repeat("new", n)
+			}
+			require.Equal(t, expected, readEntries(t, cacheDir, entries))
+			_, err = os.Stat(buildDir)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestSwapEntries_CannotRename(t *testing.T) {
+	defer func(r func(string, string) error) { rename = r }(rename)
+
+	// Entries of the previous cache that cannot be renamed, as in the lower
+	// layers of an overlay filesystem, are removed instead.
+	for _, errno := range []syscall.Errno{syscall.EXDEV, syscall.ENOTEMPTY} {
+		t.Run(errno.Error(), func(t *testing.T) {
+			var (
+				cacheDir, buildDir string
+				entries            []string
+				failMoveIn         bool
+			)
+			rename = func(oldpath, newpath string) error {
+				if filepath.Dir(oldpath) == cacheDir && filepath.Base(oldpath) == "cache" {
+					return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: errno}
+				}
+				if failMoveIn && filepath.Dir(oldpath) == buildDir {
+					return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EIO}
+				}
+				return os.Rename(oldpath, newpath)
+			}
+
+			t.Run("Success", func(t *testing.T) {
+				cacheDir = t.TempDir()
+				buildDir, entries = setupSwap(t, cacheDir)
+				require.NoError(t, swapEntries(cacheDir, buildDir, entries))
+				require.Equal(t, repeat("new", len(entries)), readEntries(t, cacheDir, entries))
+			})
+
+			t.Run("Failure", func(t *testing.T) {
+				cacheDir = t.TempDir()
+				buildDir, entries = setupSwap(t, cacheDir)
+				failMoveIn = true
+				require.Error(t, swapEntries(cacheDir, buildDir, entries))
+				require.NoError(t, recoverBuild(cacheDir, buildDir))
+
+				// The previous cache cannot be restored, and is not complete.
+				last := entries[len(entries)-1]
+				_, err := os.Stat(filepath.Join(cacheDir, last))
+				require.ErrorIs(t, err, os.ErrNotExist)
+			})
+		})
+	}
+}
+
+func TestInspect_DoesNotModifyCache(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatPogrebV1} {
+		t.Run(format, func(t *testing.T) {
+			dir := buildTestCache(t, format, validFS)
+			_, err := os.MkdirTemp(dir, buildDirPrefix+"*")
+			require.NoError(t, err)
+			if format == FormatPogrebV1 {
+				// The lock file of a database that is open, or that was not
+				// closed, makes pogreb recover the database when it is opened.
+				require.NoError(t, os.WriteFile(filepath.Join(dir, pogrebDbDir, "lock"), nil, 0600))
+			}
+			before := readTree(t, dir)
+
+			_, err = Inspect(context.Background(), dir)
+			require.NoError(t, err)
+			_, err = Verify(context.Background(), dir, validFS)
+			require.NoError(t, err)
+			require.Equal(t, before, readTree(t, dir))
+		})
+	}
+}
+
+// readTree returns the modes and contents of the files in dir by path.
+func readTree(t *testing.T, dir string) map[string]string {
+	tree := map[string]string{}
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		tree[path] = info.Mode().String()
+		if d.Type().IsRegular() {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			tree[path] += " " + string(data)
+		}
+		return nil
+	}))
+	return tree
+}
@@ -0,0 +1,228 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+var (
+	_ registry.GRPCQuery            = &Swappable{}
+	_ MetaSender                    = &Swappable{}
+	_ registry.FilteredBundleSender = &Swappable{}
+)
+
+// Swappable serves queries from a Cache that can be replaced while queries
+// are in flight. Each query, including streaming queries, is served entirely
+// by the cache that was current when it started. A replaced cache is closed
+// once the last query that uses it has completed.
+type Swappable struct {
+	mu      sync.RWMutex
+	current *swappableRef
+	closed  bool
+}
+
+// NewSwappable returns a Swappable that serves queries from c.
+func NewSwappable(c Cache) *Swappable {
+	return &Swappable{current: newSwappableRef(c)}
+}
+
+// Swap replaces the cache that serves new queries with c. The returned
+// channel receives the result of closing the replaced cache, once the queries
+// that were started before the swap have completed. If s has been closed, c
+// is closed instead, and the channel receives the result of closing it.
+func (s *Swappable) Swap(c Cache) <-chan error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		ref := newSwappableRef(c)
+		ref.retire()
+		return ref.closed
+	}
+	old := s.current
+	s.current = newSwappableRef(c)
+	s.mu.Unlock()
+	old.retire()
+	return old.closed
+}
+
+// Close closes the current cache, after waiting for the queries that use it
+// to complete. Queries must not be started after Close is called, and caches
+// that are swapped in after Close is called are closed.
+func (s *Swappable) Close() error {
+	s.mu.Lock()
+	cur := s.current
+	s.closed = true
+	s.mu.Unlock()
+	cur.retire()
+	return <-cur.closed
+}
+
+func (s *Swappable) acquire() *swappableRef {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.current.mu.Lock()
+	s.current.refs++
+	s.current.mu.Unlock()
+	return s.current
+}
+
+type swappableRef struct {
+	Cache
+
+	mu      sync.Mutex
+	refs    int
+	retired bool
+	closed  chan error
+}
+
+func newSwappableRef(c Cache) *swappableRef {
+	return &swappableRef{Cache: c, closed: make(chan error, 1)}
+}
+
+func (r *swappableRef) release() {
+	r.mu.Lock()
+	r.refs--
+	closeNow := r.retired && r.refs == 0
+	r.mu.Unlock()
+	if closeNow {
+		r.closed <- r.Cache.Close()
+	}
+}
+
+func (r *swappableRef) retire() {
+	r.mu.Lock()
+	if r.retired {
+		r.mu.Unlock()
+		return
+	}
+	r.retired = true
+	closeNow := r.refs == 0
+	r.mu.Unlock()
+	if closeNow {
+		r.closed <- r.Cache.Close()
+	}
+}
+
+func (s *Swappable) ListPackages(ctx context.Context) ([]string, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.ListPackages(ctx)
+}
+
+func (s *Swappable) SendBundles(ctx context.Context, stream registry.BundleSender) error {
+	c := s.acquire()
+	defer c.release()
+	return c.SendBundles(ctx, stream)
+}
+
+func (s *Swappable) SendFilteredBundles(ctx context.Context, stream registry.BundleSender, filter registry.BundleFilter) error {
+	c := s.acquire()
+	defer c.release()
+	return registry.SendFilteredBundles(ctx, c.Cache, stream, filter)
+}
+
+func (s *Swappable) ListBundles(ctx context.Context) ([]*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.ListBundles(ctx)
+}
+
+func (s *Swappable) GetPackage(ctx context.Context, name string) (*registry.PackageManifest, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetPackage(ctx, name)
+}
+
+func (s *Swappable) GetBundle(ctx context.Context, pkgName, channelName, csvName string) (*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetBundle(ctx, pkgName, channelName, csvName)
+}
+
+func (s *Swappable) GetBundleForChannel(ctx context.Context, pkgName string, channelName string) (*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetBundleForChannel(ctx, pkgName, channelName)
+}
+
+func (s *Swappable) GetChannelEntriesThatReplace(ctx context.Context, name string) ([]*registry.ChannelEntry, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetChannelEntriesThatReplace(ctx, name)
+}
+
+func (s *Swappable) GetBundleThatReplaces(ctx context.Context, name, pkgName, channelName string) (*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetBundleThatReplaces(ctx, name, pkgName, channelName)
+}
+
+func (s *Swappable) GetChannelEntriesThatProvide(ctx context.Context, group, version, kind string) ([]*registry.ChannelEntry, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetChannelEntriesThatProvide(ctx, group, version, kind)
+}
+
+func (s *Swappable) GetLatestChannelEntriesThatProvide(ctx context.Context, group, version, kind string) ([]*registry.ChannelEntry, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetLatestChannelEntriesThatProvide(ctx, group, version, kind)
+}
+
+func (s *Swappable) GetBundleThatProvides(ctx context.Context, group, version, kind string) (*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetBundleThatProvides(ctx, group, version, kind)
+}
+
+func (s *Swappable) ListChannelEntries(ctx context.Context, pkgName, channelName string) ([]*registry.ChannelEntry, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.ListChannelEntries(ctx, pkgName, channelName)
+}
+
+func (s *Swappable) GetDeprecations(ctx context.Context, pkgName string) (*api.Deprecations, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetDeprecations(ctx, pkgName)
+}
+
+func (s *Swappable) GetCatalogInfo(ctx context.Context) (*api.CatalogInfo, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetCatalogInfo(ctx)
+}
+
+func (s *Swappable) GetBundlesInRange(ctx context.Context, pkgName, versionRange string) ([]*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetBundlesInRange(ctx, pkgName, versionRange)
+}
+
+func (s *Swappable) GetBundleByImage(ctx context.Context, image string) (*api.Bundle, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetBundleByImage(ctx, image)
+}
+
+func (s *Swappable) FindBundles(ctx context.Context, query registry.PropertyQuery) ([]*api.BundleKey, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.FindBundles(ctx, query)
+}
+
+func (s *Swappable) GetKindForPlural(ctx context.Context, group, version, plural string) (string, error) {
+	c := s.acquire()
+	defer c.release()
+	return c.GetKindForPlural(ctx, group, version, plural)
+}
+
+func (s *Swappable) SendMetas(ctx context.Context, filter MetaFilter, send func(*declcfg.Meta) error) error {
+	c := s.acquire()
+	defer c.release()
+	return SendMetas(ctx, c.Cache, filter, send)
+}
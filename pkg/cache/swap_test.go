@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+type blockingBundleSender struct {
+	started chan struct{}
+	unblock chan struct{}
+	sent    []*api.Bundle
+}
+
+func (s *blockingBundleSender) Send(b *api.Bundle) error {
+	if len(s.sent) == 0 {
+		close(s.started)
+		<-s.unblock
+	}
+	s.sent = append(s.sent, b)
+	return nil
+}
+
+func TestSwappable(t *testing.T) {
+	cockroachFS := fstest.MapFS{
+		".":                validFS["."],
+		"cockroachdb.json": validFS["cockroachdb.json"],
+	}
+	for name, oldCache := range genTestCaches(t, validFS) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			newCache := genTestCaches(t, cockroachFS)[name]
+			s := NewSwappable(oldCache)
+
+			pkgs, err := s.ListPackages(ctx)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{"cockroachdb", "etcd"}, pkgs)
+
+			// Start a stream from the old cache and block it mid-stream.
+			sender := &blockingBundleSender{started: make(chan struct{}), unblock: make(chan struct{})}
+			streamErr := make(chan error, 1)
+			go func() {
+				streamErr <- s.SendBundles(ctx, sender)
+			}()
+			<-sender.started
+
+			oldClosed := s.Swap(newCache)
+
+			pkgs, err = s.ListPackages(ctx)
+			require.NoError(t, err)
+			require.Equal(t, []string{"cockroachdb"}, pkgs)
+
+			select {
+			case <-oldClosed:
+				t.Fatal("old cache closed while a stream was in flight")
+			default:
+			}
+
+			close(sender.unblock)
+			require.NoError(t, <-streamErr)
+			require.NoError(t, <-oldClosed)
+
+			var etcdBundles int
+			for _, b := range sender.sent {
+				if b.PackageName == "etcd" {
+					etcdBundles++
+				}
+			}
+			require.NotZero(t, etcdBundles, "stream should be served entirely by the old cache")
+
+			require.NoError(t, s.Close())
+		})
+	}
+}
+
+type closeRecorder struct {
+	Cache
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestSwappable_SwapAfterClose(t *testing.T) {
+	oldCache, newCache := &closeRecorder{}, &closeRecorder{}
+	s := NewSwappable(oldCache)
+	require.NoError(t, s.Close())
+	require.True(t, oldCache.closed)
+
+	require.NoError(t, <-s.Swap(newCache))
+	require.True(t, newCache.closed)
+}
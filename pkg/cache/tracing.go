@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+var tracer = otel.Tracer("github.com/operator-framework/operator-registry/pkg/cache")
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+var _ backend = &tracingBackend{}
+
+// tracingBackend records a span for each call to a backend, except for the
+// calls that only inspect its configuration.
+type tracingBackend struct {
+	backend
+}
+
+func (b *tracingBackend) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "cache.backend."+method, trace.WithAttributes(append(attrs, attribute.String("cache.backend", b.Name()))...))
+}
+
+func (b *tracingBackend) Init() (err error) {
+	_, span := b.start(context.Background(), "Init")
+	defer func() { endSpan(span, err) }()
+	return b.backend.Init()
+}
+
+func (b *tracingBackend) Open() (err error) {
+	_, span := b.start(context.Background(), "Open")
+	defer func() { endSpan(span, err) }()
+	return b.backend.Open()
+}
+
+func (b *tracingBackend) Close() (err error) {
+	_, span := b.start(context.Background(), "Close")
+	defer func() { endSpan(span, err) }()
+	return b.backend.Close()
+}
+
+func (b *tracingBackend) GetPackageIndex(ctx context.Context) (_ packageIndex, err error) {
+	ctx, span := b.start(ctx, "GetPackageIndex")
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetPackageIndex(ctx)
+}
+
+func (b *tracingBackend) PutPackageIndex(ctx context.Context, pi packageIndex) (err error) {
+	ctx, span := b.start(ctx, "PutPackageIndex", attribute.Int("cache.packages", len(pi)))
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutPackageIndex(ctx, pi)
+}
+
+func (b *tracingBackend) SendBundles(ctx context.Context, s registry.BundleSender, selectKey func(bundleKey) bool) (err error) {
+	ctx, span := b.start(ctx, "SendBundles")
+	defer func() { endSpan(span, err) }()
+	return b.backend.SendBundles(ctx, s, selectKey)
+}
+
+func (b *tracingBackend) GetBundle(ctx context.Context, key bundleKey) (_ *api.Bundle, err error) {
+	ctx, span := b.start(ctx, "GetBundle", bundleKeyAttributes(key)...)
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetBundle(ctx, key)
+}
+
+func (b *tracingBackend) PutBundle(ctx context.Context, key bundleKey, bundle *api.Bundle) (err error) {
+	ctx, span := b.start(ctx, "PutBundle", bundleKeyAttributes(key)...)
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutBundle(ctx, key, bundle)
+}
+
+func (b *tracingBackend) DeleteBundle(ctx context.Context, key bundleKey) (err error) {
+	ctx, span := b.start(ctx, "DeleteBundle", bundleKeyAttributes(key)...)
+	defer func() { endSpan(span, err) }()
+	return b.backend.DeleteBundle(ctx, key)
+}
+
+func (b *tracingBackend) GetDigest(ctx context.Context) (_ string, err error) {
+	ctx, span := b.start(ctx, "GetDigest")
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetDigest(ctx)
+}
+
+func (b *tracingBackend) GetBuildTime(ctx context.Context) (_ time.Time, err error) {
+	ctx, span := b.start(ctx, "GetBuildTime")
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetBuildTime(ctx)
+}
+
+func (b *tracingBackend) PutBuildTime(ctx context.Context, buildTime time.Time) (err error) {
+	ctx, span := b.start(ctx, "PutBuildTime")
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutBuildTime(ctx, buildTime)
+}
+
+func (b *tracingBackend) ComputeDigest(ctx context.Context, fbc fs.FS) (_ string, err error) {
+	ctx, span := b.start(ctx, "ComputeDigest")
+	defer func() { endSpan(span, err) }()
+	return b.backend.ComputeDigest(ctx, fbc)
+}
+
+func (b *tracingBackend) PutDigest(ctx context.Context, digest string) (err error) {
+	ctx, span := b.start(ctx, "PutDigest")
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutDigest(ctx, digest)
+}
+
+func (b *tracingBackend) GetPackageDigests(ctx context.Context) (_ map[string]string, err error) {
+	ctx, span := b.start(ctx, "GetPackageDigests")
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetPackageDigests(ctx)
+}
+
+func (b *tracingBackend) PutPackageDigests(ctx context.Context, digests map[string]string) (err error) {
+	ctx, span := b.start(ctx, "PutPackageDigests", attribute.Int("cache.packages", len(digests)))
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutPackageDigests(ctx, digests)
+}
+
+func (b *tracingBackend) GetAPIPlurals(ctx context.Context) (_ apiPlurals, err error) {
+	ctx, span := b.start(ctx, "GetAPIPlurals")
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetAPIPlurals(ctx)
+}
+
+func (b *tracingBackend) PutAPIPlurals(ctx context.Context, plurals apiPlurals) (err error) {
+	ctx, span := b.start(ctx, "PutAPIPlurals", attribute.Int("cache.packages", len(plurals)))
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutAPIPlurals(ctx, plurals)
+}
+
+func (b *tracingBackend) GetBundleInfos(ctx context.Context) (_ bundleInfos, err error) {
+	ctx, span := b.start(ctx, "GetBundleInfos")
+	defer func() { endSpan(span, err) }()
+	return b.backend.GetBundleInfos(ctx)
+}
+
+func (b *tracingBackend) PutBundleInfos(ctx context.Context, infos bundleInfos) (err error) {
+	ctx, span := b.start(ctx, "PutBundleInfos", attribute.Int("cache.packages", len(infos)))
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutBundleInfos(ctx, infos)
+}
+
+func (b *tracingBackend) OpenMetas(ctx context.Context) (_ io.ReadCloser, err error) {
+	ctx, span := b.start(ctx, "OpenMetas")
+	defer func() { endSpan(span, err) }()
+	return b.backend.OpenMetas(ctx)
+}
+
+func (b *tracingBackend) PutMetas(ctx context.Context, metas io.Reader) (err error) {
+	ctx, span := b.start(ctx, "PutMetas")
+	defer func() { endSpan(span, err) }()
+	return b.backend.PutMetas(ctx, metas)
+}
+
+func bundleKeyAttributes(key bundleKey) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("olm.package", key.PackageName),
+		attribute.String("olm.channel", key.ChannelName),
+		attribute.String("olm.bundle", key.Name),
+	}
+}
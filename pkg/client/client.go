@@ -2,16 +2,25 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"io"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/lib/certs"
 )
 
+// catalogMetadataKey must match server.CatalogMetadataKey. The server package
+// is not imported, to keep its dependencies out of clients.
+const catalogMetadataKey = "catalog"
+
 type Interface interface {
 	GetBundle(ctx context.Context, packageName, channelName, csvName string) (*api.Bundle, error)
 	GetBundleInPackageChannel(ctx context.Context, packageName, channelName string) (*api.Bundle, error)
@@ -27,6 +36,10 @@ type Client struct {
 	Registry api.RegistryClient
 	Health   grpc_health_v1.HealthClient
 	Conn     *grpc.ClientConn
+
+	// catalog is the catalog that requests are routed to by a server
+	// that serves multiple catalogs, if set.
+	catalog string
 }
 
 var _ Interface = &Client{}
@@ -98,7 +111,11 @@ func (c *Client) Close() error {
 }
 
 func (c *Client) HealthCheck(ctx context.Context, reconnectTimeout time.Duration) (bool, error) {
-	res, err := c.Health.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "Registry"})
+	service := "Registry"
+	if c.catalog != "" {
+		service = c.catalog
+	}
+	res, err := c.Health.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
 	if err != nil {
 		if c.Conn.GetState() == connectivity.TransientFailure {
 			ctx, cancel := context.WithTimeout(ctx, reconnectTimeout)
@@ -115,12 +132,89 @@ func (c *Client) HealthCheck(ctx context.Context, reconnectTimeout time.Duration
 	return true, nil
 }
 
-func NewClient(address string) (*Client, error) {
-	conn, err := grpc.Dial(address, grpc.WithInsecure())
+type ClientOptions struct {
+	TLSConfig *tls.Config
+	CAFile    string
+	CertFile  string
+	KeyFile   string
+	Catalog   string
+}
+
+type ClientOption func(*ClientOptions)
+
+// WithTLSConfig connects to the server with TLS using cfg. It takes
+// precedence over WithCAFile and WithClientCertificate.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(o *ClientOptions) {
+		o.TLSConfig = cfg
+	}
+}
+
+// WithCAFile connects to the server with TLS, and trusts the CAs in caFile in
+// addition to the system root CAs.
+func WithCAFile(caFile string) ClientOption {
+	return func(o *ClientOptions) {
+		o.CAFile = caFile
+	}
+}
+
+// WithClientCertificate connects to the server with TLS, and presents the
+// certificate in certFile and keyFile if the server requests one, as servers
+// that require mutual TLS do. The certificate is reloaded when it changes.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(o *ClientOptions) {
+		o.CertFile = certFile
+		o.KeyFile = keyFile
+	}
+}
+
+// WithCatalog routes requests to the named catalog of a server that serves
+// multiple catalogs, and checks the health of that catalog in HealthCheck.
+func WithCatalog(name string) ClientOption {
+	return func(o *ClientOptions) {
+		o.Catalog = name
+	}
+}
+
+// NewClient returns a client for the registry server at address. Without
+// options, the connection is not secured.
+func NewClient(address string, opts ...ClientOption) (*Client, error) {
+	o := &ClientOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	creds := insecure.NewCredentials()
+	switch {
+	case o.TLSConfig != nil:
+		creds = credentials.NewTLS(o.TLSConfig)
+	case o.CAFile != "" || o.CertFile != "" || o.KeyFile != "":
+		cfg, err := certs.ClientTLSConfig(o.CAFile, o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if o.Catalog != "" {
+		dialOpts = append(dialOpts,
+			grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				return invoker(metadata.AppendToOutgoingContext(ctx, catalogMetadataKey, o.Catalog), method, req, reply, cc, opts...)
+			}),
+			grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return streamer(metadata.AppendToOutgoingContext(ctx, catalogMetadataKey, o.Catalog), desc, cc, method, opts...)
+			}),
+		)
+	}
+
+	conn, err := grpc.Dial(address, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return NewClientFromConn(conn), nil
+	c := NewClientFromConn(conn)
+	c.catalog = o.Catalog
+	return c, nil
 }
 
 func NewClientFromConn(conn *grpc.ClientConn) *Client {
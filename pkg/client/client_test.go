@@ -61,6 +61,30 @@ func (s *RegistryClientStub) ListBundles(ctx context.Context, in *api.ListBundle
 	return s.ListBundlesClient, s.Error
 }
 
+func (s *RegistryClientStub) ListChannelEntries(ctx context.Context, in *api.ListChannelEntriesRequest, opts ...grpc.CallOption) (api.Registry_ListChannelEntriesClient, error) {
+	return nil, nil
+}
+
+func (s *RegistryClientStub) GetDeprecations(ctx context.Context, in *api.GetDeprecationsRequest, opts ...grpc.CallOption) (*api.Deprecations, error) {
+	return nil, nil
+}
+
+func (s *RegistryClientStub) GetCatalogInfo(ctx context.Context, in *api.GetCatalogInfoRequest, opts ...grpc.CallOption) (*api.CatalogInfo, error) {
+	return nil, nil
+}
+
+func (s *RegistryClientStub) GetBundlesInRange(ctx context.Context, in *api.GetBundlesInRangeRequest, opts ...grpc.CallOption) (api.Registry_GetBundlesInRangeClient, error) {
+	return nil, nil
+}
+
+func (s *RegistryClientStub) GetBundleByImage(ctx context.Context, in *api.GetBundleByImageRequest, opts ...grpc.CallOption) (*api.Bundle, error) {
+	return nil, nil
+}
+
+func (s *RegistryClientStub) FindBundles(ctx context.Context, in *api.FindBundlesRequest, opts ...grpc.CallOption) (api.Registry_FindBundlesClient, error) {
+	return nil, nil
+}
+
 func (s *RegistryClientStub) Check(ctx context.Context, in *grpc_health_v1.HealthCheckRequest, opts ...grpc.CallOption) (*grpc_health_v1.HealthCheckResponse, error) {
 	return nil, nil
 }
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/operator-framework/operator-registry/pkg/lib/certs"
+	"github.com/operator-framework/operator-registry/pkg/server"
+)
+
+// writeTestCert writes a certificate for name and its key to dir. If caFile
+// and caKeyFile are empty, the certificate is a self-signed CA.
+func writeTestCert(t *testing.T, dir, name, caFile, caKeyFile string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	parent, parentKey := tmpl, key
+	if caFile == "" {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		ca, err := certs.NewKeyPairLoader(caFile, caKeyFile)
+		require.NoError(t, err)
+		caCert, err := ca.GetCertificate(nil)
+		require.NoError(t, err)
+		parent, err = x509.ParseCertificate(caCert.Certificate[0])
+		require.NoError(t, err)
+		parentKey = caCert.PrivateKey.(*ecdsa.PrivateKey)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestNewClientTLS(t *testing.T) {
+	dir := t.TempDir()
+	caFile, caKeyFile := writeTestCert(t, dir, "ca", "", "")
+	serverCert, serverKey := writeTestCert(t, dir, "server", caFile, caKeyFile)
+	clientCert, clientKey := writeTestCert(t, dir, "client", caFile, caKeyFile)
+
+	serverConfig, err := certs.ServerTLSConfig(serverCert, serverKey, caFile)
+	require.NoError(t, err)
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverConfig)))
+	grpc_health_v1.RegisterHealthServer(s, server.NewHealthServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	type spec struct {
+		name    string
+		opts    []ClientOption
+		healthy bool
+	}
+	specs := []spec{
+		{name: "MutualTLS", opts: []ClientOption{WithCAFile(caFile), WithClientCertificate(clientCert, clientKey)}, healthy: true},
+		{name: "NoClientCertificate", opts: []ClientOption{WithCAFile(caFile)}},
+		{name: "UntrustedServer", opts: []ClientOption{WithClientCertificate(clientCert, clientKey)}},
+		{name: "Insecure"},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			c, err := NewClient(lis.Addr().String(), s.opts...)
+			require.NoError(t, err)
+			defer c.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			healthy, err := c.HealthCheck(ctx, time.Second)
+			if s.healthy {
+				require.NoError(t, err)
+				require.True(t, healthy)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// KeyPairLoader loads a certificate and key pair from disk, and reloads them
+// when either file changes, so that rotated certificates are used without a
+// restart. If a changed pair cannot be loaded, e.g. because only one of the
+// files has been replaced so far, the previous pair continues to be used.
+type KeyPairLoader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	version string
+	cert    *tls.Certificate
+}
+
+// NewKeyPairLoader returns a KeyPairLoader for the given files. It returns an
+// error if the pair cannot be loaded initially.
+func NewKeyPairLoader(certFile, keyFile string) (*KeyPairLoader, error) {
+	l := &KeyPairLoader{certFile: certFile, keyFile: keyFile}
+	if _, err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetCertificate can be used as tls.Config.GetCertificate.
+func (l *KeyPairLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return l.load()
+}
+
+// GetClientCertificate can be used as tls.Config.GetClientCertificate.
+func (l *KeyPairLoader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return l.load()
+}
+
+func (l *KeyPairLoader) load() (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	version, err := fileVersion(l.certFile, l.keyFile)
+	if err != nil {
+		if l.cert != nil {
+			return l.cert, nil
+		}
+		return nil, err
+	}
+	if version == l.version {
+		return l.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		if l.cert != nil {
+			return l.cert, nil
+		}
+		return nil, fmt.Errorf("load key pair: %v", err)
+	}
+	l.version, l.cert = version, &cert
+	return l.cert, nil
+}
+
+// CertPoolLoader loads a pool of PEM encoded certificates from a file, and
+// reloads it when the file changes. If a changed file cannot be loaded, the
+// previous pool continues to be used.
+type CertPoolLoader struct {
+	caFile string
+
+	mu      sync.Mutex
+	version string
+	pool    *x509.CertPool
+}
+
+// NewCertPoolLoader returns a CertPoolLoader for the given file. It returns an
+// error if the file cannot be loaded initially.
+func NewCertPoolLoader(caFile string) (*CertPoolLoader, error) {
+	l := &CertPoolLoader{caFile: caFile}
+	if _, err := l.CertPool(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// CertPool returns the current pool.
+func (l *CertPoolLoader) CertPool() (*x509.CertPool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	version, err := fileVersion(l.caFile)
+	if err != nil {
+		if l.pool != nil {
+			return l.pool, nil
+		}
+		return nil, err
+	}
+	if version == l.version {
+		return l.pool, nil
+	}
+	pem, err := os.ReadFile(l.caFile)
+	if err != nil {
+		if l.pool != nil {
+			return l.pool, nil
+		}
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(pem); !ok {
+		if l.pool != nil {
+			return l.pool, nil
+		}
+		return nil, fmt.Errorf("unable to add certs specified in %s", l.caFile)
+	}
+	l.version, l.pool = version, pool
+	return l.pool, nil
+}
+
+// ServerTLSConfig returns a TLS configuration for a server that presents the
+// certificate in certFile and keyFile. If clientCAFile is set, clients must
+// present a certificate signed by one of the CAs in clientCAFile. The files
+// are reloaded when they change.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	keyPair, err := NewKeyPairLoader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: keyPair.GetCertificate,
+	}
+	if clientCAFile == "" {
+		return cfg, nil
+	}
+
+	clientCAs, err := NewCertPoolLoader(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	// The client certificates are verified by VerifyPeerCertificate rather
+	// than by setting ClientCAs, so that the current pool is used.
+	cfg.ClientAuth = tls.RequireAnyClientCert
+	cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		pool, err := clientCAs.CertPool()
+		if err != nil {
+			return err
+		}
+		return verifyClientCertificate(rawCerts, pool)
+	}
+	return cfg, nil
+}
+
+func verifyClientCertificate(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("client certificate required")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("parse client certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
+
+// ClientTLSConfig returns a TLS configuration for a client that trusts the
+// system root CAs and the CAs in caFile, if it is set. If certFile and keyFile
+// are set, the client presents that certificate to servers that request one,
+// and reloads it when it changes.
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	rootCAs, err := RootCAs(caFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+	if certFile == "" && keyFile == "" {
+		return cfg, nil
+	}
+	keyPair, err := NewKeyPairLoader(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg.GetClientCertificate = keyPair.GetClientCertificate
+	return cfg, nil
+}
+
+// fileVersion identifies the content of files by their sizes and
+// modification times.
+func fileVersion(files ...string) (string, error) {
+	var version string
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return "", err
+		}
+		version += fmt.Sprintf("%d/%d;", info.Size(), info.ModTime().UnixNano())
+	}
+	return version, nil
+}
@@ -0,0 +1,159 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert returns a certificate for name. If parent is nil, the
+// certificate is a self-signed CA.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer := &testCert{cert: tmpl, key: key}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer = parent
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer.cert, &key.PublicKey, signer.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+// write writes the certificate and key to dir, and returns their paths.
+func (c *testCert) write(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestKeyPairLoader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	first := newTestCert(t, "first", ca)
+	certFile, keyFile := first.write(t, dir, "server")
+
+	l, err := NewKeyPairLoader(certFile, keyFile)
+	require.NoError(t, err)
+	cert, err := l.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, first.cert.Raw, cert.Certificate[0])
+
+	// A partially rotated pair does not replace the loaded pair.
+	second := newTestCert(t, "second", ca)
+	secondDir := t.TempDir()
+	secondCert, secondKey := second.write(t, secondDir, "server")
+	require.NoError(t, os.Rename(secondCert, certFile))
+	cert, err = l.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, first.cert.Raw, cert.Certificate[0])
+
+	require.NoError(t, os.Rename(secondKey, keyFile))
+	cert, err = l.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, second.cert.Raw, cert.Certificate[0])
+
+	_, err = NewKeyPairLoader(filepath.Join(dir, "missing.crt"), keyFile)
+	require.Error(t, err)
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	otherCA := newTestCert(t, "other-ca", nil)
+	caFile, _ := ca.write(t, dir, "ca")
+	serverCert, serverKey := newTestCert(t, "127.0.0.1", ca).write(t, dir, "server")
+	clientCert, clientKey := newTestCert(t, "client", ca).write(t, dir, "client")
+	untrustedCert, untrustedKey := newTestCert(t, "untrusted", otherCA).write(t, dir, "untrusted")
+
+	serverConfig, err := ServerTLSConfig(serverCert, serverKey, caFile)
+	require.NoError(t, err)
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Write([]byte("ok"))
+			}()
+		}
+	}()
+
+	type spec struct {
+		name      string
+		certFile  string
+		keyFile   string
+		expectErr bool
+	}
+	specs := []spec{
+		{name: "TrustedClient", certFile: clientCert, keyFile: clientKey},
+		{name: "UntrustedClient", certFile: untrustedCert, keyFile: untrustedKey, expectErr: true},
+		{name: "NoClientCertificate", expectErr: true},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			clientConfig, err := ClientTLSConfig(caFile, s.certFile, s.keyFile)
+			require.NoError(t, err)
+			conn, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			// With TLS 1.3, the server reports a rejected client
+			// certificate after the client completes its handshake, so the
+			// rejection surfaces when reading.
+			buf := make([]byte, 2)
+			_, err = conn.Read(buf)
+			if s.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, "ok", string(buf))
+			}
+		})
+	}
+}
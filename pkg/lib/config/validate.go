@@ -10,6 +10,9 @@ import (
 // Validate takes a filesystem containing the declarative config file(s)
 // 1. Validate if declarative config file(s) are valid based on specified schema
 // 2. Validate the `replaces` chains of the upgrade graph
+// 3. Validate the olm.constraint properties of bundles, which serving a
+// catalog does not require
+// 4. Validate objects with schemas registered in declcfg.DefaultSchemaRegistry
 // Inputs:
 // directory: a filesystem where declarative config file(s) exist
 // Outputs:
@@ -24,9 +27,14 @@ func Validate(ctx context.Context, root fs.FS) error {
 	// This will convert declcfg objects to intermediate model objects that are
 	// also used for serve and add commands. The conversion process will run
 	// validation for the model objects and ensure they are valid.
-	_, err = declcfg.ConvertToModel(*cfg)
+	m, err := declcfg.ConvertToModelContext(ctx, *cfg)
 	if err != nil {
 		return err
 	}
-	return nil
+	if err := m.ValidateConstraints(); err != nil {
+		return err
+	}
+	// Run the validators of any schemas that library users have registered
+	// for objects outside of the olm.* schemas.
+	return declcfg.DefaultSchemaRegistry.Validate(*cfg)
 }
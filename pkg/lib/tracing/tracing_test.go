@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+
+	"github.com/operator-framework/operator-registry/pkg/cache"
+)
+
+// collector is a stand-in for an OTLP collector that records the names of
+// the spans it receives.
+type collector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	spans []string
+}
+
+func (c *collector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				c.spans = append(c.spans, span.GetName())
+			}
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func TestSetup(t *testing.T) {
+	c := &collector{}
+	s := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(s, c)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://"+lis.Addr().String())
+	t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+
+	ctx := context.Background()
+	shutdown, err := Setup(ctx, "opm-test")
+	require.NoError(t, err)
+
+	fbc := fstest.MapFS{
+		"foo.json": &fstest.MapFile{Data: []byte(`
+{"schema": "olm.package", "name": "foo", "defaultChannel": "stable"}
+{"schema": "olm.channel", "package": "foo", "name": "stable", "entries": [{"name": "foo.v0.1.0"}]}
+{"schema": "olm.bundle", "package": "foo", "name": "foo.v0.1.0", "image": "foo:v0.1.0", "properties": [{"type": "olm.package", "value": {"packageName": "foo", "version": "0.1.0"}}]}
+`)},
+	}
+	store, err := cache.New(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, cache.LoadOrRebuild(ctx, store, fbc))
+	require.NoError(t, store.Close())
+	require.NoError(t, shutdown(ctx))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	require.Subset(t, c.spans, []string{
+		"cache.CheckIntegrity",
+		"cache.Build",
+		"cache.Load",
+		"declcfg.ConvertToModel",
+		"cache.backend.Init",
+		"cache.backend.PutBundle",
+		"cache.backend.PutPackageIndex",
+		"cache.backend.PutDigest",
+		"cache.backend.GetPackageIndex",
+	})
+}
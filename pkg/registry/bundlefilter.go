@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+// BundleFilter restricts the bundles sent by SendBundles and the fields that
+// are set on them. The zero value selects every field of every bundle.
+type BundleFilter struct {
+	// PackageName, if set, selects only the bundles of this package.
+	PackageName string
+	// ChannelName, if set, selects only the bundles in this channel.
+	ChannelName string
+	// Fields, if set, lists the names of the api.Bundle fields to send, as
+	// named in registry.proto. All other fields are cleared.
+	Fields []string
+	// Offset is the number of matching bundles to skip.
+	Offset int
+	// Limit, if positive, is the maximum number of bundles to send.
+	Limit int
+}
+
+// Validate returns an error if f names an unknown field or has a negative
+// offset or limit.
+func (f BundleFilter) Validate() error {
+	fields := (&api.Bundle{}).ProtoReflect().Descriptor().Fields()
+	for _, name := range f.Fields {
+		if fields.ByName(protoreflect.Name(name)) == nil {
+			return fmt.Errorf("unknown bundle field %q", name)
+		}
+	}
+	if f.Offset < 0 {
+		return fmt.Errorf("invalid offset %d", f.Offset)
+	}
+	if f.Limit < 0 {
+		return fmt.Errorf("invalid limit %d", f.Limit)
+	}
+	return nil
+}
+
+// Matches reports whether a bundle in the given package and channel passes
+// the package and channel filters of f.
+func (f BundleFilter) Matches(pkgName, channelName string) bool {
+	return (f.PackageName == "" || f.PackageName == pkgName) &&
+		(f.ChannelName == "" || f.ChannelName == channelName)
+}
+
+// Selector returns a function that is called with the package and channel of
+// each bundle, in the order in which the bundles are considered for sending,
+// and reports whether the bundle is selected by the filters, the offset and
+// the limit of f.
+func (f BundleFilter) Selector() func(pkgName, channelName string) bool {
+	matched := 0
+	return func(pkgName, channelName string) bool {
+		if !f.Matches(pkgName, channelName) {
+			return false
+		}
+		matched++
+		if matched <= f.Offset {
+			return false
+		}
+		return f.Limit <= 0 || matched-f.Offset <= f.Limit
+	}
+}
+
+// MaskFields clears the fields of b that are not listed in f.Fields. It does
+// nothing if f.Fields is empty.
+func (f BundleFilter) MaskFields(b *api.Bundle) {
+	if len(f.Fields) == 0 {
+		return
+	}
+	keep := make(map[protoreflect.Name]struct{}, len(f.Fields))
+	for _, name := range f.Fields {
+		keep[protoreflect.Name(name)] = struct{}{}
+	}
+	m := b.ProtoReflect()
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if _, ok := keep[fd.Name()]; !ok {
+			m.Clear(fd)
+		}
+		return true
+	})
+}
+
+// FilteredBundleSender is implemented by queriers that apply a BundleFilter
+// while they read their bundles, so that the bundles that are not selected
+// are not read.
+type FilteredBundleSender interface {
+	// SendFilteredBundles sends the bundles that are selected by filter,
+	// with the fields that are not listed in filter cleared.
+	SendFilteredBundles(ctx context.Context, stream BundleSender, filter BundleFilter) error
+}
+
+// SendFilteredBundles sends the bundles of q that are selected by filter. If
+// q is a FilteredBundleSender, q applies the filter. Otherwise, the filter is
+// applied to the bundles sent by q.SendBundles, and the offset and the limit
+// refer to the order in which q sends them.
+func SendFilteredBundles(ctx context.Context, q GRPCQuery, stream BundleSender, filter BundleFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	if s, ok := q.(FilteredBundleSender); ok {
+		return s.SendFilteredBundles(ctx, stream, filter)
+	}
+	return q.SendBundles(ctx, &filteringBundleSender{stream: stream, filter: filter, selected: filter.Selector()})
+}
+
+type filteringBundleSender struct {
+	stream   BundleSender
+	filter   BundleFilter
+	selected func(pkgName, channelName string) bool
+}
+
+func (s *filteringBundleSender) Send(b *api.Bundle) error {
+	if !s.selected(b.GetPackageName(), b.GetChannelName()) {
+		return nil
+	}
+	s.filter.MaskFields(b)
+	return s.stream.Send(b)
+}
@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+func TestBundleFilterSelector(t *testing.T) {
+	type entry struct{ pkg, channel string }
+	entries := []entry{
+		{"a", "alpha"}, {"a", "beta"}, {"b", "alpha"}, {"a", "alpha"}, {"a", "alpha"}, {"b", "beta"},
+	}
+	for _, tt := range []struct {
+		name     string
+		filter   BundleFilter
+		expected []int
+	}{
+		{name: "All", filter: BundleFilter{}, expected: []int{0, 1, 2, 3, 4, 5}},
+		{name: "Package", filter: BundleFilter{PackageName: "a"}, expected: []int{0, 1, 3, 4}},
+		{name: "PackageAndChannel", filter: BundleFilter{PackageName: "a", ChannelName: "alpha"}, expected: []int{0, 3, 4}},
+		{name: "Offset", filter: BundleFilter{ChannelName: "alpha", Offset: 1}, expected: []int{2, 3, 4}},
+		{name: "Limit", filter: BundleFilter{ChannelName: "alpha", Limit: 2}, expected: []int{0, 2}},
+		{name: "OffsetAndLimit", filter: BundleFilter{PackageName: "a", Offset: 1, Limit: 2}, expected: []int{1, 3}},
+		{name: "OffsetPastEnd", filter: BundleFilter{PackageName: "b", Offset: 2}, expected: nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := tt.filter.Selector()
+			var actual []int
+			for i, e := range entries {
+				if selected(e.pkg, e.channel) {
+					actual = append(actual, i)
+				}
+			}
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestBundleFilterMaskFields(t *testing.T) {
+	b := &api.Bundle{
+		CsvName:     "a.v1",
+		PackageName: "a",
+		Version:     "1.0.0",
+		CsvJson:     "{}",
+		Object:      []string{"{}"},
+		Properties:  []*api.Property{{Type: "olm.package"}},
+	}
+	f := BundleFilter{Fields: []string{"csvName", "version"}}
+	require.NoError(t, f.Validate())
+	f.MaskFields(b)
+	require.Equal(t, "a.v1", b.CsvName)
+	require.Equal(t, "1.0.0", b.Version)
+	require.Empty(t, b.PackageName)
+	require.Empty(t, b.CsvJson)
+	require.Empty(t, b.Object)
+	require.Empty(t, b.Properties)
+
+	require.Error(t, BundleFilter{Fields: []string{"csv_name"}}.Validate())
+	require.Error(t, BundleFilter{Offset: -1}.Validate())
+	require.Error(t, BundleFilter{Limit: -1}.Validate())
+}
+
+// bundleQuery sends its bundles with SendBundles, and does not filter them
+// itself.
+type bundleQuery struct {
+	EmptyQuery
+	bundles []*api.Bundle
+}
+
+func (q bundleQuery) SendBundles(_ context.Context, stream BundleSender) error {
+	for _, b := range q.bundles {
+		if err := stream.Send(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type sliceSender []*api.Bundle
+
+func (s *sliceSender) Send(b *api.Bundle) error {
+	*s = append(*s, b)
+	return nil
+}
+
+func TestSendFilteredBundles(t *testing.T) {
+	q := bundleQuery{bundles: []*api.Bundle{
+		{CsvName: "a.v1", PackageName: "a", ChannelName: "alpha"},
+		{CsvName: "a.v1", PackageName: "a", ChannelName: "beta"},
+		{CsvName: "a.v2", PackageName: "a", ChannelName: "alpha"},
+		{CsvName: "b.v1", PackageName: "b", ChannelName: "alpha"},
+	}}
+
+	var sent sliceSender
+	require.NoError(t, SendFilteredBundles(context.Background(), q, &sent, BundleFilter{
+		PackageName: "a",
+		ChannelName: "alpha",
+		Fields:      []string{"csvName"},
+		Offset:      1,
+		Limit:       1,
+	}))
+	require.Len(t, sent, 1)
+	require.Equal(t, "a.v2", sent[0].CsvName)
+	require.Empty(t, sent[0].PackageName)
+
+	require.Error(t, SendFilteredBundles(context.Background(), q, &sent, BundleFilter{Fields: []string{"unknown"}}))
+}
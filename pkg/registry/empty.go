@@ -60,6 +60,34 @@ func (EmptyQuery) GetBundleThatProvides(ctx context.Context, group, version, kin
 	return nil, errors.New("empty querier: cannot get bundle that provides")
 }
 
+func (EmptyQuery) ListChannelEntries(ctx context.Context, pkgName, channelName string) ([]*ChannelEntry, error) {
+	return nil, errors.New("empty querier: cannot list channel entries")
+}
+
+func (EmptyQuery) GetDeprecations(ctx context.Context, pkgName string) (*api.Deprecations, error) {
+	return nil, errors.New("empty querier: cannot get deprecations")
+}
+
+func (EmptyQuery) GetCatalogInfo(ctx context.Context) (*api.CatalogInfo, error) {
+	return nil, errors.New("empty querier: cannot get catalog info")
+}
+
+func (EmptyQuery) GetBundlesInRange(ctx context.Context, pkgName, versionRange string) ([]*api.Bundle, error) {
+	return nil, errors.New("empty querier: cannot get bundles in range")
+}
+
+func (EmptyQuery) GetBundleByImage(ctx context.Context, image string) (*api.Bundle, error) {
+	return nil, errors.New("empty querier: cannot get bundle by image")
+}
+
+func (EmptyQuery) FindBundles(ctx context.Context, query PropertyQuery) ([]*api.BundleKey, error) {
+	return nil, errors.New("empty querier: cannot find bundles")
+}
+
+func (EmptyQuery) GetKindForPlural(ctx context.Context, group, version, plural string) (string, error) {
+	return "", errors.New("empty querier: cannot get kind for plural")
+}
+
 func (EmptyQuery) ListImages(ctx context.Context) ([]string, error) {
 	return nil, errors.New("empty querier: cannot get image list")
 }
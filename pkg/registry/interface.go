@@ -57,6 +57,28 @@ type GRPCQuery interface {
 
 	// Get the the latest bundle that provides the API in a default channel
 	GetBundleThatProvides(ctx context.Context, group, version, kind string) (*api.Bundle, error)
+
+	// List the entries of a channel in a package. A bundle has an entry for
+	// the bundle it replaces, and one for each bundle in the channel it skips
+	ListChannelEntries(ctx context.Context, pkgName, channelName string) ([]*ChannelEntry, error)
+
+	// Get the deprecations of a package, and of its channels and bundles
+	GetDeprecations(ctx context.Context, pkgName string) (*api.Deprecations, error)
+
+	// Get the digest, build time and size of the index
+	GetCatalogInfo(ctx context.Context) (*api.CatalogInfo, error)
+
+	// Get the bundles of a package whose versions are in a semver range, highest version first
+	GetBundlesInRange(ctx context.Context, pkgName, versionRange string) ([]*api.Bundle, error)
+
+	// Get the bundle with the given image reference or image digest
+	GetBundleByImage(ctx context.Context, image string) (*api.Bundle, error)
+
+	// Get the keys of the bundles with a property that matches the query
+	FindBundles(ctx context.Context, query PropertyQuery) ([]*api.BundleKey, error)
+
+	// Get the kind of the provided API with the given group, version and resource plural
+	GetKindForPlural(ctx context.Context, group, version, plural string) (string, error)
 }
 
 type Query interface {
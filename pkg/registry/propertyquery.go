@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+// PropertyQuery matches bundles by the properties they declare.
+type PropertyQuery struct {
+	// Type is the property type to match, for example olm.gvk.
+	Type string
+	// Path, if set, is a dot-separated path of object keys into the
+	// property value, for example "maintainers.name". Arrays along the path
+	// are searched element by element.
+	Path string
+	// Value is compared with the values found at Path. String values are
+	// compared as they are; other values are compared by their JSON
+	// encoding.
+	Value string
+}
+
+// Validate returns an error if q has no type, or has a value without a path.
+func (q PropertyQuery) Validate() error {
+	if q.Type == "" {
+		return errors.New("property type is required")
+	}
+	if q.Path == "" && q.Value != "" {
+		return errors.New("a value requires a path")
+	}
+	if q.Path != "" && slices.Contains(strings.Split(q.Path, "."), "") {
+		return fmt.Errorf("invalid path %q", q.Path)
+	}
+	return nil
+}
+
+// Matches reports whether any of props matches q.
+func (q PropertyQuery) Matches(props []*api.Property) (bool, error) {
+	for _, p := range props {
+		if p.GetType() != q.Type {
+			continue
+		}
+		match, err := q.MatchesValue(p.GetValue())
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
+
+// MatchesValue reports whether the JSON value of a property of type q.Type
+// matches q.
+func (q PropertyQuery) MatchesValue(value string) (bool, error) {
+	if q.Path == "" {
+		return true, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return false, fmt.Errorf("decode %s property value: %v", q.Type, err)
+	}
+	return matchPath(v, strings.Split(q.Path, "."), q.Value), nil
+}
+
+func matchPath(v interface{}, path []string, want string) bool {
+	switch t := v.(type) {
+	case []interface{}:
+		for _, e := range t {
+			if matchPath(e, path, want) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return false
+		}
+		child, ok := t[path[0]]
+		if !ok {
+			return false
+		}
+		return matchPath(child, path[1:], want)
+	case string:
+		return len(path) == 0 && t == want
+	default:
+		if len(path) != 0 {
+			return false
+		}
+		data, err := json.Marshal(t)
+		return err == nil && string(data) == want
+	}
+}
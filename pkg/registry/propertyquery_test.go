@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+)
+
+func TestPropertyQueryMatches(t *testing.T) {
+	props := []*api.Property{
+		{Type: "olm.package", Value: `{"packageName":"etcd","version":"0.9.2"}`},
+		{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdBackup","version":"v1beta2"}`},
+		{Type: "olm.gvk", Value: `{"group":"etcd.database.coreos.com","kind":"EtcdCluster","version":"v1beta2"}`},
+		{Type: "olm.csv.metadata", Value: `{"keywords":["etcd","key value"],"maintainers":[{"name":"etcd Community","email":"etcd@example.com"}],"annotations":{"capabilities":"Full Lifecycle"},"minKubeVersion":"1.20.0","nativeAPIs":[{"version":1}]}`},
+	}
+	for _, tt := range []struct {
+		name     string
+		query    PropertyQuery
+		expected bool
+	}{
+		{name: "TypeOnly", query: PropertyQuery{Type: "olm.gvk"}, expected: true},
+		{name: "TypeMissing", query: PropertyQuery{Type: "olm.maxOpenShiftVersion"}, expected: false},
+		{name: "SecondPropertyOfType", query: PropertyQuery{Type: "olm.gvk", Path: "kind", Value: "EtcdCluster"}, expected: true},
+		{name: "NoValueMatch", query: PropertyQuery{Type: "olm.gvk", Path: "kind", Value: "EtcdRestore"}, expected: false},
+		{name: "ArrayElement", query: PropertyQuery{Type: "olm.csv.metadata", Path: "keywords", Value: "key value"}, expected: true},
+		{name: "ObjectInArray", query: PropertyQuery{Type: "olm.csv.metadata", Path: "maintainers.name", Value: "etcd Community"}, expected: true},
+		{name: "NestedObject", query: PropertyQuery{Type: "olm.csv.metadata", Path: "annotations.capabilities", Value: "Full Lifecycle"}, expected: true},
+		{name: "NonString", query: PropertyQuery{Type: "olm.csv.metadata", Path: "nativeAPIs.version", Value: "1"}, expected: true},
+		{name: "PathTooShort", query: PropertyQuery{Type: "olm.csv.metadata", Path: "annotations", Value: "Full Lifecycle"}, expected: false},
+		{name: "PathTooLong", query: PropertyQuery{Type: "olm.csv.metadata", Path: "minKubeVersion.major", Value: "1"}, expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.query.Validate())
+			actual, err := tt.query.Matches(props)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestPropertyQueryValidate(t *testing.T) {
+	require.Error(t, PropertyQuery{}.Validate())
+	require.Error(t, PropertyQuery{Type: "olm.gvk", Value: "EtcdCluster"}.Validate())
+	require.Error(t, PropertyQuery{Type: "olm.gvk", Path: ".kind", Value: "EtcdCluster"}.Validate())
+	require.NoError(t, PropertyQuery{Type: "olm.gvk", Path: "kind"}.Validate())
+
+	_, err := PropertyQuery{Type: "olm.gvk", Path: "kind"}.Matches([]*api.Property{{Type: "olm.gvk", Value: "{"}})
+	require.Error(t, err)
+}
@@ -2,20 +2,104 @@ package server
 
 import (
 	"context"
+	"sync"
 
+	"google.golang.org/grpc/codes"
 	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	// RegistryHealthService is the service name that clients of the
+	// registry have historically checked.
+	RegistryHealthService = "Registry"
+	// APIRegistryHealthService is the name of the Registry gRPC service.
+	APIRegistryHealthService = "api.Registry"
+)
+
+// HealthServer implements the gRPC health service. Until a status has been
+// set for any service, every service is reported as SERVING. Once a status
+// has been set, only the services with a status are known, and checks for
+// other services fail with codes.NotFound.
 type HealthServer struct {
 	health.UnimplementedHealthServer
+
+	mu       sync.RWMutex
+	statuses map[string]health.HealthCheckResponse_ServingStatus
+	// changed is closed and replaced whenever a status changes.
+	changed chan struct{}
 }
 
 var _ health.HealthServer = &HealthServer{}
 
 func NewHealthServer() *HealthServer {
-	return &HealthServer{health.UnimplementedHealthServer{}}
+	return &HealthServer{
+		UnimplementedHealthServer: health.UnimplementedHealthServer{},
+		changed:                   make(chan struct{}),
+	}
+}
+
+// SetServingStatus sets the status that is reported for service, and
+// notifies the clients that watch it.
+func (s *HealthServer) SetServingStatus(service string, servingStatus health.HealthCheckResponse_ServingStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.statuses == nil {
+		s.statuses = map[string]health.HealthCheckResponse_ServingStatus{}
+	}
+	if current, ok := s.statuses[service]; ok && current == servingStatus {
+		return
+	}
+	s.statuses[service] = servingStatus
+	if s.changed != nil {
+		close(s.changed)
+	}
+	s.changed = make(chan struct{})
+}
+
+// servingStatus returns the status of service, whether service is known, and
+// a channel that is closed when any status changes.
+func (s *HealthServer) servingStatus(service string) (health.HealthCheckResponse_ServingStatus, bool, <-chan struct{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.statuses) == 0 {
+		return health.HealthCheckResponse_SERVING, true, s.changed
+	}
+	servingStatus, ok := s.statuses[service]
+	return servingStatus, ok, s.changed
 }
 
 func (s *HealthServer) Check(ctx context.Context, req *health.HealthCheckRequest) (*health.HealthCheckResponse, error) {
-	return &health.HealthCheckResponse{Status: health.HealthCheckResponse_SERVING}, nil
+	servingStatus, ok, _ := s.servingStatus(req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &health.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// Watch sends the status of the requested service, and then sends it again
+// whenever it changes, until the client cancels the stream. The status of an
+// unknown service is sent as SERVICE_UNKNOWN.
+func (s *HealthServer) Watch(req *health.HealthCheckRequest, stream health.Health_WatchServer) error {
+	var (
+		last health.HealthCheckResponse_ServingStatus
+		sent bool
+	)
+	for {
+		servingStatus, ok, changed := s.servingStatus(req.GetService())
+		if !ok {
+			servingStatus = health.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if !sent || servingStatus != last {
+			if err := stream.Send(&health.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+			last, sent = servingStatus, true
+		}
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-changed:
+		}
+	}
 }
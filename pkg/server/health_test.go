@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthServer(t *testing.T) {
+	type spec struct {
+		name       string
+		statuses   map[string]health.HealthCheckResponse_ServingStatus
+		service    string
+		expected   health.HealthCheckResponse_ServingStatus
+		expectCode codes.Code
+	}
+	statuses := map[string]health.HealthCheckResponse_ServingStatus{
+		"":                       health.HealthCheckResponse_SERVING,
+		RegistryHealthService:    health.HealthCheckResponse_SERVING,
+		APIRegistryHealthService: health.HealthCheckResponse_SERVING,
+		"a":                      health.HealthCheckResponse_SERVING,
+		"b":                      health.HealthCheckResponse_NOT_SERVING,
+	}
+	specs := []spec{
+		{name: "NoStatuses/Registry", service: RegistryHealthService, expected: health.HealthCheckResponse_SERVING},
+		{name: "NoStatuses/Any", service: "any", expected: health.HealthCheckResponse_SERVING},
+		{name: "Statuses/Server", statuses: statuses, service: "", expected: health.HealthCheckResponse_SERVING},
+		{name: "Statuses/Registry", statuses: statuses, service: RegistryHealthService, expected: health.HealthCheckResponse_SERVING},
+		{name: "Statuses/APIRegistry", statuses: statuses, service: APIRegistryHealthService, expected: health.HealthCheckResponse_SERVING},
+		{name: "Statuses/Serving", statuses: statuses, service: "a", expected: health.HealthCheckResponse_SERVING},
+		{name: "Statuses/NotServing", statuses: statuses, service: "b", expected: health.HealthCheckResponse_NOT_SERVING},
+		{name: "Statuses/Unknown", statuses: statuses, service: "c", expectCode: codes.NotFound},
+		{
+			name:     "NotServingUntilLoaded",
+			statuses: map[string]health.HealthCheckResponse_ServingStatus{"": health.HealthCheckResponse_NOT_SERVING},
+			service:  "",
+			expected: health.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			hs := NewHealthServer()
+			for service, servingStatus := range s.statuses {
+				hs.SetServingStatus(service, servingStatus)
+			}
+			res, err := hs.Check(context.Background(), &health.HealthCheckRequest{Service: s.service})
+			if s.expectCode != codes.OK {
+				require.Equal(t, s.expectCode, status.Code(err), err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, s.expected, res.GetStatus())
+		})
+	}
+}
+
+type testWatchServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan health.HealthCheckResponse_ServingStatus
+}
+
+func (s *testWatchServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testWatchServer) Send(res *health.HealthCheckResponse) error {
+	s.responses <- res.GetStatus()
+	return nil
+}
+
+func TestHealthServerWatch(t *testing.T) {
+	hs := NewHealthServer()
+	hs.SetServingStatus(APIRegistryHealthService, health.HealthCheckResponse_NOT_SERVING)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := func(service string) (*testWatchServer, <-chan error) {
+		stream := &testWatchServer{ctx: ctx, responses: make(chan health.HealthCheckResponse_ServingStatus, 10)}
+		done := make(chan error, 1)
+		go func() {
+			done <- hs.Watch(&health.HealthCheckRequest{Service: service}, stream)
+		}()
+		return stream, done
+	}
+	next := func(stream *testWatchServer) health.HealthCheckResponse_ServingStatus {
+		select {
+		case s := <-stream.responses:
+			return s
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a health status")
+			return health.HealthCheckResponse_UNKNOWN
+		}
+	}
+
+	registry, registryDone := watch(APIRegistryHealthService)
+	unknown, _ := watch("unknown")
+	require.Equal(t, health.HealthCheckResponse_NOT_SERVING, next(registry))
+	require.Equal(t, health.HealthCheckResponse_SERVICE_UNKNOWN, next(unknown))
+
+	// Changes to other services and unchanged statuses are not sent.
+	hs.SetServingStatus("other", health.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(APIRegistryHealthService, health.HealthCheckResponse_NOT_SERVING)
+	hs.SetServingStatus(APIRegistryHealthService, health.HealthCheckResponse_SERVING)
+	require.Equal(t, health.HealthCheckResponse_SERVING, next(registry))
+	hs.SetServingStatus(APIRegistryHealthService, health.HealthCheckResponse_NOT_SERVING)
+	require.Equal(t, health.HealthCheckResponse_NOT_SERVING, next(registry))
+
+	hs.SetServingStatus("unknown", health.HealthCheckResponse_SERVING)
+	require.Equal(t, health.HealthCheckResponse_SERVING, next(unknown))
+
+	cancel()
+	select {
+	case err := <-registryDone:
+		require.Equal(t, codes.Canceled, status.Code(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the watch to return")
+	}
+	require.Empty(t, registry.responses)
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/operator-framework/operator-registry/alpha/declcfg"
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/cache"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+// NewHTTPHandler returns a handler that serves the content of store as JSON,
+// using the same representation of packages and bundles as the Registry gRPC
+// service. The raw declarative config metas are served if store is a
+// cache.MetaSender.
+//
+// The handler serves:
+//
+//	GET /api/v1/packages                                                list package names
+//	GET /api/v1/packages/{package}                                      get a package
+//	GET /api/v1/packages/{package}/channels/{channel}/bundles/{bundle}  get a bundle
+//	GET /api/v1/bundles?package=&channel=                               list bundles
+//	GET /api/v1/metas?schema=&package=&name=                            list raw metas as JSON lines
+func NewHTTPHandler(store registry.GRPCQuery) http.Handler {
+	h := &httpHandler{store: store}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/packages", h.listPackages)
+	mux.HandleFunc("GET /api/v1/packages/{package}", h.getPackage)
+	mux.HandleFunc("GET /api/v1/packages/{package}/channels/{channel}/bundles/{bundle}", h.getBundle)
+	mux.HandleFunc("GET /api/v1/bundles", h.listBundles)
+	mux.HandleFunc("GET /api/v1/metas", h.listMetas)
+	return mux
+}
+
+type httpHandler struct {
+	store registry.GRPCQuery
+}
+
+var errMetasNotServed = status.Error(codes.Unimplemented, "declarative config metas are not served from this store")
+
+func (h *httpHandler) listPackages(w http.ResponseWriter, r *http.Request) {
+	names, err := h.store.ListPackages(r.Context())
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if names == nil {
+		names = []string{}
+	}
+	sort.Strings(names)
+	writeJSON(w, names)
+}
+
+func (h *httpHandler) getPackage(w http.ResponseWriter, r *http.Request) {
+	pkg, err := h.store.GetPackage(r.Context(), r.PathValue("package"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeProto(w, registry.PackageManifestToAPIPackage(pkg))
+}
+
+func (h *httpHandler) getBundle(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.store.GetBundle(r.Context(), r.PathValue("package"), r.PathValue("channel"), r.PathValue("bundle"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeProto(w, bundle)
+}
+
+// listBundles streams the bundles as a JSON array, so that the bundles do not
+// need to be held in memory. Because the status is sent with the first
+// bundle, an error while streaming truncates the response.
+func (h *httpHandler) listBundles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := registry.BundleFilter{
+		PackageName: query.Get("package"),
+		ChannelName: query.Get("channel"),
+	}
+	sender := &httpBundleSender{w: w}
+	if err := registry.SendFilteredBundles(r.Context(), h.store, sender, filter); err != nil {
+		if !sender.started {
+			writeHTTPError(w, err)
+		}
+		return
+	}
+	if !sender.started {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]\n"))
+		return
+	}
+	w.Write([]byte("]\n"))
+}
+
+type httpBundleSender struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (s *httpBundleSender) Send(b *api.Bundle) error {
+	data, err := protojson.Marshal(b)
+	if err != nil {
+		return err
+	}
+	sep := []byte(",\n")
+	if !s.started {
+		s.w.Header().Set("Content-Type", "application/json")
+		sep = []byte("[\n")
+		s.started = true
+	}
+	if _, err := s.w.Write(sep); err != nil {
+		return err
+	}
+	_, err = s.w.Write(data)
+	return err
+}
+
+// listMetas streams the metas that match the schema, package and name query
+// parameters as JSON lines, grouped by package. Because the status is sent
+// with the first meta, an error while streaming truncates the response.
+func (h *httpHandler) listMetas(w http.ResponseWriter, r *http.Request) {
+	metas, ok := h.store.(cache.MetaSender)
+	if !ok {
+		writeHTTPError(w, errMetasNotServed)
+		return
+	}
+	query := r.URL.Query()
+	filter := cache.MetaFilter{
+		Schema:  query.Get("schema"),
+		Package: query.Get("package"),
+		Name:    query.Get("name"),
+	}
+
+	started := false
+	err := metas.SendMetas(r.Context(), filter, func(meta *declcfg.Meta) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/jsonl")
+			started = true
+		}
+		if _, err := w.Write(meta.Blob); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte("\n"))
+		return err
+	})
+	if err != nil {
+		if !started {
+			writeHTTPError(w, err)
+		}
+		return
+	}
+	if !started {
+		w.Header().Set("Content-Type", "application/jsonl")
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+func writeProto(w http.ResponseWriter, m proto.Message) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(data, '\n'))
+}
+
+func writeHTTPError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, cache.ErrNotFound):
+		code = http.StatusNotFound
+	case status.Code(err) == codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	case status.Code(err) == codes.Unimplemented:
+		code = http.StatusNotImplemented
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	store, err := fbcCacheFromFs(validFS, t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+	srv := httptest.NewServer(NewHTTPHandler(store))
+	defer srv.Close()
+
+	get := func(t *testing.T, path string, expectStatus int) []byte {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, expectStatus, resp.StatusCode, string(body))
+		return body
+	}
+
+	t.Run("ListPackages", func(t *testing.T) {
+		var names []string
+		require.NoError(t, json.Unmarshal(get(t, "/api/v1/packages", http.StatusOK), &names))
+		require.Equal(t, []string{"cockroachdb"}, names)
+	})
+
+	t.Run("GetPackage", func(t *testing.T) {
+		var pkg api.Package
+		require.NoError(t, protojson.Unmarshal(get(t, "/api/v1/packages/cockroachdb", http.StatusOK), &pkg))
+		require.Equal(t, "cockroachdb", pkg.GetName())
+		require.Len(t, pkg.GetChannels(), 2)
+	})
+
+	t.Run("GetPackage/NotFound", func(t *testing.T) {
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(get(t, "/api/v1/packages/etcd", http.StatusNotFound), &body))
+		require.Equal(t, `package "etcd" not found`, body["error"])
+	})
+
+	t.Run("GetBundle", func(t *testing.T) {
+		var bundle api.Bundle
+		require.NoError(t, protojson.Unmarshal(get(t, "/api/v1/packages/cockroachdb/channels/stable-5.x/bundles/cockroachdb.v5.0.4", http.StatusOK), &bundle))
+		require.Equal(t, "cockroachdb.v5.0.4", bundle.GetCsvName())
+		require.Equal(t, "stable-5.x", bundle.GetChannelName())
+	})
+
+	t.Run("GetBundle/NotFound", func(t *testing.T) {
+		get(t, "/api/v1/packages/cockroachdb/channels/stable-5.x/bundles/cockroachdb.v6.0.0", http.StatusNotFound)
+	})
+
+	t.Run("ListBundles", func(t *testing.T) {
+		type spec struct {
+			query    string
+			expected []string
+		}
+		specs := []spec{
+			{query: "", expected: []string{"cockroachdb.v5.0.3", "cockroachdb.v5.0.4", "cockroachdb.v6.0.0"}},
+			{query: "?package=cockroachdb&channel=stable-v6.x", expected: []string{"cockroachdb.v6.0.0"}},
+			{query: "?package=etcd", expected: []string{}},
+		}
+		for _, s := range specs {
+			var raw []json.RawMessage
+			require.NoError(t, json.Unmarshal(get(t, "/api/v1/bundles"+s.query, http.StatusOK), &raw))
+			names := []string{}
+			for _, r := range raw {
+				var b api.Bundle
+				require.NoError(t, protojson.Unmarshal(r, &b))
+				names = append(names, b.GetCsvName())
+			}
+			require.ElementsMatch(t, s.expected, names, s.query)
+		}
+	})
+
+	t.Run("ListMetas", func(t *testing.T) {
+		type spec struct {
+			query    string
+			expected []string
+		}
+		specs := []spec{
+			{query: "?schema=olm.channel", expected: []string{"stable-5.x", "stable-v6.x"}},
+			{query: "?package=cockroachdb&schema=olm.package", expected: []string{"cockroachdb"}},
+			{query: "?schema=olm.bundle&name=cockroachdb.v5.0.3", expected: []string{"cockroachdb.v5.0.3"}},
+			{query: "?package=etcd", expected: nil},
+		}
+		for _, s := range specs {
+			body := get(t, "/api/v1/metas"+s.query, http.StatusOK)
+			var names []string
+			scanner := bufio.NewScanner(bytes.NewReader(body))
+			scanner.Buffer(nil, 1<<20)
+			for scanner.Scan() {
+				var meta struct {
+					Name string `json:"name"`
+				}
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &meta))
+				names = append(names, meta.Name)
+			}
+			require.Equal(t, s.expected, names, s.query)
+		}
+	})
+	t.Run("ListMetas/NotServed", func(t *testing.T) {
+		srv := httptest.NewServer(NewHTTPHandler(struct{ registry.GRPCQuery }{store}))
+		defer srv.Close()
+		resp, err := http.Get(srv.URL + "/api/v1/metas")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+	})
+}
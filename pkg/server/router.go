@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/operator-framework/operator-registry/pkg/api"
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+// CatalogMetadataKey is the key of the request metadata that selects the
+// catalog that serves a request routed by a CatalogRouter.
+const CatalogMetadataKey = "catalog"
+
+var _ registry.GRPCQuery = &CatalogRouter{}
+
+// CatalogRouter serves queries from one of several named catalogs, chosen by
+// the CatalogMetadataKey metadata of the incoming request.
+type CatalogRouter struct {
+	catalogs map[string]registry.GRPCQuery
+}
+
+// NewCatalogRouter returns a CatalogRouter that serves the given catalogs. A
+// catalog with a nil store is known but unavailable, e.g. because it could
+// not be loaded, and queries for it fail with codes.Unavailable. Queries for
+// a catalog that is not in catalogs fail with codes.NotFound.
+func NewCatalogRouter(catalogs map[string]registry.GRPCQuery) *CatalogRouter {
+	return &CatalogRouter{catalogs: catalogs}
+}
+
+func (r *CatalogRouter) route(ctx context.Context) (registry.GRPCQuery, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	names := md.Get(CatalogMetadataKey)
+	if len(names) != 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "exactly one %q metadata value must be set to select a catalog, found %d", CatalogMetadataKey, len(names))
+	}
+	store, ok := r.catalogs[names[0]]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "catalog %q not found", names[0])
+	}
+	if store == nil {
+		return nil, status.Errorf(codes.Unavailable, "catalog %q is not available", names[0])
+	}
+	return store, nil
+}
+
+func (r *CatalogRouter) ListPackages(ctx context.Context) ([]string, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListPackages(ctx)
+}
+
+func (r *CatalogRouter) SendBundles(ctx context.Context, stream registry.BundleSender) error {
+	store, err := r.route(ctx)
+	if err != nil {
+		return err
+	}
+	return store.SendBundles(ctx, stream)
+}
+
+func (r *CatalogRouter) SendFilteredBundles(ctx context.Context, stream registry.BundleSender, filter registry.BundleFilter) error {
+	store, err := r.route(ctx)
+	if err != nil {
+		return err
+	}
+	return registry.SendFilteredBundles(ctx, store, stream, filter)
+}
+
+func (r *CatalogRouter) ListBundles(ctx context.Context) ([]*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListBundles(ctx)
+}
+
+func (r *CatalogRouter) GetPackage(ctx context.Context, name string) (*registry.PackageManifest, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetPackage(ctx, name)
+}
+
+func (r *CatalogRouter) GetBundle(ctx context.Context, pkgName, channelName, csvName string) (*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBundle(ctx, pkgName, channelName, csvName)
+}
+
+func (r *CatalogRouter) GetBundleForChannel(ctx context.Context, pkgName string, channelName string) (*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBundleForChannel(ctx, pkgName, channelName)
+}
+
+func (r *CatalogRouter) GetChannelEntriesThatReplace(ctx context.Context, name string) ([]*registry.ChannelEntry, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetChannelEntriesThatReplace(ctx, name)
+}
+
+func (r *CatalogRouter) GetBundleThatReplaces(ctx context.Context, name, pkgName, channelName string) (*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBundleThatReplaces(ctx, name, pkgName, channelName)
+}
+
+func (r *CatalogRouter) GetChannelEntriesThatProvide(ctx context.Context, group, version, kind string) ([]*registry.ChannelEntry, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetChannelEntriesThatProvide(ctx, group, version, kind)
+}
+
+func (r *CatalogRouter) GetLatestChannelEntriesThatProvide(ctx context.Context, group, version, kind string) ([]*registry.ChannelEntry, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetLatestChannelEntriesThatProvide(ctx, group, version, kind)
+}
+
+func (r *CatalogRouter) GetBundleThatProvides(ctx context.Context, group, version, kind string) (*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBundleThatProvides(ctx, group, version, kind)
+}
+
+func (r *CatalogRouter) ListChannelEntries(ctx context.Context, pkgName, channelName string) ([]*registry.ChannelEntry, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListChannelEntries(ctx, pkgName, channelName)
+}
+
+func (r *CatalogRouter) GetDeprecations(ctx context.Context, pkgName string) (*api.Deprecations, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetDeprecations(ctx, pkgName)
+}
+
+func (r *CatalogRouter) GetCatalogInfo(ctx context.Context) (*api.CatalogInfo, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetCatalogInfo(ctx)
+}
+
+func (r *CatalogRouter) GetBundlesInRange(ctx context.Context, pkgName, versionRange string) ([]*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBundlesInRange(ctx, pkgName, versionRange)
+}
+
+func (r *CatalogRouter) GetBundleByImage(ctx context.Context, image string) (*api.Bundle, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBundleByImage(ctx, image)
+}
+
+func (r *CatalogRouter) FindBundles(ctx context.Context, query registry.PropertyQuery) ([]*api.BundleKey, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.FindBundles(ctx, query)
+}
+
+func (r *CatalogRouter) GetKindForPlural(ctx context.Context, group, version, plural string) (string, error) {
+	store, err := r.route(ctx)
+	if err != nil {
+		return "", err
+	}
+	return store.GetKindForPlural(ctx, group, version, plural)
+}
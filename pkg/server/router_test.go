@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/operator-framework/operator-registry/pkg/registry"
+)
+
+func TestCatalogRouter(t *testing.T) {
+	store, err := fbcCacheFromFs(validFS, t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+	router := NewCatalogRouter(map[string]registry.GRPCQuery{
+		"cockroachdb": store,
+		"unavailable": nil,
+	})
+
+	type spec struct {
+		name       string
+		catalogs   []string
+		expected   []string
+		expectCode codes.Code
+	}
+	specs := []spec{
+		{name: "Routed", catalogs: []string{"cockroachdb"}, expected: []string{"cockroachdb"}},
+		{name: "Unavailable", catalogs: []string{"unavailable"}, expectCode: codes.Unavailable},
+		{name: "NotFound", catalogs: []string{"etcd"}, expectCode: codes.NotFound},
+		{name: "Missing", expectCode: codes.InvalidArgument},
+		{name: "Multiple", catalogs: []string{"cockroachdb", "cockroachdb"}, expectCode: codes.InvalidArgument},
+	}
+	for _, s := range specs {
+		t.Run(s.name, func(t *testing.T) {
+			md := metadata.MD{}
+			for _, c := range s.catalogs {
+				md.Append(CatalogMetadataKey, c)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			pkgs, err := router.ListPackages(ctx)
+			if s.expectCode != codes.OK {
+				require.Equal(t, s.expectCode, status.Code(err), err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, s.expected, pkgs)
+		})
+	}
+}
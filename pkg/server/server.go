@@ -1,7 +1,13 @@
 package server
 
 import (
+	"encoding/base64"
+	"strconv"
+
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/operator-framework/operator-registry/pkg/api"
 	"github.com/operator-framework/operator-registry/pkg/registry"
@@ -32,8 +38,76 @@ func (s *RegistryServer) ListPackages(req *api.ListPackageRequest, stream api.Re
 	return nil
 }
 
+// NextPageTokenKey is the trailer in which ListBundles returns the token of
+// the next page, when a page size is requested and more bundles remain.
+const NextPageTokenKey = "next-page-token"
+
 func (s *RegistryServer) ListBundles(req *api.ListBundlesRequest, stream api.Registry_ListBundlesServer) error {
-	return s.store.SendBundles(stream.Context(), stream)
+	offset, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid page token %q", req.GetPageToken())
+	}
+	filter := registry.BundleFilter{
+		PackageName: req.GetPkgName(),
+		ChannelName: req.GetChannelName(),
+		Fields:      req.GetFieldMask(),
+		Offset:      offset,
+	}
+	if err := filter.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid page size %d", pageSize)
+	}
+	if pageSize == 0 {
+		return registry.SendFilteredBundles(stream.Context(), s.store, stream, filter)
+	}
+
+	// Ask for one bundle more than fits in the page, to learn whether
+	// there is a next page.
+	filter.Limit = pageSize + 1
+	sender := &pageBundleSender{stream: stream, remaining: pageSize}
+	if err := registry.SendFilteredBundles(stream.Context(), s.store, sender, filter); err != nil {
+		return err
+	}
+	if sender.more {
+		stream.SetTrailer(metadata.Pairs(NextPageTokenKey, encodePageToken(offset+pageSize)))
+	}
+	return nil
+}
+
+// pageBundleSender sends bundles until the page is full, and records whether
+// any bundles were left over.
+type pageBundleSender struct {
+	stream    registry.BundleSender
+	remaining int
+	more      bool
+}
+
+func (s *pageBundleSender) Send(b *api.Bundle) error {
+	if s.remaining == 0 {
+		s.more = true
+		return nil
+	}
+	s.remaining--
+	return s.stream.Send(b)
+}
+
+// Page tokens encode the number of matching bundles that precede the page.
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(b))
 }
 
 func (s *RegistryServer) GetPackage(ctx context.Context, req *api.GetPackageRequest) (*api.Package, error) {
@@ -70,7 +144,11 @@ func (s *RegistryServer) GetBundleThatReplaces(ctx context.Context, req *api.Get
 }
 
 func (s *RegistryServer) GetChannelEntriesThatProvide(req *api.GetAllProvidersRequest, stream api.Registry_GetChannelEntriesThatProvideServer) error {
-	channelEntries, err := s.store.GetChannelEntriesThatProvide(stream.Context(), req.GetGroup(), req.GetVersion(), req.GetKind())
+	kind, err := s.providedKind(stream.Context(), req.GetGroup(), req.GetVersion(), req.GetKind(), req.GetPlural())
+	if err != nil {
+		return err
+	}
+	channelEntries, err := s.store.GetChannelEntriesThatProvide(stream.Context(), req.GetGroup(), req.GetVersion(), kind)
 	if err != nil {
 		return err
 	}
@@ -83,7 +161,11 @@ func (s *RegistryServer) GetChannelEntriesThatProvide(req *api.GetAllProvidersRe
 }
 
 func (s *RegistryServer) GetLatestChannelEntriesThatProvide(req *api.GetLatestProvidersRequest, stream api.Registry_GetLatestChannelEntriesThatProvideServer) error {
-	channelEntries, err := s.store.GetLatestChannelEntriesThatProvide(stream.Context(), req.GetGroup(), req.GetVersion(), req.GetKind())
+	kind, err := s.providedKind(stream.Context(), req.GetGroup(), req.GetVersion(), req.GetKind(), req.GetPlural())
+	if err != nil {
+		return err
+	}
+	channelEntries, err := s.store.GetLatestChannelEntriesThatProvide(stream.Context(), req.GetGroup(), req.GetVersion(), kind)
 	if err != nil {
 		return err
 	}
@@ -96,5 +178,90 @@ func (s *RegistryServer) GetLatestChannelEntriesThatProvide(req *api.GetLatestPr
 }
 
 func (s *RegistryServer) GetDefaultBundleThatProvides(ctx context.Context, req *api.GetDefaultProviderRequest) (*api.Bundle, error) {
-	return s.store.GetBundleThatProvides(ctx, req.GetGroup(), req.GetVersion(), req.GetKind())
+	kind, err := s.providedKind(ctx, req.GetGroup(), req.GetVersion(), req.GetKind(), req.GetPlural())
+	if err != nil {
+		return nil, err
+	}
+	return s.store.GetBundleThatProvides(ctx, req.GetGroup(), req.GetVersion(), kind)
+}
+
+// providedKind returns the kind of the API named by a provider request. If
+// the request names the API by its resource plural, the kind is looked up in
+// the store. If the request also has a kind, the kind is used when the plural
+// is not known to the store, and must agree with the kind of the plural
+// otherwise.
+func (s *RegistryServer) providedKind(ctx context.Context, group, version, kind, plural string) (string, error) {
+	if plural == "" {
+		return kind, nil
+	}
+	pluralKind, err := s.store.GetKindForPlural(ctx, group, version, plural)
+	if err != nil {
+		if kind != "" {
+			return kind, nil
+		}
+		return "", err
+	}
+	if kind != "" && kind != pluralKind {
+		return "", status.Errorf(codes.InvalidArgument, "kind %q does not match plural %q of kind %q", kind, plural, pluralKind)
+	}
+	return pluralKind, nil
+}
+
+func (s *RegistryServer) ListChannelEntries(req *api.ListChannelEntriesRequest, stream api.Registry_ListChannelEntriesServer) error {
+	channelEntries, err := s.store.ListChannelEntries(stream.Context(), req.GetPkgName(), req.GetChannelName())
+	if err != nil {
+		return err
+	}
+	for _, e := range channelEntries {
+		if err := stream.Send(registry.ChannelEntryToAPIChannelEntry(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RegistryServer) GetDeprecations(ctx context.Context, req *api.GetDeprecationsRequest) (*api.Deprecations, error) {
+	return s.store.GetDeprecations(ctx, req.GetPkgName())
+}
+
+func (s *RegistryServer) GetCatalogInfo(ctx context.Context, req *api.GetCatalogInfoRequest) (*api.CatalogInfo, error) {
+	return s.store.GetCatalogInfo(ctx)
+}
+
+func (s *RegistryServer) GetBundlesInRange(req *api.GetBundlesInRangeRequest, stream api.Registry_GetBundlesInRangeServer) error {
+	bundles, err := s.store.GetBundlesInRange(stream.Context(), req.GetPkgName(), req.GetVersionRange())
+	if err != nil {
+		return err
+	}
+	for _, b := range bundles {
+		if err := stream.Send(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *RegistryServer) GetBundleByImage(ctx context.Context, req *api.GetBundleByImageRequest) (*api.Bundle, error) {
+	return s.store.GetBundleByImage(ctx, req.GetImage())
+}
+
+func (s *RegistryServer) FindBundles(req *api.FindBundlesRequest, stream api.Registry_FindBundlesServer) error {
+	query := registry.PropertyQuery{
+		Type:  req.GetPropertyType(),
+		Path:  req.GetPath(),
+		Value: req.GetValue(),
+	}
+	if err := query.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	keys, err := s.store.FindBundles(stream.Context(), query)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := stream.Send(k); err != nil {
+			return err
+		}
+	}
+	return nil
 }
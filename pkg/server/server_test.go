@@ -18,7 +18,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/operator-framework/operator-registry/alpha/action"
 	"github.com/operator-framework/operator-registry/alpha/declcfg"
@@ -787,6 +790,454 @@ func testListBundles(addr string, etcdAlpha *api.Bundle, etcdStable *api.Bundle)
 	}
 }
 
+func TestListChannelEntries(t *testing.T) {
+	var (
+		listChannelEntriesExpected = []*api.ChannelEntry{
+			{PackageName: "etcd", ChannelName: "beta", BundleName: "etcdoperator.v0.9.0", Replaces: "etcdoperator.v0.6.1"},
+			{PackageName: "etcd", ChannelName: "beta", BundleName: "etcdoperator.v0.6.1", Replaces: ""},
+		}
+		listChannelEntriesExpectedDep = []*api.ChannelEntry{
+			{PackageName: "cockroachdb", ChannelName: "stable-5.x", BundleName: "cockroachdb.v5.0.4", Replaces: "cockroachdb.v5.0.3"},
+			{PackageName: "cockroachdb", ChannelName: "stable-5.x", BundleName: "cockroachdb.v5.0.3", Replaces: ""},
+		}
+	)
+	t.Run("Sqlite", testListChannelEntries(dbAddress, listChannelEntriesExpected))
+	t.Run("FBCCache", testListChannelEntries(cacheAddress, listChannelEntriesExpected))
+	t.Run("FBCCacheWithDeprecations", testListChannelEntries(deprecationCacheAddress, listChannelEntriesExpectedDep))
+	t.Run("SqliteNotFound", testListChannelEntriesNotFound(dbAddress))
+	t.Run("FBCCacheNotFound", testListChannelEntriesNotFound(cacheAddress))
+}
+
+func testListChannelEntries(addr string, expected []*api.ChannelEntry) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		stream, err := c.ListChannelEntries(context.TODO(), &api.ListChannelEntriesRequest{PkgName: expected[0].PackageName, ChannelName: expected[0].ChannelName})
+		require.NoError(t, err)
+
+		channelEntries := []*api.ChannelEntry{}
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			channelEntries = append(channelEntries, in)
+		}
+
+		opts := []cmp.Option{
+			cmpopts.IgnoreUnexported(api.ChannelEntry{}),
+			cmpopts.SortSlices(func(x, y *api.ChannelEntry) bool {
+				if x.BundleName != y.BundleName {
+					return x.BundleName < y.BundleName
+				}
+				return x.Replaces < y.Replaces
+			}),
+		}
+
+		require.Truef(t, cmp.Equal(expected, channelEntries, opts...), cmp.Diff(expected, channelEntries, opts...))
+	}
+}
+
+func testListChannelEntriesNotFound(addr string) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		stream, err := c.ListChannelEntries(context.TODO(), &api.ListChannelEntriesRequest{PkgName: "etcd", ChannelName: "missing"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+		require.NotEqual(t, io.EOF, err)
+	}
+}
+
+func TestGetDeprecations(t *testing.T) {
+	var (
+		getDeprecationsExpected = &api.Deprecations{
+			PackageName: "etcd",
+		}
+		getDeprecationsExpectedDep = &api.Deprecations{
+			PackageName: "cockroachdb",
+			Deprecation: &api.Deprecation{
+				Message: "package cockroachdb is end of life.  Please use 'nouveau-cockroachdb' package for support.\n",
+			},
+			Channels: []*api.ChannelDeprecation{
+				{
+					ChannelName: "stable-5.x",
+					Deprecation: &api.Deprecation{
+						Message: "channel stable-5.x is no longer supported.  Please switch to channel 'stable-6.x'.\n",
+					},
+				},
+			},
+			Bundles: []*api.BundleDeprecation{
+				{
+					CsvName: "cockroachdb.v5.0.3",
+					Deprecation: &api.Deprecation{
+						Message: "cockroachdb.v5.0.3 is deprecated. Uninstall and install cockroachdb.v5.0.4 for support.\n",
+					},
+				},
+			},
+		}
+	)
+	t.Run("Sqlite", testGetDeprecations(dbAddress, getDeprecationsExpected))
+	t.Run("FBCCache", testGetDeprecations(cacheAddress, getDeprecationsExpected))
+	t.Run("FBCCacheWithDeprecations", testGetDeprecations(deprecationCacheAddress, getDeprecationsExpectedDep))
+}
+
+func testGetDeprecations(addr string, expected *api.Deprecations) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		deprecations, err := c.GetDeprecations(context.TODO(), &api.GetDeprecationsRequest{PkgName: expected.PackageName})
+		require.NoError(t, err)
+
+		opts := []cmp.Option{
+			cmpopts.IgnoreUnexported(api.Deprecations{}, api.Deprecation{}, api.ChannelDeprecation{}, api.BundleDeprecation{}),
+		}
+		require.Truef(t, cmp.Equal(expected, deprecations, opts...), cmp.Diff(expected, deprecations, opts...))
+
+		_, err = c.GetDeprecations(context.TODO(), &api.GetDeprecationsRequest{PkgName: "missing"})
+		require.Error(t, err)
+	}
+}
+
+func TestGetCatalogInfo(t *testing.T) {
+	t.Run("Sqlite", testGetCatalogInfo(dbAddress, &api.CatalogInfo{Packages: 3, Bundles: 10, Backend: "sqlite"}))
+	t.Run("FBCCache", testGetCatalogInfo(cacheAddress, &api.CatalogInfo{Packages: 3, Bundles: 10, Backend: "pogreb.v1"}))
+	t.Run("FBCCacheWithDeprecations", testGetCatalogInfo(deprecationCacheAddress, &api.CatalogInfo{Packages: 1, Bundles: 3, Backend: "pogreb.v1"}))
+}
+
+func testGetCatalogInfo(addr string, expected *api.CatalogInfo) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		info, err := c.GetCatalogInfo(context.TODO(), &api.GetCatalogInfoRequest{})
+		require.NoError(t, err)
+		require.Equal(t, expected.Backend, info.Backend)
+		require.Equal(t, expected.Packages, info.Packages)
+		require.Equal(t, expected.Bundles, info.Bundles)
+
+		if expected.Backend == "sqlite" {
+			require.Empty(t, info.Digest)
+			require.Empty(t, info.BuildTime)
+			return
+		}
+		require.NotEmpty(t, info.Digest)
+		_, err = time.Parse(time.RFC3339, info.BuildTime)
+		require.NoError(t, err)
+	}
+}
+
+func TestListBundlesFiltered(t *testing.T) {
+	t.Run("Sqlite", testListBundlesFiltered(dbAddress))
+	t.Run("FBCCache", testListBundlesFiltered(cacheAddress))
+}
+
+func testListBundlesFiltered(addr string) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		list := func(req *api.ListBundlesRequest) ([]*api.Bundle, string) {
+			var trailer metadata.MD
+			stream, err := c.ListBundles(context.TODO(), req, grpc.Trailer(&trailer))
+			require.NoError(t, err)
+			var bundles []*api.Bundle
+			for {
+				in, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				bundles = append(bundles, in)
+			}
+			var token string
+			if values := trailer.Get(NextPageTokenKey); len(values) > 0 {
+				token = values[0]
+			}
+			return bundles, token
+		}
+
+		t.Run("PackageAndChannel", func(t *testing.T) {
+			bundles, token := list(&api.ListBundlesRequest{PkgName: "etcd", ChannelName: "alpha"})
+			require.Empty(t, token)
+			var names []string
+			for _, b := range bundles {
+				require.Equal(t, "etcd", b.PackageName)
+				require.Equal(t, "alpha", b.ChannelName)
+				names = append(names, b.CsvName)
+			}
+			require.ElementsMatch(t, []string{"etcdoperator.v0.6.1", "etcdoperator.v0.9.0", "etcdoperator.v0.9.2"}, names)
+		})
+
+		t.Run("FieldMask", func(t *testing.T) {
+			bundles, _ := list(&api.ListBundlesRequest{PkgName: "etcd", ChannelName: "alpha", FieldMask: []string{"csvName", "version"}})
+			require.Len(t, bundles, 3)
+			for _, b := range bundles {
+				require.NotEmpty(t, b.CsvName)
+				require.NotEmpty(t, b.Version)
+				require.Empty(t, b.PackageName)
+				require.Empty(t, b.CsvJson)
+				require.Empty(t, b.Object)
+				require.Empty(t, b.Properties)
+			}
+		})
+
+		t.Run("Pages", func(t *testing.T) {
+			all, _ := list(&api.ListBundlesRequest{PkgName: "etcd", FieldMask: []string{"csvName", "channelName"}})
+			require.Len(t, all, 8)
+
+			var paged []*api.Bundle
+			req := &api.ListBundlesRequest{PkgName: "etcd", FieldMask: []string{"csvName", "channelName"}, PageSize: 3}
+			for pages := 1; ; pages++ {
+				bundles, token := list(req)
+				require.LessOrEqual(t, len(bundles), 3)
+				paged = append(paged, bundles...)
+				if token == "" {
+					require.Equal(t, 3, pages)
+					break
+				}
+				req.PageToken = token
+			}
+			// Only paged requests are ordered, so the bundles of an unpaged
+			// request may be sent in another order.
+			opts := []cmp.Option{
+				cmpopts.IgnoreUnexported(api.Bundle{}),
+				cmpopts.SortSlices(func(a, b *api.Bundle) bool {
+					return a.ChannelName < b.ChannelName || (a.ChannelName == b.ChannelName && a.CsvName < b.CsvName)
+				}),
+			}
+			require.Truef(t, cmp.Equal(all, paged, opts...), cmp.Diff(all, paged, opts...))
+		})
+
+		t.Run("Invalid", func(t *testing.T) {
+			for _, req := range []*api.ListBundlesRequest{
+				{FieldMask: []string{"unknown"}},
+				{PageSize: -1},
+				{PageToken: "not a token"},
+			} {
+				stream, err := c.ListBundles(context.TODO(), req)
+				require.NoError(t, err)
+				_, err = stream.Recv()
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestGetBundlesInRange(t *testing.T) {
+	t.Run("Sqlite", testGetBundlesInRange(dbAddress, "etcd", ">=0.9.0", []string{"etcdoperator.v0.9.2", "etcdoperator.v0.9.0"}))
+	t.Run("FBCCache", testGetBundlesInRange(cacheAddress, "etcd", ">=0.9.0", []string{"etcdoperator.v0.9.2", "etcdoperator.v0.9.0"}))
+	t.Run("FBCCacheWithDeprecations", testGetBundlesInRange(deprecationCacheAddress, "cockroachdb", ">=5.0.0 <6.0.0", []string{"cockroachdb.v5.0.4", "cockroachdb.v5.0.3"}))
+}
+
+func testGetBundlesInRange(addr, pkgName, versionRange string, expected []string) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		recvAll := func(req *api.GetBundlesInRangeRequest) ([]string, error) {
+			stream, err := c.GetBundlesInRange(context.TODO(), req)
+			require.NoError(t, err)
+			var names []string
+			for {
+				in, err := stream.Recv()
+				if err == io.EOF {
+					return names, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				require.Equal(t, pkgName, in.PackageName)
+				names = append(names, in.CsvName)
+			}
+		}
+
+		names, err := recvAll(&api.GetBundlesInRangeRequest{PkgName: pkgName, VersionRange: versionRange})
+		require.NoError(t, err)
+		require.Equal(t, expected, names)
+
+		names, err = recvAll(&api.GetBundlesInRangeRequest{PkgName: pkgName, VersionRange: ">=100.0.0"})
+		require.NoError(t, err)
+		require.Empty(t, names)
+
+		_, err = recvAll(&api.GetBundlesInRangeRequest{PkgName: pkgName, VersionRange: "not a range"})
+		require.Error(t, err)
+
+		_, err = recvAll(&api.GetBundlesInRangeRequest{PkgName: "missing", VersionRange: versionRange})
+		require.Error(t, err)
+	}
+}
+
+func TestGetBundleByImage(t *testing.T) {
+	t.Run("Sqlite", testGetBundleByImage(dbAddress, "fake/etcd-operator:v0.9.2", "etcdoperator.v0.9.2", "alpha"))
+	t.Run("FBCCache", testGetBundleByImage(cacheAddress, "fake/etcd-operator:v0.9.2", "etcdoperator.v0.9.2", "alpha"))
+	t.Run("FBCCacheWithDeprecations", testGetBundleByImage(deprecationCacheAddress, "quay.io/openshift-community-operators/cockroachdb@sha256:a5d4f4467250074216eb1ba1c36e06a3ab797d81c431427fc2aca97ecaf4e9d8", "cockroachdb.v5.0.3", "stable-5.x"))
+	t.Run("FBCCacheWithDeprecationsDigest", testGetBundleByImage(deprecationCacheAddress, "sha256:f42337e7b85a46d83c94694638e2312e10ca16a03542399a65ba783c94a32b63", "cockroachdb.v5.0.4", "stable-5.x"))
+}
+
+func testGetBundleByImage(addr, image, expectedName, expectedChannel string) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		bundle, err := c.GetBundleByImage(context.TODO(), &api.GetBundleByImageRequest{Image: image})
+		require.NoError(t, err)
+		require.Equal(t, expectedName, bundle.CsvName)
+		require.Equal(t, expectedChannel, bundle.ChannelName)
+
+		_, err = c.GetBundleByImage(context.TODO(), &api.GetBundleByImageRequest{Image: "missing/image:latest"})
+		require.Error(t, err)
+	}
+}
+
+func TestFindBundles(t *testing.T) {
+	var (
+		etcdClusterKeys = []*api.BundleKey{
+			{PackageName: "etcd", ChannelName: "alpha", CsvName: "etcdoperator.v0.6.1"},
+			{PackageName: "etcd", ChannelName: "alpha", CsvName: "etcdoperator.v0.9.0"},
+			{PackageName: "etcd", ChannelName: "alpha", CsvName: "etcdoperator.v0.9.2"},
+			{PackageName: "etcd", ChannelName: "beta", CsvName: "etcdoperator.v0.6.1"},
+			{PackageName: "etcd", ChannelName: "beta", CsvName: "etcdoperator.v0.9.0"},
+			{PackageName: "etcd", ChannelName: "stable", CsvName: "etcdoperator.v0.6.1"},
+			{PackageName: "etcd", ChannelName: "stable", CsvName: "etcdoperator.v0.9.0"},
+			{PackageName: "etcd", ChannelName: "stable", CsvName: "etcdoperator.v0.9.2"},
+		}
+		etcdClusterQuery = &api.FindBundlesRequest{PropertyType: "olm.gvk", Path: "kind", Value: "EtcdCluster"}
+	)
+	t.Run("Sqlite", testFindBundles(dbAddress, etcdClusterQuery, etcdClusterKeys))
+	t.Run("FBCCache", testFindBundles(cacheAddress, etcdClusterQuery, etcdClusterKeys))
+	t.Run("FBCCacheWithDeprecations", testFindBundles(deprecationCacheAddress,
+		&api.FindBundlesRequest{PropertyType: "olm.package", Path: "version", Value: "5.0.3"},
+		[]*api.BundleKey{{PackageName: "cockroachdb", ChannelName: "stable-5.x", CsvName: "cockroachdb.v5.0.3"}},
+	))
+	t.Run("SqliteNoMatch", testFindBundles(dbAddress, &api.FindBundlesRequest{PropertyType: "olm.gvk", Path: "kind", Value: "Missing"}, nil))
+	t.Run("FBCCacheNoMatch", testFindBundles(cacheAddress, &api.FindBundlesRequest{PropertyType: "olm.gvk", Path: "kind", Value: "Missing"}, nil))
+	t.Run("Invalid", testFindBundlesInvalid(cacheAddress))
+}
+
+func testFindBundles(addr string, req *api.FindBundlesRequest, expected []*api.BundleKey) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		stream, err := c.FindBundles(context.TODO(), req)
+		require.NoError(t, err)
+
+		var keys []*api.BundleKey
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			keys = append(keys, in)
+		}
+
+		opts := []cmp.Option{cmpopts.IgnoreUnexported(api.BundleKey{})}
+		require.Truef(t, cmp.Equal(expected, keys, opts...), cmp.Diff(expected, keys, opts...))
+	}
+}
+
+func testFindBundlesInvalid(addr string) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		for _, req := range []*api.FindBundlesRequest{
+			{Path: "kind", Value: "EtcdCluster"},
+			{PropertyType: "olm.gvk", Value: "EtcdCluster"},
+			{PropertyType: "olm.gvk", Path: "kind.", Value: "EtcdCluster"},
+		} {
+			stream, err := c.FindBundles(context.TODO(), req)
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	}
+}
+
+func TestProvidersByPlural(t *testing.T) {
+	t.Run("Sqlite", testProvidersByPlural(dbAddress))
+	t.Run("FBCCache", testProvidersByPlural(cacheAddress))
+}
+
+func testProvidersByPlural(addr string) func(*testing.T) {
+	return func(t *testing.T) {
+		c, conn := client(t, addr)
+		defer conn.Close()
+
+		recvAll := func(stream interface {
+			Recv() (*api.ChannelEntry, error)
+		}) ([]*api.ChannelEntry, error) {
+			var entries []*api.ChannelEntry
+			for {
+				in, err := stream.Recv()
+				if err == io.EOF {
+					return entries, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				entries = append(entries, in)
+			}
+		}
+		opts := []cmp.Option{
+			cmpopts.IgnoreUnexported(api.ChannelEntry{}),
+			cmpopts.SortSlices(func(x, y *api.ChannelEntry) bool {
+				return x.String() < y.String()
+			}),
+		}
+
+		byKind, err := c.GetChannelEntriesThatProvide(context.TODO(), &api.GetAllProvidersRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster"})
+		require.NoError(t, err)
+		expected, err := recvAll(byKind)
+		require.NoError(t, err)
+		require.NotEmpty(t, expected)
+		byPlural, err := c.GetChannelEntriesThatProvide(context.TODO(), &api.GetAllProvidersRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Plural: "etcdclusters"})
+		require.NoError(t, err)
+		actual, err := recvAll(byPlural)
+		require.NoError(t, err)
+		require.Truef(t, cmp.Equal(expected, actual, opts...), cmp.Diff(expected, actual, opts...))
+
+		latestByKind, err := c.GetLatestChannelEntriesThatProvide(context.TODO(), &api.GetLatestProvidersRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster"})
+		require.NoError(t, err)
+		expected, err = recvAll(latestByKind)
+		require.NoError(t, err)
+		require.Len(t, expected, 3)
+		latestByPlural, err := c.GetLatestChannelEntriesThatProvide(context.TODO(), &api.GetLatestProvidersRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster", Plural: "etcdclusters"})
+		require.NoError(t, err)
+		actual, err = recvAll(latestByPlural)
+		require.NoError(t, err)
+		require.Truef(t, cmp.Equal(expected, actual, opts...), cmp.Diff(expected, actual, opts...))
+
+		bundle, err := c.GetDefaultBundleThatProvides(context.TODO(), &api.GetDefaultProviderRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Plural: "etcdclusters"})
+		require.NoError(t, err)
+		require.Equal(t, "etcdoperator.v0.9.2", bundle.GetCsvName())
+		require.Equal(t, "alpha", bundle.GetChannelName())
+
+		_, err = c.GetDefaultBundleThatProvides(context.TODO(), &api.GetDefaultProviderRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdBackup", Plural: "etcdclusters"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = c.GetDefaultBundleThatProvides(context.TODO(), &api.GetDefaultProviderRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Plural: "etcdwidgets"})
+		require.Error(t, err)
+
+		missing, err := c.GetChannelEntriesThatProvide(context.TODO(), &api.GetAllProvidersRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Plural: "etcdwidgets"})
+		require.NoError(t, err)
+		_, err = recvAll(missing)
+		require.Error(t, err)
+
+		// The kind is used if the plural is not known.
+		bundle, err = c.GetDefaultBundleThatProvides(context.TODO(), &api.GetDefaultProviderRequest{Group: "etcd.database.coreos.com", Version: "v1beta2", Kind: "EtcdCluster", Plural: "etcdwidgets"})
+		require.NoError(t, err)
+		require.Equal(t, "etcdoperator.v0.9.2", bundle.GetCsvName())
+	}
+}
+
 func EqualBundles(t *testing.T, expected, actual api.Bundle) {
 	t.Helper()
 	stripPlural(actual.ProvidedApis)
@@ -269,6 +269,29 @@ func TestQuerierForDirectory(t *testing.T) {
 	require.ElementsMatch(t, expectedDatabaseImages, dbImages)
 }
 
+func TestQuerierForDirectoryGetKindForPlural(t *testing.T) {
+	db, cleanup := CreateTestDb(t)
+	defer cleanup()
+	load, err := NewSQLLiteLoader(db)
+	require.NoError(t, err)
+	require.NoError(t, load.Migrate(context.TODO()))
+
+	loader := NewSQLLoaderForDirectory(load, "../../manifests")
+	require.NoError(t, loader.Populate())
+
+	store := NewSQLLiteQuerierFromDb(db)
+	kind, err := store.GetKindForPlural(context.TODO(), "etcd.database.coreos.com", "v1beta2", "etcdclusters")
+	require.NoError(t, err)
+	require.Equal(t, "EtcdCluster", kind)
+
+	kind, err = store.GetKindForPlural(context.TODO(), "etcd.database.coreos.com", "v1beta2", "etcdbackups")
+	require.NoError(t, err)
+	require.Equal(t, "EtcdBackup", kind)
+
+	_, err = store.GetKindForPlural(context.TODO(), "etcd.database.coreos.com", "v1beta2", "etcdwidgets")
+	require.Error(t, err)
+}
+
 func EqualBundles(t *testing.T, expected, actual api.Bundle) {
 	require.ElementsMatch(t, expected.ProvidedApis, actual.ProvidedApis)
 	require.ElementsMatch(t, expected.RequiredApis, actual.RequiredApis)
@@ -7,8 +7,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/operator-framework/operator-registry/pkg/api"
@@ -586,6 +588,223 @@ func (s *SQLQuerier) GetBundleThatProvides(ctx context.Context, group, apiVersio
 	return out, nil
 }
 
+func (s *SQLQuerier) ListChannelEntries(ctx context.Context, pkgName, channelName string) (entries []*registry.ChannelEntry, err error) {
+	query := `SELECT DISTINCT channel_entry.operatorbundle_name, replaces.operatorbundle_name
+			  FROM channel_entry
+			  LEFT OUTER JOIN channel_entry replaces ON channel_entry.replaces = replaces.entry_id
+              WHERE channel_entry.package_name = ? AND channel_entry.channel_name = ?`
+	rows, err := s.db.QueryContext(ctx, query, pkgName, channelName)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	entries = []*registry.ChannelEntry{}
+
+	for rows.Next() {
+		var bundleNameSQL sql.NullString
+		var replacesSQL sql.NullString
+
+		if err = rows.Scan(&bundleNameSQL, &replacesSQL); err != nil {
+			return
+		}
+		entries = append(entries, &registry.ChannelEntry{
+			PackageName: pkgName,
+			ChannelName: channelName,
+			BundleName:  bundleNameSQL.String,
+			Replaces:    replacesSQL.String,
+		})
+	}
+	if len(entries) == 0 {
+		err = fmt.Errorf("package %s, channel %s not found", pkgName, channelName)
+		return
+	}
+	return
+}
+
+// GetDeprecations returns the deprecations of a package. The sqlite schema
+// does not store olm.deprecations, so a package never has any.
+func (s *SQLQuerier) GetDeprecations(ctx context.Context, pkgName string) (*api.Deprecations, error) {
+	if _, err := s.GetDefaultPackage(ctx, pkgName); err != nil {
+		return nil, err
+	}
+	return &api.Deprecations{PackageName: pkgName}, nil
+}
+
+// GetCatalogInfo returns the number of packages and bundles in the index.
+// The sqlite schema does not store a digest or build time.
+func (s *SQLQuerier) GetCatalogInfo(ctx context.Context) (*api.CatalogInfo, error) {
+	info := &api.CatalogInfo{Backend: "sqlite"}
+	counts := []struct {
+		query string
+		count *int32
+	}{
+		{`SELECT COUNT(*) FROM package`, &info.Packages},
+		{`SELECT COUNT(*) FROM operatorbundle`, &info.Bundles},
+	}
+	for _, c := range counts {
+		rows, err := s.db.QueryContext(ctx, c.query)
+		if err != nil {
+			return nil, err
+		}
+		if rows.Next() {
+			if err := rows.Scan(c.count); err != nil {
+				rows.Close()
+				return nil, err
+			}
+		}
+		rows.Close()
+	}
+	return info, nil
+}
+
+// GetBundlesInRange returns the bundles of a package whose versions are in
+// versionRange, highest version first. A bundle that is in more than one
+// channel is returned from the default channel of its package if it is in
+// it, and from the first of its channels by name otherwise.
+func (s *SQLQuerier) GetBundlesInRange(ctx context.Context, pkgName, versionRange string) ([]*api.Bundle, error) {
+	inRange, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %v", versionRange, err)
+	}
+	if _, err := s.GetDefaultPackage(ctx, pkgName); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT operatorbundle.name, operatorbundle.version, channel_entry.channel_name
+			  FROM operatorbundle
+			  INNER JOIN channel_entry ON operatorbundle.name = channel_entry.operatorbundle_name
+			  INNER JOIN package ON channel_entry.package_name = package.name
+			  WHERE channel_entry.package_name = ?
+			  ORDER BY operatorbundle.name, channel_entry.channel_name != package.default_channel, channel_entry.channel_name`
+	rows, err := s.db.QueryContext(ctx, query, pkgName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type versionedBundle struct {
+		name    string
+		channel string
+		version semver.Version
+	}
+	var matches []versionedBundle
+	for rows.Next() {
+		var name, version, channel sql.NullString
+		if err := rows.Scan(&name, &version, &channel); err != nil {
+			return nil, err
+		}
+		if len(matches) > 0 && matches[len(matches)-1].name == name.String {
+			continue
+		}
+		v, err := semver.Parse(version.String)
+		if err != nil || !inRange(v) {
+			continue
+		}
+		matches = append(matches, versionedBundle{name: name.String, channel: channel.String, version: v})
+	}
+	rows.Close()
+	sort.Slice(matches, func(i, j int) bool {
+		if c := matches[i].version.Compare(matches[j].version); c != 0 {
+			return c > 0
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	bundles := make([]*api.Bundle, 0, len(matches))
+	for _, m := range matches {
+		b, err := s.GetBundle(ctx, pkgName, m.channel, m.name)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, b)
+	}
+	return bundles, nil
+}
+
+// GetBundleByImage returns the bundle whose image is image. The image may
+// also be given as the digest of a digest-pinned bundle image.
+func (s *SQLQuerier) GetBundleByImage(ctx context.Context, image string) (*api.Bundle, error) {
+	query := `SELECT channel_entry.package_name, channel_entry.channel_name, operatorbundle.name
+			  FROM operatorbundle
+			  INNER JOIN channel_entry ON operatorbundle.name = channel_entry.operatorbundle_name
+			  INNER JOIN package ON channel_entry.package_name = package.name
+			  WHERE operatorbundle.bundlepath = ?
+			    OR (instr(operatorbundle.bundlepath, '@') > 0 AND substr(operatorbundle.bundlepath, instr(operatorbundle.bundlepath, '@') + 1) = ?)
+			  ORDER BY channel_entry.channel_name != package.default_channel, channel_entry.channel_name
+			  LIMIT 1`
+	rows, err := s.db.QueryContext(ctx, query, image, image)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, fmt.Errorf("no bundle found with image %s", image)
+	}
+	var pkgName, channelName, bundleName sql.NullString
+	if err := rows.Scan(&pkgName, &channelName, &bundleName); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	return s.GetBundle(ctx, pkgName.String, channelName.String, bundleName.String)
+}
+
+// FindBundles returns the keys of the bundles with a property that matches
+// query, sorted by package, channel and name.
+func (s *SQLQuerier) FindBundles(ctx context.Context, query registry.PropertyQuery) ([]*api.BundleKey, error) {
+	sqlQuery := `SELECT DISTINCT channel_entry.package_name, channel_entry.channel_name, properties.operatorbundle_name, properties.value
+			  FROM properties
+			  INNER JOIN channel_entry ON properties.operatorbundle_name = channel_entry.operatorbundle_name
+			  WHERE properties.type = ?
+			  ORDER BY channel_entry.package_name, channel_entry.channel_name, properties.operatorbundle_name`
+	rows, err := s.db.QueryContext(ctx, sqlQuery, query.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*api.BundleKey{}
+	for rows.Next() {
+		var pkgName, channelName, bundleName, value sql.NullString
+		if err := rows.Scan(&pkgName, &channelName, &bundleName, &value); err != nil {
+			return nil, err
+		}
+		last := len(keys) - 1
+		if last >= 0 && keys[last].PackageName == pkgName.String && keys[last].ChannelName == channelName.String && keys[last].CsvName == bundleName.String {
+			continue
+		}
+		match, err := query.MatchesValue(value.String)
+		if err != nil {
+			return nil, fmt.Errorf("package %s, bundle %s: %v", pkgName.String, bundleName.String, err)
+		}
+		if match {
+			keys = append(keys, &api.BundleKey{PackageName: pkgName.String, ChannelName: channelName.String, CsvName: bundleName.String})
+		}
+	}
+	return keys, nil
+}
+
+// GetKindForPlural returns the kind of the API with the given group, version
+// and resource plural.
+func (s *SQLQuerier) GetKindForPlural(ctx context.Context, group, version, plural string) (string, error) {
+	query := `SELECT DISTINCT kind FROM api WHERE group_name = ? AND version = ? AND plural = ?`
+	rows, err := s.db.QueryContext(ctx, query, group, version, plural)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return "", fmt.Errorf("no api found with group %s, version %s, plural %s", group, version, plural)
+	}
+	var kind sql.NullString
+	if err := rows.Scan(&kind); err != nil {
+		return "", err
+	}
+	return kind.String, nil
+}
+
 func (s *SQLQuerier) ListImages(ctx context.Context) ([]string, error) {
 	query := "SELECT DISTINCT image FROM related_image"
 	rows, err := s.db.QueryContext(ctx, query)
@@ -993,14 +1212,36 @@ SELECT
     LEFT OUTER JOIN merged_properties
       ON operatorbundle.name = merged_properties.bundle_name`
 
+// listBundlesPageOrder orders the result of listBundlesQuery, so that offsets
+// into it are stable between queries. It is only applied when a page of the
+// result is requested.
+const listBundlesPageOrder = `
+  ORDER BY replaces_bundle.package_name, replaces_bundle.channel_name, operatorbundle.name`
+
 func (s *SQLQuerier) SendBundles(ctx context.Context, stream registry.BundleSender) error {
-	rows, err := s.db.QueryContext(ctx, listBundlesQuery, sql.Named("omit_manifests", s.omitManifests))
+	return s.SendFilteredBundles(ctx, stream, registry.BundleFilter{})
+}
+
+func (s *SQLQuerier) SendFilteredBundles(ctx context.Context, stream registry.BundleSender, filter registry.BundleFilter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+	query := listBundlesQuery
+	if filter.Offset > 0 || filter.Limit > 0 {
+		query += listBundlesPageOrder
+	}
+	rows, err := s.db.QueryContext(ctx, query, sql.Named("omit_manifests", s.omitManifests))
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 
+	selected := filter.Selector()
+	sent := 0
 	for rows.Next() {
+		if filter.Limit > 0 && sent == filter.Limit {
+			break
+		}
 		var (
 			entryID     sql.NullInt64
 			bundle      sql.NullString
@@ -1022,6 +1263,9 @@ func (s *SQLQuerier) SendBundles(ctx context.Context, stream registry.BundleSend
 		if !bundleName.Valid || !version.Valid || !bundlePath.Valid || !channelName.Valid {
 			continue
 		}
+		if !selected(pkgName.String, channelName.String) {
+			continue
+		}
 
 		out := &api.Bundle{}
 		if bundle.Valid && bundle.String != "" {
@@ -1057,9 +1301,11 @@ func (s *SQLQuerier) SendBundles(ctx context.Context, stream registry.BundleSend
 		}
 		buildLegacyProvidedAPIs(out.Properties, &out.ProvidedApis)
 		out.Properties = uniqueProps(out.Properties)
+		filter.MaskFields(out)
 		if err := stream.Send(out); err != nil {
 			return err
 		}
+		sent++
 	}
 
 	return nil